@@ -0,0 +1,626 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/google/uuid"
+	"golang.org/x/term"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"os"
+	"path/filepath"
+	"secstorage/internal/api"
+	"secstorage/internal/client/model"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// exit codes of the commands, they are kept stable for scripts
+const (
+	exitOK = iota
+	exitError
+	exitUsage
+	exitAuth
+	exitNotFound
+	exitUnavailable
+)
+
+const commandsUsage = `usage: secstorage [command] [args] [flags]
+
+without a command the interactive mode starts
+
+commands:
+	login [--login name] - log in and keep the session for the other commands, the password is read from SECSTORAGE_PASSWORD or prompted
+	logout - forget the session
+	list [--type lp|file|bc|sn|otp|ssh|tpl] [--collection id] - list resources of the type, lp by default
+	get [id] [--field name] [--reveal] - print the resource or its single field, e.g. --field password, reveal shows hidden fields
+	save-lp --login [login] [--url url]... [--notes text] [--description text] [--collection id] [--password-stdin] - save login password, the password is prompted unless read from stdin
+	upload [path] [--description text] [--parent id] - save the file or attach it to the resource
+	download [id] [--out path] - download the file, to the id in the current directory by default
+	delete [id] - delete the resource
+
+all commands accept --json to print the result as JSON, errors are printed to stderr
+shared resources are opened only when SECSTORAGE_PASSWORD is set
+
+exit codes: 0 - ok, 1 - error, 2 - bad usage, 3 - not logged in or the session expired, 4 - not found, 5 - server unreachable
+`
+
+var errNotLoggedIn = errors.New("not logged in or the session expired, run login")
+var errLoginFailed = errors.New("login failed")
+
+type usageError struct {
+	message string
+}
+
+func (e *usageError) Error() string {
+	return e.message
+}
+
+// command returns the result printed with --json and the text printed otherwise.
+type command func(args []string) (any, string, error)
+
+var commands = map[string]command{
+	"login":    runLogin,
+	"logout":   runLogout,
+	"list":     runList,
+	"get":      runGet,
+	"save-lp":  runSaveLP,
+	"upload":   runUpload,
+	"download": runDownload,
+	"delete":   runDelete,
+}
+
+var listTypes = map[string]api.ResourceType{
+	"lp":   api.LoginPassword,
+	"file": api.File,
+	"bc":   api.BankCard,
+	"sn":   api.SecureNote,
+	"otp":  api.OTP,
+	"ssh":  api.SSHKey,
+	"tpl":  api.Template,
+}
+
+// jsonOutput is set by the --json flag of the running command
+var jsonOutput bool
+
+// runCommand runs the command given in the arguments without the interactive mode and returns the exit code.
+func runCommand(args []string) int {
+	if args[0] == "help" {
+		fmt.Print(commandsUsage)
+		return exitOK
+	}
+	run, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprint(os.Stderr, commandsUsage)
+		return exitUsage
+	}
+	result, text, err := run(args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		fmt.Print(commandsUsage)
+		return exitOK
+	}
+	if err != nil {
+		printError(err)
+		return exitCode(err)
+	}
+	if jsonOutput {
+		if err := json.NewEncoder(os.Stdout).Encode(result); err != nil {
+			printError(err)
+			return exitError
+		}
+		return exitOK
+	}
+	if len(text) != 0 {
+		fmt.Println(text)
+	}
+	return exitOK
+}
+
+func printError(err error) {
+	message := err.Error()
+	if e, ok := status.FromError(err); ok {
+		message = e.Message()
+	}
+	if jsonOutput {
+		_ = json.NewEncoder(os.Stderr).Encode(map[string]string{"error": message})
+		return
+	}
+	fmt.Fprintln(os.Stderr, "error:", message)
+}
+
+func exitCode(err error) int {
+	var usage *usageError
+	if errors.As(err, &usage) {
+		return exitUsage
+	}
+	if errors.Is(err, errNotLoggedIn) || errors.Is(err, errLoginFailed) {
+		return exitAuth
+	}
+	switch statusCode(err) {
+	case codes.Unauthenticated:
+		return exitAuth
+	case codes.NotFound:
+		return exitNotFound
+	case codes.Unavailable:
+		return exitUnavailable
+	}
+	return exitError
+}
+
+// statusCode finds the status in wrapped errors too, status.Code only checks the error itself.
+func statusCode(err error) codes.Code {
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcErr) {
+		return grpcErr.GRPCStatus().Code()
+	}
+	return codes.Unknown
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.BoolVar(&jsonOutput, "json", false, "print the result as JSON")
+	return flags
+}
+
+// parseFlags allows flags after the positional arguments, e.g. get [id] --field password.
+func parseFlags(flags *flag.FlagSet, args []string, count int) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, &usageError{message: err.Error()}
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+	if len(positional) != count {
+		return nil, &usageError{message: fmt.Sprintf("%v expects %v argument(s), got %v", flags.Name(), count, len(positional))}
+	}
+	return positional, nil
+}
+
+func parseId(arg string) (api.ResourceId, error) {
+	id, err := uuid.Parse(arg)
+	if err != nil {
+		return uuid.Nil, &usageError{message: fmt.Sprintf("bad id %q: %v", arg, err)}
+	}
+	return id, nil
+}
+
+func parseOptionalId(arg string) (uuid.NullUUID, error) {
+	if len(arg) == 0 {
+		return uuid.NullUUID{}, nil
+	}
+	id, err := parseId(arg)
+	return uuid.NullUUID{UUID: id, Valid: err == nil}, err
+}
+
+// session is kept between the commands, the password is never stored.
+type session struct {
+	Login     string    `json:"login"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func sessionPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "secstorage", "session"), nil
+}
+
+func saveSession(s *session) error {
+	path, err := sessionPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// restoreSession sets the token of the saved session, keys for shared resources are opened when the password is set.
+func restoreSession() error {
+	path, err := sessionPath()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return errNotLoggedIn
+	}
+	if err != nil {
+		return err
+	}
+	var s session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if time.Now().After(s.ExpiresAt) {
+		return errNotLoggedIn
+	}
+	tokenService.Set(s.Token)
+	if password, ok := os.LookupEnv("SECSTORAGE_PASSWORD"); ok {
+		// without keys only own resources are opened
+		_ = keyService.Init(context.Background(), password)
+	}
+	return nil
+}
+
+func commandPassword(label string) (string, error) {
+	if password, ok := os.LookupEnv("SECSTORAGE_PASSWORD"); ok {
+		return password, nil
+	}
+	return promptSecret(label)
+}
+
+func promptSecret(label string) (string, error) {
+	fmt.Fprint(os.Stderr, label+": ")
+	password, err := term.ReadPassword(syscall.Stdin)
+	fmt.Fprintln(os.Stderr)
+	return string(password), err
+}
+
+func runLogin(args []string) (any, string, error) {
+	flags := newFlagSet("login")
+	login := flags.String("login", "", "login, prompted when empty")
+	if _, err := parseFlags(flags, args, 0); err != nil {
+		return nil, "", err
+	}
+	if len(*login) == 0 {
+		fmt.Fprint(os.Stderr, "login: ")
+		scanner.Scan()
+		*login = strings.TrimSpace(scanner.Text())
+	}
+	password, err := commandPassword("password")
+	if err != nil {
+		return nil, "", err
+	}
+	tokenData, err := authService.Login(context.Background(), *login, password)
+	if err != nil {
+		if statusCode(err) == codes.Unavailable {
+			return nil, "", err
+		}
+		return nil, "", fmt.Errorf("%w: %v", errLoginFailed, err)
+	}
+	s := &session{Login: *login, Token: tokenData.Token, ExpiresAt: tokenData.ExpireAt.AsTime()}
+	if err := saveSession(s); err != nil {
+		return nil, "", err
+	}
+	return s, fmt.Sprintf("logged in as %v until %v", s.Login, s.ExpiresAt.Local().Format(time.RFC3339)), nil
+}
+
+func runLogout(args []string) (any, string, error) {
+	flags := newFlagSet("logout")
+	if _, err := parseFlags(flags, args, 0); err != nil {
+		return nil, "", err
+	}
+	path, err := sessionPath()
+	if err != nil {
+		return nil, "", err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, "", err
+	}
+	return map[string]bool{"loggedOut": true}, "logged out", nil
+}
+
+type listItem struct {
+	Id          api.ResourceId `json:"id"`
+	Description string         `json:"description"`
+	Shared      bool           `json:"shared"`
+}
+
+func runList(args []string) (any, string, error) {
+	flags := newFlagSet("list")
+	typeName := flags.String("type", "lp", "lp, file, bc, sn, otp, ssh, tpl or the type number")
+	collection := flags.String("collection", "", "collection id, the personal vault when empty")
+	if _, err := parseFlags(flags, args, 0); err != nil {
+		return nil, "", err
+	}
+	rType, ok := listTypes[*typeName]
+	if !ok {
+		t, err := strconv.Atoi(*typeName)
+		if err != nil || t <= int(api.Undefined) || t > int(api.Template) {
+			return nil, "", &usageError{message: fmt.Sprintf("unknown type %q", *typeName)}
+		}
+		rType = api.ResourceType(t)
+	}
+	collectionId, err := parseOptionalId(*collection)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := restoreSession(); err != nil {
+		return nil, "", err
+	}
+
+	shortInfos, err := resourceService.ListByUserId(context.Background(), rType, collectionId)
+	if err != nil {
+		return nil, "", err
+	}
+	items := make([]listItem, 0, len(shortInfos))
+	lines := make([]string, 0, len(shortInfos))
+	for i := 0; i < len(shortInfos); i++ {
+		items = append(items, listItem{Id: shortInfos[i].Id, Description: shortInfos[i].Meta, Shared: shortInfos[i].Shared})
+		line := fmt.Sprintf("%v\t%v", shortInfos[i].Id, shortInfos[i].Meta)
+		if shortInfos[i].Shared {
+			line += "\tshared"
+		}
+		lines = append(lines, line)
+	}
+	return items, strings.Join(lines, "\n"), nil
+}
+
+type getResult struct {
+	Id          api.ResourceId `json:"id"`
+	Description string         `json:"description"`
+	Data        model.Resource `json:"data"`
+	Attachments []listItem     `json:"attachments"`
+}
+
+type fieldResult struct {
+	Id    api.ResourceId `json:"id"`
+	Field string         `json:"field"`
+	Value string         `json:"value"`
+}
+
+func runGet(args []string) (any, string, error) {
+	flags := newFlagSet("get")
+	field := flags.String("field", "", "print only the field, e.g. login, password or the name of the custom field")
+	reveal := flags.Bool("reveal", false, "show hidden custom fields")
+	positional, err := parseFlags(flags, args, 1)
+	if err != nil {
+		return nil, "", err
+	}
+	id, err := parseId(positional[0])
+	if err != nil {
+		return nil, "", err
+	}
+	if err := restoreSession(); err != nil {
+		return nil, "", err
+	}
+
+	data, meta, attachments, err := resourceService.GetWithAttachments(context.Background(), id)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(*field) != 0 {
+		value, err := fieldValue(data, *field)
+		if err != nil {
+			return nil, "", err
+		}
+		return &fieldResult{Id: id, Field: *field, Value: value}, value, nil
+	}
+
+	result := &getResult{Id: id, Description: string(meta), Data: data, Attachments: make([]listItem, 0, len(attachments))}
+	text := strings.TrimPrefix(data.Print(string(meta)), "\n")
+	if _, ok := data.(*model.Template); !ok {
+		text += model.PrintFields(data.GetFields(), *reveal)
+	}
+	if len(attachments) != 0 {
+		text += "\nattachments:"
+	}
+	for i := 0; i < len(attachments); i++ {
+		result.Attachments = append(result.Attachments, listItem{Id: attachments[i].Id, Description: attachments[i].Meta})
+		text += fmt.Sprintf("\n  id: %v - %v", attachments[i].Id, attachments[i].Meta)
+	}
+	return result, text, nil
+}
+
+// fieldValue returns the field of the resource by its JSON name or the custom field by its name,
+// strings are returned as they are and other values as JSON.
+func fieldValue(resource model.Resource, name string) (string, error) {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return "", err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", err
+	}
+	if raw, ok := fields[name]; ok && name != "fields" {
+		var value string
+		if err := json.Unmarshal(raw, &value); err == nil {
+			return value, nil
+		}
+		return string(raw), nil
+	}
+	custom := resource.GetFields()
+	for i := 0; i < len(custom); i++ {
+		if custom[i].Name == name {
+			return custom[i].Value, nil
+		}
+	}
+	return "", status.Errorf(codes.NotFound, "the resource has no field %q", name)
+}
+
+// stringsFlag collects the values of the flag repeated several times.
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, " ")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+type savedResult struct {
+	Id api.ResourceId `json:"id"`
+}
+
+func runSaveLP(args []string) (any, string, error) {
+	flags := newFlagSet("save-lp")
+	login := flags.String("login", "", "login of the site")
+	var urls stringsFlag
+	flags.Var(&urls, "url", "website URL, repeat for several URLs, https:// is added to bare domains")
+	notes := flags.String("notes", "", "notes")
+	description := flags.String("description", "", "description shown in the list")
+	collection := flags.String("collection", "", "collection id, the personal vault when empty")
+	passwordStdin := flags.Bool("password-stdin", false, "read the password from stdin instead of prompting")
+	if _, err := parseFlags(flags, args, 0); err != nil {
+		return nil, "", err
+	}
+	if len(*login) == 0 {
+		return nil, "", &usageError{message: "save-lp expects --login"}
+	}
+	collectionId, err := parseOptionalId(*collection)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := restoreSession(); err != nil {
+		return nil, "", err
+	}
+
+	var password string
+	if *passwordStdin {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, "", err
+		}
+		password = strings.TrimRight(string(data), "\r\n")
+	} else {
+		password, err = promptSecret("password to save")
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	lp := model.NewLoginPassword(*login, password)
+	for _, u := range urls {
+		lp.URLs = append(lp.URLs, withScheme(u))
+	}
+	lp.Notes = *notes
+
+	id, err := resourceService.Save(context.Background(), lp, []byte(*description), collectionId)
+	if err != nil {
+		return nil, "", err
+	}
+	return &savedResult{Id: id}, id.String(), nil
+}
+
+func runUpload(args []string) (any, string, error) {
+	flags := newFlagSet("upload")
+	description := flags.String("description", "", "description shown in the list, the file name when empty")
+	parent := flags.String("parent", "", "id of the resource to attach the file to")
+	positional, err := parseFlags(flags, args, 1)
+	if err != nil {
+		return nil, "", err
+	}
+	path := positional[0]
+	parentId, err := parseOptionalId(*parent)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return nil, "", err
+	}
+	if len(*description) == 0 {
+		*description = filepath.Base(path)
+	}
+	if err := restoreSession(); err != nil {
+		return nil, "", err
+	}
+
+	var id api.ResourceId
+	if parentId.Valid {
+		id, err = resourceService.SaveAttachment(context.Background(), parentId.UUID, *description, path)
+	} else {
+		id, err = resourceService.SaveFile(context.Background(), *description, path)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return &savedResult{Id: id}, id.String(), nil
+}
+
+func runDownload(args []string) (any, string, error) {
+	flags := newFlagSet("download")
+	out := flags.String("out", "", "path to save the file to, the id in the current directory when empty")
+	positional, err := parseFlags(flags, args, 1)
+	if err != nil {
+		return nil, "", err
+	}
+	id, err := parseId(positional[0])
+	if err != nil {
+		return nil, "", err
+	}
+	if len(*out) == 0 {
+		*out = id.String()
+	}
+	if err := restoreSession(); err != nil {
+		return nil, "", err
+	}
+
+	path, err := resourceService.GetFile(context.Background(), id)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := moveFile(path, *out); err != nil {
+		return nil, "", err
+	}
+	return map[string]string{"path": *out}, *out, nil
+}
+
+// moveFile renames the downloaded file, it is copied when the temp dir is on another device.
+func moveFile(src, dst string) error {
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Remove(src)
+}
+
+func runDelete(args []string) (any, string, error) {
+	flags := newFlagSet("delete")
+	positional, err := parseFlags(flags, args, 1)
+	if err != nil {
+		return nil, "", err
+	}
+	id, err := parseId(positional[0])
+	if err != nil {
+		return nil, "", err
+	}
+	if err := restoreSession(); err != nil {
+		return nil, "", err
+	}
+
+	if err := resourceService.Delete(context.Background(), id); err != nil {
+		return nil, "", err
+	}
+	return map[string]string{"deleted": id.String()}, "deleted", nil
+}
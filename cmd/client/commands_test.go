@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"secstorage/internal/api"
+	"secstorage/internal/client/model"
+	"testing"
+)
+
+func TestParseFlags_AfterArguments(t *testing.T) {
+	flags := newFlagSet("get")
+	field := flags.String("field", "", "")
+	positional, err := parseFlags(flags, []string{"id", "--field", "password", "--json"}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id"}, positional)
+	assert.Equal(t, "password", *field)
+	assert.True(t, jsonOutput)
+
+	_, err = parseFlags(newFlagSet("get"), []string{"id", "other"}, 1)
+	assert.Equal(t, exitUsage, exitCode(err))
+	_, err = parseFlags(newFlagSet("get"), []string{"--unknown"}, 0)
+	assert.Equal(t, exitUsage, exitCode(err))
+}
+
+func TestFieldValue(t *testing.T) {
+	lp := model.NewLoginPassword("login", "secret")
+	lp.URLs = []string{"example.com"}
+	lp.SetFields([]model.CustomField{{Name: "pin", Type: api.Hidden, Value: "1234"}})
+
+	value, err := fieldValue(lp, "password")
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", value)
+	value, err = fieldValue(lp, "urls")
+	assert.NoError(t, err)
+	assert.Equal(t, `["example.com"]`, value)
+	value, err = fieldValue(lp, "pin")
+	assert.NoError(t, err)
+	assert.Equal(t, "1234", value)
+	_, err = fieldValue(lp, "missing")
+	assert.Equal(t, exitNotFound, exitCode(err))
+}
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, exitAuth, exitCode(errNotLoggedIn))
+	assert.Equal(t, exitAuth, exitCode(fmt.Errorf("%w: wrong password", errLoginFailed)))
+	assert.Equal(t, exitAuth, exitCode(status.Error(codes.Unauthenticated, "expired")))
+	assert.Equal(t, exitNotFound, exitCode(fmt.Errorf("get: %w", status.Error(codes.NotFound, "not found"))))
+	assert.Equal(t, exitUnavailable, exitCode(status.Error(codes.Unavailable, "down")))
+	// a forbidden action on the resource of another user is not a session problem
+	assert.Equal(t, exitError, exitCode(status.Error(codes.PermissionDenied, "forbidden")))
+	assert.Equal(t, exitError, exitCode(status.Error(codes.Internal, "internal error")))
+}
+
+func TestWithScheme(t *testing.T) {
+	assert.Equal(t, "https://example.com", withScheme("example.com"))
+	assert.Equal(t, "http://example.com/login", withScheme("http://example.com/login"))
+}
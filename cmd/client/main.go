@@ -3,24 +3,35 @@ package main
 import (
 	"bufio"
 	"context"
-	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
 	"golang.org/x/term"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 	"os"
+	"path/filepath"
 	"secstorage/internal/api"
 	pb "secstorage/internal/api/proto"
+	"secstorage/internal/client/cache"
 	"secstorage/internal/client/interceptors"
+	"secstorage/internal/client/keys"
 	"secstorage/internal/client/model"
+	"secstorage/internal/client/qr"
 	"secstorage/internal/client/services"
+	"secstorage/internal/client/sshagent"
+	"secstorage/internal/fileutil"
 	. "secstorage/internal/logger"
+	"secstorage/internal/otp"
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 )
 
 var (
@@ -30,8 +41,20 @@ var (
 
 var authService *services.AuthService
 var resourceService *services.ResourceService
+var keyService *services.KeyService
+var shareService *services.ShareService
+var orgService *services.OrgService
+var sendService *services.SendService
+var emergencyService *services.EmergencyService
+var auditService *services.AuditService
+
+// currentCollection is the organization collection used by save and list instead of the personal vault
+var currentCollection uuid.NullUUID
 var scanner = makeScanner()
 var tokenService = &services.TokenService{}
+var agentServer *sshagent.Server
+
+var receive = flag.String("receive", "", "open the send link without login and exit")
 
 func main() {
 	creds, err := credentials.NewClientTLSFromFile("cert/service.pem", "")
@@ -56,10 +79,36 @@ func main() {
 	}(con)
 
 	authService = services.NewAuthService(pb.NewAuthClient(con), tokenService)
-	resourceService = services.NewResourceService(pb.NewResourcesClient(con), os.TempDir())
+	keyService = services.NewKeyService(pb.NewSharesClient(con))
+	resourceService = services.NewResourceService(pb.NewResourcesClient(con), keyService, os.TempDir(), true, fileutil.MaxChunkSize)
+	shareService = services.NewShareService(pb.NewSharesClient(con), pb.NewResourcesClient(con), keyService)
+	orgService = services.NewOrgService(pb.NewOrganizationsClient(con))
+	sendService = services.NewSendService(pb.NewSendsClient(con))
+	emergencyService = services.NewEmergencyService(pb.NewEmergencyClient(con))
+	auditService = services.NewAuditService(pb.NewAuditClient(con))
+
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), commandsUsage+"\nflags:\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 0 {
+		code := runCommand(flag.Args())
+		_ = con.Close()
+		os.Exit(code)
+	}
+	if *receive != "" {
+		result, err := handleReceive([]string{*receive})
+		if err != nil {
+			fmt.Println("ERR:", err)
+			os.Exit(1)
+		}
+		fmt.Println(result)
+		return
+	}
 
 	startLoop(loginRegisterInitMsg, initAuth)
-	infinityLoop(saveInitMsg, processUI)
+	infinityLoop(emergencyNotice()+saveInitMsg, processUI)
 }
 
 func clear(msg string) {
@@ -70,8 +119,9 @@ func clear(msg string) {
 }
 
 var loginRegisterInitMsg = `
-login - to login
+login - to login, without the server resources fetched before are available and changes are sent later
 register - to register
+recover - to set a new password with the recovery key
 `
 
 func initAuth(input string) error {
@@ -79,18 +129,172 @@ func initAuth(input string) error {
 	case "login":
 		login := readString("input login")
 		password := readPassword()
-		_, err := authService.Login(context.Background(), login, password)
-		return err
+		if _, err := authService.Login(context.Background(), login, password); err != nil {
+			if status.Code(err) == codes.Unavailable {
+				return loginOffline(login, password)
+			}
+			return err
+		}
+		if err := keyService.Init(context.Background(), password); err != nil {
+			return err
+		}
+		openCache(login, password)
+		go keepSynced(login, password, true)
+		return nil
 
 	case "register":
 		login := readString("input login")
 		password := readPassword()
-		_, err := authService.Register(context.Background(), login, password)
-		return err
+		if _, err := authService.Register(context.Background(), login, password); err != nil {
+			return err
+		}
+		if err := keyService.Init(context.Background(), password); err != nil {
+			return err
+		}
+		openCache(login, password)
+		go keepSynced(login, password, true)
+		if err := showRecoveryKey(password); err != nil {
+			// the account is already created, the key can be set up later with the recovery command
+			Log.Error("failed to set up recovery key", zap.Error(err))
+		}
+		return nil
+
+	case "recover":
+		login := readString("input login")
+		recoveryKey := readSecret("recovery key")
+		password := readSecret("new password")
+		if err := authService.Recover(context.Background(), login, recoveryKey, password, keyService); err != nil {
+			return err
+		}
+		openCache(login, password)
+		go keepSynced(login, password, true)
+		return nil
 	}
 	return errors.New("bad args")
 }
 
+const syncInterval = 10 * time.Second
+
+// openCache opens the local cache after the server checked the password, a cache sealed
+// with another password, e.g. before recovery, is sealed with the new one. The client works without the cache.
+func openCache(login, password string) {
+	path, err := cache.Path(login)
+	if err != nil {
+		Log.Error("failed to find the cache path", zap.Error(err))
+		return
+	}
+	c, err := cache.Open(path, password)
+	if errors.Is(err, os.ErrNotExist) {
+		c, err = cache.New(path, password)
+	}
+	if errors.Is(err, keys.ErrDecrypt) {
+		c, err = resealCache(path, password)
+	}
+	if err != nil {
+		Log.Error("failed to open the cache", zap.Error(err))
+		return
+	}
+	resourceService.SetCache(c)
+}
+
+// resealCache asks for the previous password to keep the cache with writes made offline,
+// the cache is replaced only when the user drops it explicitly.
+func resealCache(path, password string) (*cache.Cache, error) {
+	for {
+		previous := readSecret(`offline data is sealed with the previous password, input it to keep the data or "discard" to drop unsent changes`)
+		if previous == "discard" {
+			return cache.New(path, password)
+		}
+		c, err := cache.Reseal(path, previous, password)
+		if !errors.Is(err, keys.ErrDecrypt) {
+			return c, err
+		}
+		fmt.Println("the password doesn't open the offline data")
+	}
+}
+
+// loginOffline serves resources from the cache while the server is unreachable, the password is checked by opening the cache.
+func loginOffline(login, password string) error {
+	path, err := cache.Path(login)
+	if err != nil {
+		return err
+	}
+	c, err := cache.Open(path, password)
+	if errors.Is(err, os.ErrNotExist) {
+		return errors.New("the server is unreachable and there is no offline data for the user")
+	}
+	if errors.Is(err, keys.ErrDecrypt) {
+		return errors.New("the server is unreachable and the password doesn't open the offline data")
+	}
+	if err != nil {
+		return err
+	}
+	resourceService.SetCache(c)
+	resourceService.SetOffline()
+	go keepSynced(login, password, false)
+	return nil
+}
+
+// keepSynced logs in when the server is reachable again after the offline login,
+// replays writes made offline and brings the cache up to date.
+func keepSynced(login, password string, loggedIn bool) {
+	ticker := time.NewTicker(syncInterval)
+	defer ticker.Stop()
+	for ; true; <-ticker.C {
+		ctx := context.Background()
+		if !loggedIn {
+			_, err := authService.Login(ctx, login, password)
+			if status.Code(err) == codes.Unavailable {
+				continue
+			}
+			if err == nil {
+				err = keyService.Init(ctx, password)
+			}
+			if err != nil {
+				fmt.Println("* the server is reachable, but login failed, login again: " + err.Error())
+				return
+			}
+			loggedIn = true
+			fmt.Println("* the server is reachable again")
+		}
+		if !tokenService.Unlocked() {
+			continue
+		}
+		replayed, failed, err := resourceService.Replay(ctx)
+		if replayed != 0 {
+			fmt.Printf("* %v changes made offline are saved\n", replayed)
+		}
+		for i := 0; i < len(failed); i++ {
+			fmt.Println("* change made offline is not applied: " + failed[i].Error())
+		}
+		if err != nil {
+			continue
+		}
+		if err := resourceService.SyncCache(ctx); err != nil && status.Code(err) != codes.Unavailable {
+			Log.Error("failed to sync the cache", zap.Error(err))
+		}
+	}
+}
+
+// offlineNote tells the user the result is from the local cache.
+func offlineNote() string {
+	if !resourceService.Offline() {
+		return ""
+	}
+	return fmt.Sprintf("offline, showing the local copy, changes waiting for the server: %v\n", resourceService.PendingCount())
+}
+
+// showRecoveryKey sets up a new recovery key and shows it once.
+func showRecoveryKey(password string) error {
+	printed, err := authService.SetupRecovery(context.Background(), password, keyService)
+	if err != nil {
+		return err
+	}
+	clear(fmt.Sprintf("your recovery key, write it down and keep it safe, it is shown only once:\n\n%v\n", printed))
+	readString("press enter when done")
+	return nil
+}
+
 func startLoop(initMsg string, handler func(string) error) {
 	clear(fmt.Sprintf("%v\n%v", printBuildInfo(buildVersion, buildDate), initMsg))
 	for {
@@ -142,10 +346,113 @@ func processUI(input string) (string, error) {
 
 	case "getf":
 		return handleGetFile(args)
+
+	case "attach":
+		return handleAttach(args)
+
+	case "usage":
+		return handleUsage()
+
+	case "match":
+		return handleMatch(args)
+
+	case "share":
+		return handleShare(args)
+
+	case "unshare":
+		return handleUnshare(args)
+
+	case "grants":
+		return handleGrants(args)
+
+	case "agent":
+		return handleAgent(args)
+
+	case "org":
+		return handleOrg(args)
+
+	case "coll":
+		return handleCollection(args)
+
+	case "use":
+		return handleUse(args)
+
+	case "send":
+		return handleSend(args)
+
+	case "sends":
+		return handleSends()
+
+	case "unsend":
+		if len(args) == 0 {
+			return "", errors.New("bad args")
+		}
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			return "", err
+		}
+		if err := sendService.Delete(context.Background(), id); err != nil {
+			return "", err
+		}
+		return "deleted", nil
+
+	case "receive":
+		return handleReceive(args)
+
+	case "recovery":
+		if err := showRecoveryKey(readSecret("input current password")); err != nil {
+			return "", err
+		}
+		return "new recovery key is set, the previous one doesn't work anymore", nil
+
+	case "trust":
+		return handleTrust(args)
+
+	case "emergency":
+		return handleEmergency(args)
+
+	case "audit":
+		return handleAudit(args)
+
+	case "watch":
+		return handleWatch(args)
+
+	case "edit":
+		return handleEdit(args)
+
+	case "conflicts":
+		return handleConflicts(args)
+
+	case "lock":
+		tokenService.Lock()
+		return "locked", nil
+
+	case "unlock":
+		if err := authService.Unlock(context.Background(), readPassword()); err != nil {
+			return "", err
+		}
+		return "unlocked", nil
 	}
 	return "", errors.New("bad args")
 }
 
+func handleAttach(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", errors.New("bad args")
+	}
+	parentId, err := uuid.Parse(args[0])
+	if err != nil {
+		return "", err
+	}
+	path := readString("file path")
+	description := readString("description")
+	id, err := resourceService.SaveAttachment(context.Background(), parentId, description, path)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("attached, id: %v", id), nil
+}
+
 func handleGetFile(args []string) (string, error) {
 	id, err := uuid.Parse(args[0])
 	if err != nil {
@@ -161,24 +468,661 @@ func handleGetFile(args []string) (string, error) {
 var saveInitMsg = `
 save lp - save login password
 save bc - save bank card
+save sn - save secure note
+save otp - save TOTP authenticator from otpauth URI or QR image
+save ssh - save SSH private key
+save tpl - save template with custom fields
+save [lp|bc|sn|otp|ssh] [template id] - save filling fields of the template
 save fl - save file
-del [id] - delete by id
-list [type:1,2,3] - 1 - LoginPassword, 2 - File, 3 - BankCard 
-get [id] - get loginPassword or BankCard by id
+attach [id] - attach file to the resource
+share [id] [login] [r|rw] - share with the user for reading or reading and writing
+unshare [id] [login] - stop sharing with the user
+grants [id] - list users the resource is shared with
+del [id] - delete by id, attachments are deleted too, shared with you are only removed from your list
+list [type:1,2,3,4,5,6,7] - 1 - LoginPassword, 2 - File, 3 - BankCard, 4 - SecureNote, 5 - OTP, 6 - SSHKey, 7 - Template
+match [url] - find login passwords for the site
+get [id] [reveal] - get loginPassword, BankCard, SecureNote, SSHKey, Template or current OTP code by id, reveal shows hidden fields
 getf [id] - get file
+usage - show used storage and limits
+agent [socket path] - serve stored SSH keys as ssh-agent
+agent stop - stop ssh-agent
+org new [name] - create organization
+org list - list organizations and invitations
+org invite [org id] [login] [ro|member|admin|owner] - invite the user
+org accept [org id] - accept the invitation
+org role [org id] [login] [ro|member|admin|owner] - change role of the member
+org remove [org id] [login] - remove the member, remove yourself to leave
+org members [org id] - list members
+coll new [org id] [name] - create collection
+coll list [org id] - list collections
+use [collection id] - save and list in the collection, use without id returns to the personal vault
+send [id|text] [views] [hours] - make a link to the resource or typed text, opened at most views times (1 by default) within hours (24 by default)
+sends - list active sends
+unsend [id] - delete the send
+receive [link] - open the send link, also available without login with -receive flag
+recovery - generate a new recovery key
+trust [login] [hours] - make the user a trusted contact who gets access to your vault hours after requesting it
+emergency - list trusted contacts and users who trust you
+emergency [request|approve|reject|remove] [id] - request access, approve or reject the request, remove the contact
+emergency list [vault id] [type] - list resources of the vault you have emergency access to
+watch - print changes of your resources made from other devices as they happen
+watch stop - stop printing changes
+edit [id] - enter the resource again keeping its custom fields, a change made over a newer version becomes a conflict
+conflicts - list changes made over newer versions, e.g. on another device while offline
+conflicts [keep|take|merge] [conflict id] - keep the current version, take the conflicting one or choose field by field
+audit [count] [before] - list your events and events on your resources, latest first or before the event number
+lock - lock session, ssh-agent stops signing
+unlock - unlock session
 `
 
+func handleShare(args []string) (string, error) {
+	if len(args) < 2 {
+		return "", errors.New("bad args")
+	}
+	id, err := uuid.Parse(args[0])
+	if err != nil {
+		return "", err
+	}
+	permission := api.Read
+	if len(args) > 2 && args[2] == "rw" {
+		permission = api.ReadWrite
+	}
+	if err := shareService.Share(context.Background(), id, args[1], permission); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("shared with %v", args[1]), nil
+}
+
+func handleUnshare(args []string) (string, error) {
+	if len(args) < 2 {
+		return "", errors.New("bad args")
+	}
+	id, err := uuid.Parse(args[0])
+	if err != nil {
+		return "", err
+	}
+	if err := shareService.Unshare(context.Background(), id, args[1]); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("unshared with %v", args[1]), nil
+}
+
+func handleGrants(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", errors.New("bad args")
+	}
+	id, err := uuid.Parse(args[0])
+	if err != nil {
+		return "", err
+	}
+	grants, err := shareService.Grants(context.Background(), id)
+	if err != nil {
+		return "", err
+	}
+	var writer strings.Builder
+	for i := 0; i < len(grants); i++ {
+		writer.WriteString(grants[i].Print() + "\n")
+	}
+	return writer.String(), nil
+}
+
+func handleOrg(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", errors.New("bad args")
+	}
+	if args[0] == "new" {
+		if len(args) < 2 {
+			return "", errors.New("bad args")
+		}
+		id, err := orgService.Create(context.Background(), strings.Join(args[1:], " "))
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("created, id: %v", id), nil
+	}
+	if args[0] == "list" {
+		orgs, err := orgService.List(context.Background())
+		if err != nil {
+			return "", err
+		}
+		var writer strings.Builder
+		for i := 0; i < len(orgs); i++ {
+			writer.WriteString(orgs[i].Print() + "\n")
+		}
+		return writer.String(), nil
+	}
+
+	if len(args) < 2 {
+		return "", errors.New("bad args")
+	}
+	orgId, err := uuid.Parse(args[1])
+	if err != nil {
+		return "", err
+	}
+	switch args[0] {
+	case "accept":
+		if err := orgService.Accept(context.Background(), orgId); err != nil {
+			return "", err
+		}
+		return "joined", nil
+
+	case "members":
+		members, err := orgService.Members(context.Background(), orgId)
+		if err != nil {
+			return "", err
+		}
+		var writer strings.Builder
+		for i := 0; i < len(members); i++ {
+			writer.WriteString(members[i].Print() + "\n")
+		}
+		return writer.String(), nil
+
+	case "remove":
+		if len(args) < 3 {
+			return "", errors.New("bad args")
+		}
+		if err := orgService.Remove(context.Background(), orgId, args[2]); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%v removed", args[2]), nil
+
+	case "invite", "role":
+		if len(args) < 4 {
+			return "", errors.New("bad args")
+		}
+		role, ok := model.ParseRole(args[3])
+		if !ok {
+			return "", errors.New("unknown role")
+		}
+		if args[0] == "role" {
+			err = orgService.SetRole(context.Background(), orgId, args[2], role)
+		} else {
+			err = orgService.Invite(context.Background(), orgId, args[2], role)
+		}
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%v is %v", args[2], model.RoleName(role)), nil
+	}
+	return "", errors.New("bad args")
+}
+
+func handleCollection(args []string) (string, error) {
+	if len(args) < 2 {
+		return "", errors.New("bad args")
+	}
+	orgId, err := uuid.Parse(args[1])
+	if err != nil {
+		return "", err
+	}
+	switch args[0] {
+	case "new":
+		if len(args) < 3 {
+			return "", errors.New("bad args")
+		}
+		id, err := orgService.CreateCollection(context.Background(), orgId, strings.Join(args[2:], " "))
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("created, id: %v", id), nil
+
+	case "list":
+		collections, err := orgService.Collections(context.Background(), orgId)
+		if err != nil {
+			return "", err
+		}
+		var writer strings.Builder
+		for i := 0; i < len(collections); i++ {
+			writer.WriteString(collections[i].Print() + "\n")
+		}
+		return writer.String(), nil
+	}
+	return "", errors.New("bad args")
+}
+
+func handleUse(args []string) (string, error) {
+	if len(args) == 0 || args[0] == "" {
+		currentCollection = uuid.NullUUID{}
+		return "using personal vault", nil
+	}
+	id, err := uuid.Parse(args[0])
+	if err != nil {
+		return "", err
+	}
+	currentCollection = uuid.NullUUID{UUID: id, Valid: true}
+	return fmt.Sprintf("using collection %v", id), nil
+}
+
+func handleSend(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", errors.New("bad args")
+	}
+	maxViews, hours := 1, 24
+	var err error
+	if len(args) > 1 {
+		if maxViews, err = strconv.Atoi(args[1]); err != nil {
+			return "", err
+		}
+	}
+	if len(args) > 2 {
+		if hours, err = strconv.Atoi(args[2]); err != nil {
+			return "", err
+		}
+	}
+
+	var resource model.Resource
+	var meta []byte
+	if args[0] == "text" {
+		resource, meta = model.NewSecureNote(readString("title"), readMultiline("text")), nil
+	} else {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			return "", err
+		}
+		if resource, meta, err = resourceService.Get(context.Background(), id); err != nil {
+			return "", err
+		}
+	}
+	link, err := sendService.Send(context.Background(), resource, meta, maxViews, time.Duration(hours)*time.Hour)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("link, anyone with it can open the secret:\n%v", link), nil
+}
+
+func handleSends() (string, error) {
+	sends, err := sendService.List(context.Background())
+	if err != nil {
+		return "", err
+	}
+	var writer strings.Builder
+	for i := 0; i < len(sends); i++ {
+		writer.WriteString(sends[i].Print() + "\n")
+	}
+	return writer.String(), nil
+}
+
+func handleReceive(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", errors.New("bad args")
+	}
+	resource, meta, viewsLeft, err := sendService.Open(context.Background(), args[0])
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%v%v\nviews left: %v", resource.Print(string(meta)), model.PrintFields(resource.GetFields(), true), viewsLeft), nil
+}
+
+// emergencyNotice warns the user about requests for access to the vault.
+func emergencyNotice() string {
+	requests, err := emergencyService.PendingRequests(context.Background(), authService.CurrentLogin())
+	if err != nil || len(requests) == 0 {
+		return ""
+	}
+	var writer strings.Builder
+	writer.WriteString("\nWARNING: emergency access to your vault is requested, reject it if it is unexpected:\n")
+	for i := 0; i < len(requests); i++ {
+		writer.WriteString(requests[i].Print(authService.CurrentLogin()) + "\n")
+	}
+	return writer.String()
+}
+
+var stopWatch context.CancelFunc
+
+func handleWatch(args []string) (string, error) {
+	if len(args) != 0 && args[0] == "stop" {
+		if stopWatch == nil {
+			return "", errors.New("not watching")
+		}
+		stopWatch()
+		stopWatch = nil
+		return "stopped watching", nil
+	}
+	if stopWatch != nil {
+		return "", errors.New("already watching")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	stopWatch = cancel
+	go func() {
+		started := false
+		err := resourceService.Watch(ctx, func(change model.ResourceChange) {
+			// every stream starts with a reset, only the ones after reconnects matter
+			if change.Kind == api.ChangeReset && !started {
+				started = true
+				return
+			}
+			fmt.Println("* " + change.Print())
+		})
+		if err != nil {
+			fmt.Println("* watching stopped: " + err.Error())
+		}
+	}()
+	return "watching changes", nil
+}
+
+func handleEdit(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", errors.New("bad args")
+	}
+	id, err := uuid.Parse(args[0])
+	if err != nil {
+		return "", err
+	}
+	current, meta, revision, err := resourceService.GetRevision(context.Background(), id)
+	if err != nil {
+		return "", err
+	}
+	resource, description, err := readResource(current)
+	if err != nil {
+		return "", err
+	}
+	if len(description) == 0 {
+		description = string(meta)
+	}
+	resource.SetFields(current.GetFields())
+	if lp, ok := resource.(*model.LoginPassword); ok {
+		lp.KeepPasswordAge(current.(*model.LoginPassword))
+	}
+
+	revision, conflictId, err := resourceService.Update(context.Background(), id, resource, []byte(description), revision)
+	if err != nil {
+		return "", err
+	}
+	if conflictId.Valid {
+		return fmt.Sprintf("the resource was changed meanwhile, your version is kept as conflict %v", conflictId.UUID), nil
+	}
+	if resourceService.Offline() {
+		return offlineNote() + "updated locally, the change is sent when the server is reachable", nil
+	}
+	return fmt.Sprintf("updated, revision: %v", revision), nil
+}
+
+// readResource reads a new version of the resource of the same type.
+func readResource(current model.Resource) (model.Resource, string, error) {
+	switch current.(type) {
+	case *model.LoginPassword:
+		return readLoginPassword()
+	case *model.BankCard:
+		resource, description := readBankCard()
+		return resource, description, nil
+	case *model.SecureNote:
+		resource, description := readSecureNote()
+		return resource, description, nil
+	case *model.OTP:
+		return readOTP()
+	case *model.SSHKey:
+		return readSSHKey()
+	case *model.Template:
+		return readTemplate()
+	}
+	return nil, "", fmt.Errorf("%T can't be edited", current)
+}
+
+func handleConflicts(args []string) (string, error) {
+	conflicts, err := resourceService.ListConflicts(context.Background())
+	if err != nil {
+		return "", err
+	}
+	if len(args) == 0 {
+		var writer strings.Builder
+		for i := 0; i < len(conflicts); i++ {
+			writer.WriteString(conflicts[i].Print() + "\n\n")
+		}
+		return writer.String(), nil
+	}
+	if len(args) < 2 {
+		return "", errors.New("bad args")
+	}
+	id, err := uuid.Parse(args[1])
+	if err != nil {
+		return "", err
+	}
+	var conflict *model.Conflict
+	for i := 0; i < len(conflicts); i++ {
+		if conflicts[i].Id == id {
+			conflict = &conflicts[i]
+		}
+	}
+	if conflict == nil {
+		return "", errors.New("conflict not found")
+	}
+
+	var resolved model.Resource
+	meta := conflict.CurrentMeta
+	switch args[0] {
+	case "keep":
+	case "take":
+		resolved, meta = conflict.Conflicting, conflict.ConflictingMeta
+	case "merge":
+		choose := func(name, current, conflicting string) bool {
+			return readString(fmt.Sprintf("%v differs\n1 - current: %v\n2 - conflicting: %v\nchoose 1 or 2", name, current, conflicting)) == "2"
+		}
+		if resolved, err = model.Merge(conflict.Current, conflict.Conflicting, choose); err != nil {
+			return "", err
+		}
+		if meta != conflict.ConflictingMeta && choose("description", meta, conflict.ConflictingMeta) {
+			meta = conflict.ConflictingMeta
+		}
+	default:
+		return "", errors.New("bad args")
+	}
+	if err := resourceService.ResolveConflict(context.Background(), conflict, resolved, []byte(meta)); err != nil {
+		return "", err
+	}
+	return "resolved", nil
+}
+
+func handleAudit(args []string) (string, error) {
+	var limit int
+	var before int64
+	var err error
+	if len(args) > 0 {
+		if limit, err = strconv.Atoi(args[0]); err != nil {
+			return "", err
+		}
+	}
+	if len(args) > 1 {
+		if before, err = strconv.ParseInt(args[1], 10, 64); err != nil {
+			return "", err
+		}
+	}
+	events, err := auditService.List(context.Background(), before, limit)
+	if err != nil {
+		return "", err
+	}
+	var writer strings.Builder
+	for i := 0; i < len(events); i++ {
+		writer.WriteString(events[i].Print() + "\n")
+	}
+	return writer.String(), nil
+}
+
+func handleTrust(args []string) (string, error) {
+	if len(args) < 2 {
+		return "", errors.New("bad args")
+	}
+	hours, err := strconv.Atoi(args[1])
+	if err != nil {
+		return "", err
+	}
+	id, err := emergencyService.Add(context.Background(), args[0], hours)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%v is trusted, id: %v", args[0], id), nil
+}
+
+func handleEmergency(args []string) (string, error) {
+	if len(args) == 0 {
+		accesses, err := emergencyService.List(context.Background())
+		if err != nil {
+			return "", err
+		}
+		var writer strings.Builder
+		for i := 0; i < len(accesses); i++ {
+			writer.WriteString(accesses[i].Print(authService.CurrentLogin()) + "\n")
+		}
+		return writer.String(), nil
+	}
+	if len(args) < 2 {
+		return "", errors.New("bad args")
+	}
+	id, err := uuid.Parse(args[1])
+	if err != nil {
+		return "", err
+	}
+	switch args[0] {
+	case "list":
+		if len(args) < 3 {
+			return "", errors.New("bad args")
+		}
+		t, err := strconv.Atoi(args[2])
+		if err != nil {
+			return "", err
+		}
+		infos, err := resourceService.ListOwnedBy(context.Background(), api.ResourceType(t), id)
+		if err != nil {
+			return "", err
+		}
+		var writer strings.Builder
+		for i := 0; i < len(infos); i++ {
+			writer.WriteString(fmt.Sprintf("id: %v - %v\n", infos[i].Id, infos[i].Meta))
+		}
+		return writer.String(), nil
+
+	case "request":
+		err = emergencyService.Request(context.Background(), id)
+	case "approve":
+		err = emergencyService.Approve(context.Background(), id)
+	case "reject":
+		err = emergencyService.Reject(context.Background(), id)
+	case "remove":
+		err = emergencyService.Remove(context.Background(), id)
+	default:
+		return "", errors.New("bad args")
+	}
+	if err != nil {
+		return "", err
+	}
+	return "done", nil
+}
+
+func handleMatch(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", errors.New("bad args")
+	}
+	infos, err := resourceService.ListByUserId(context.Background(), api.LoginPassword, uuid.NullUUID{})
+	if err != nil {
+		return "", err
+	}
+	var writer strings.Builder
+	for i := 0; i < len(infos); i++ {
+		resource, _, err := resourceService.Get(context.Background(), infos[i].Id)
+		if err != nil {
+			return "", err
+		}
+		lp, ok := resource.(*model.LoginPassword)
+		if !ok || !lp.Matches(args[0]) {
+			continue
+		}
+		stale := ""
+		if lp.IsStale(time.Now()) {
+			stale = " (stale password)"
+		}
+		writer.WriteString(fmt.Sprintf("id: %v - %v - %v%v\n", infos[i].Id, lp.Login, infos[i].Meta, stale))
+	}
+	return writer.String(), nil
+}
+
+func handleAgent(args []string) (string, error) {
+	if len(args) != 0 && args[0] == "stop" {
+		if agentServer == nil {
+			return "", errors.New("agent is not running")
+		}
+		err := agentServer.Close()
+		agentServer = nil
+		return "agent stopped", err
+	}
+	if agentServer != nil {
+		return "", errors.New("agent is already running")
+	}
+	path := filepath.Join(os.TempDir(), "secstorage-agent.sock")
+	if len(args) != 0 {
+		path = args[0]
+	}
+
+	a := sshagent.New(tokenService)
+	count, err := loadSSHKeys(a)
+	if err != nil {
+		return "", err
+	}
+	agentServer, err = sshagent.Listen(path, a)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("serving %v keys\nexport SSH_AUTH_SOCK=%v", count, path), nil
+}
+
+func loadSSHKeys(a *sshagent.Agent) (int, error) {
+	infos, err := resourceService.ListByUserId(context.Background(), api.SSHKey, uuid.NullUUID{})
+	if err != nil {
+		return 0, err
+	}
+	for i := 0; i < len(infos); i++ {
+		resource, _, err := resourceService.Get(context.Background(), infos[i].Id)
+		if err != nil {
+			return 0, err
+		}
+		key, ok := resource.(*model.SSHKey)
+		if !ok {
+			return 0, fmt.Errorf("resource %v is not an SSH key", infos[i].Id)
+		}
+		raw, err := key.Raw("")
+		var missing *ssh.PassphraseMissingError
+		if errors.As(err, &missing) {
+			fmt.Printf("key %v is encrypted\n", infos[i].Meta)
+			raw, err = key.Raw(readPassword())
+		}
+		if err != nil {
+			return 0, err
+		}
+		if err := a.AddKey(raw, key.Comment); err != nil {
+			return 0, err
+		}
+	}
+	return len(infos), nil
+}
+
+func handleUsage() (string, error) {
+	usage, err := resourceService.Usage(context.Background())
+	if err != nil {
+		return "", err
+	}
+	return usage.Print(), nil
+}
+
 func handleGet(args []string) (string, error) {
 	id, err := uuid.Parse(args[0])
 	if err != nil {
 		return "", err
 	}
-	data, meta, err := resourceService.Get(context.Background(), id)
+	data, meta, attachments, err := resourceService.GetWithAttachments(context.Background(), id)
 	if err != nil {
 		return "", err
 	}
+	if _, ok := data.(*model.Template); ok {
+		return offlineNote() + data.Print(string(meta)), nil
+	}
+	reveal := len(args) > 1 && args[1] == "reveal"
 
-	return data.Print(string(meta)), nil
+	result := offlineNote() + data.Print(string(meta)) + model.PrintFields(data.GetFields(), reveal)
+	if len(attachments) != 0 {
+		result += "\nattachments:"
+		for i := 0; i < len(attachments); i++ {
+			result += fmt.Sprintf("\n  id: %v - %v", attachments[i].Id, attachments[i].Meta)
+		}
+	}
+	return result, nil
 }
 
 func handleList(args []string) (string, error) {
@@ -188,13 +1132,18 @@ func handleList(args []string) (string, error) {
 	}
 	rtype := api.ResourceType(t)
 
-	shortInfos, err := resourceService.ListByUserId(context.Background(), rtype)
+	shortInfos, err := resourceService.ListByUserId(context.Background(), rtype, currentCollection)
 	if err != nil {
 		return "", err
 	}
 	var writer strings.Builder
+	writer.WriteString(offlineNote())
 	for i := 0; i < len(shortInfos); i++ {
-		_, err := writer.WriteString(fmt.Sprintf("id: %v - %v\n", shortInfos[i].Id, shortInfos[i].Meta))
+		shared := ""
+		if shortInfos[i].Shared {
+			shared = " (shared with you)"
+		}
+		_, err := writer.WriteString(fmt.Sprintf("id: %v - %v%v\n", shortInfos[i].Id, shortInfos[i].Meta, shared))
 		if err != nil {
 			return "", err
 		}
@@ -211,22 +1160,57 @@ func handleDelete(args []string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return "deleted", nil
+	return offlineNote() + "deleted", nil
+}
+
+var saveTypes = map[string]api.ResourceType{
+	"lp":  api.LoginPassword,
+	"bc":  api.BankCard,
+	"sn":  api.SecureNote,
+	"otp": api.OTP,
+	"ssh": api.SSHKey,
 }
 
 func handleSave(args []string) (string, error) {
-	var resource any
+	var resource model.Resource
 	var meta string
-	var rType api.ResourceType
+	var err error
+
+	template, err := loadTemplate(args)
+	if err != nil {
+		return "", err
+	}
 
 	switch args[0] {
 	case "lp":
-		resource, meta = readLoginPassword()
-		rType = api.LoginPassword
+		resource, meta, err = readLoginPassword()
+		if err != nil {
+			return "", err
+		}
 
 	case "bc":
 		resource, meta = readBankCard()
-		rType = api.BankCard
+
+	case "sn":
+		resource, meta = readSecureNote()
+
+	case "otp":
+		resource, meta, err = readOTP()
+		if err != nil {
+			return "", err
+		}
+
+	case "ssh":
+		resource, meta, err = readSSHKey()
+		if err != nil {
+			return "", err
+		}
+
+	case "tpl":
+		resource, meta, err = readTemplate()
+		if err != nil {
+			return "", err
+		}
 
 	case "fl":
 		return readAndSaveFile()
@@ -235,18 +1219,105 @@ func handleSave(args []string) (string, error) {
 		return "", errors.New("bad args")
 	}
 
-	resourceJson, err := json.Marshal(resource)
+	fields, err := readCustomFields(template)
 	if err != nil {
 		return "", err
 	}
+	resource.SetFields(fields)
 
-	id, err := resourceService.Save(context.Background(), rType, resourceJson, []byte(meta))
+	id, err := resourceService.Save(context.Background(), resource, []byte(meta), currentCollection)
 	if err != nil {
 		return "", err
 	}
+	if resourceService.Offline() {
+		return fmt.Sprintf("%vsaved locally, the id changes when the server gets it, local id: %v", offlineNote(), id), nil
+	}
 	return fmt.Sprintf("saved successfully, id: %v", id), nil
 }
 
+// loadTemplate gets the template passed after the type of the saved resource.
+func loadTemplate(args []string) (*model.Template, error) {
+	if len(args) < 2 {
+		return nil, nil
+	}
+	rType, ok := saveTypes[args[0]]
+	if !ok {
+		return nil, errors.New("templates are not supported for this type")
+	}
+	id, err := uuid.Parse(args[1])
+	if err != nil {
+		return nil, err
+	}
+	resource, _, err := resourceService.Get(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+	template, ok := resource.(*model.Template)
+	if !ok {
+		return nil, fmt.Errorf("resource %v is not a template", id)
+	}
+	if template.ResourceType != rType {
+		return nil, fmt.Errorf("template %v is for another resource type", template.Name)
+	}
+	return template, nil
+}
+
+func readTemplate() (*model.Template, string, error) {
+	name := readString("input template name")
+	t, err := strconv.Atoi(readString("input resource type: 1 - LoginPassword, 3 - BankCard, 4 - SecureNote, 5 - OTP, 6 - SSHKey"))
+	if err != nil {
+		return nil, "", err
+	}
+	description := readString("input description")
+	fmt.Println("add template fields with default values")
+
+	return model.NewTemplate(name, api.ResourceType(t), nil), description, nil
+}
+
+// readCustomFields asks values for the template fields and then for any number of new fields.
+func readCustomFields(template *model.Template) ([]model.CustomField, error) {
+	fields := make([]model.CustomField, 0)
+	if template != nil {
+		for _, field := range template.Fields {
+			field.Value = readFieldValue(field.Name, field.Type, field.Value)
+			fields = append(fields, field)
+		}
+	}
+	for {
+		name := readString("input custom field name, empty to finish")
+		if len(name) == 0 {
+			return fields, nil
+		}
+		fieldType, err := model.ParseFieldType(readString("input field type: text, hidden, url, date"))
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, model.CustomField{Name: name, Type: fieldType, Value: readFieldValue(name, fieldType, "")})
+	}
+}
+
+func readFieldValue(name string, fieldType api.FieldType, defaultValue string) string {
+	label := "input " + name
+	switch fieldType {
+	case api.Hidden:
+		value := readSecret(label)
+		if len(value) == 0 {
+			return defaultValue
+		}
+		return value
+	case api.Date:
+		label += " in format: YYYY-MM-DD"
+	}
+	if len(defaultValue) != 0 {
+		label += fmt.Sprintf(" [%v]", defaultValue)
+	}
+	value := readString(label)
+	if len(value) == 0 {
+		return defaultValue
+	}
+	return value
+}
+
 func readAndSaveFile() (string, error) {
 	path := readString("file path")
 	description := readString("description")
@@ -257,12 +1328,35 @@ func readAndSaveFile() (string, error) {
 	return id.String(), nil
 }
 
-func readLoginPassword() (*model.LoginPassword, string) {
+func readLoginPassword() (*model.LoginPassword, string, error) {
 	login := readString("input login")
 	password := readPassword()
+	lp := model.NewLoginPassword(login, password)
+
+	for _, u := range strings.Split(readString("input website URLs separated by spaces, https:// is added to bare domains"), " ") {
+		if len(u) != 0 {
+			lp.URLs = append(lp.URLs, withScheme(u))
+		}
+	}
+	lp.Notes = readMultiline("input notes, finish with a single '.' line")
+	if source := readString("input otpauth URI or path to QR image, empty to skip"); len(source) != 0 {
+		key, err := parseOTP(source)
+		if err != nil {
+			return nil, "", err
+		}
+		lp.TOTP = model.NewOTP(key)
+	}
 	description := readString("input description")
 
-	return model.NewLoginPassword(login, password), description
+	return lp, description, nil
+}
+
+// withScheme adds https:// to a bare domain like example.com, the server accepts only URLs with a scheme.
+func withScheme(u string) string {
+	if strings.Contains(u, "://") {
+		return u
+	}
+	return "https://" + u
 }
 
 func readBankCard() (*model.BankCard, string) {
@@ -275,8 +1369,60 @@ func readBankCard() (*model.BankCard, string) {
 	return model.NewBankCard(number, until, name, surname), description
 }
 
+func readSecureNote() (*model.SecureNote, string) {
+	title := readString("input title")
+	body := readMultiline("input note, finish with a single '.' line")
+	description := readString("input description")
+
+	return model.NewSecureNote(title, body), description
+}
+
+func readOTP() (*model.OTP, string, error) {
+	key, err := parseOTP(readString("input otpauth URI or path to QR image"))
+	if err != nil {
+		return nil, "", err
+	}
+	description := readString("input description")
+
+	return model.NewOTP(key), description, nil
+}
+
+func readSSHKey() (*model.SSHKey, string, error) {
+	path := readString("input path to private key")
+	privateKey, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+	fmt.Println("passphrase to keep with the key, leave empty to ask on use")
+	passphrase := readPassword()
+	comment := readString("input comment")
+	description := readString("input description")
+
+	key, err := model.NewSSHKey(string(privateKey), passphrase, comment)
+	if err != nil {
+		return nil, "", err
+	}
+	return key, description, nil
+}
+
+func parseOTP(source string) (*otp.Key, error) {
+	uri := source
+	if !strings.HasPrefix(source, "otpauth://") {
+		decoded, err := qr.Decode(source)
+		if err != nil {
+			return nil, err
+		}
+		uri = decoded
+	}
+	return otp.ParseURI(uri)
+}
+
 func readPassword() string {
-	fmt.Println("input password")
+	return readSecret("input password")
+}
+
+func readSecret(label string) string {
+	fmt.Println(label)
 	fmt.Print("-> ")
 	bytePassword, err := term.ReadPassword(syscall.Stdin)
 	if err != nil {
@@ -303,6 +1449,23 @@ func readString(label string) string {
 	return scanner.Text()
 }
 
+func readMultiline(label string) string {
+	fmt.Println(label)
+	lines := make([]string, 0)
+	for {
+		fmt.Print(".. ")
+		if !scanner.Scan() {
+			break
+		}
+		line := scanner.Text()
+		if line == "." {
+			break
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
 func strOrNA(s string) string {
 	if strings.TrimSpace(s) == "" {
 		return "N/A"
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"golang.org/x/term"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"os"
+	pb "secstorage/internal/api/proto"
+	"secstorage/internal/client/interceptors"
+	"secstorage/internal/client/services"
+	"syscall"
+)
+
+const usage = `usage: secstorage-admin [flags] command [login]
+
+commands:
+	users - list users with their usage
+	user [login] - show the user and the usage
+	disable [login] - disable the account and revoke its tokens
+	enable [login] - enable the account
+	logout [login] - revoke all tokens issued to the user
+	gc - remove unreferenced blobs and expired uploads
+	verify - verify checksums of all stored files
+	audit [login] - list the latest events of all users or of the user
+	audit-verify - check the hash chain of the audit log
+
+flags:
+`
+
+var addr = flag.String("addr", ":3200", "server address")
+var cert = flag.String("cert", "cert/service.pem", "server certificate")
+var login = flag.String("login", "", "admin login, the password is read from SECSTORAGE_PASSWORD or prompted")
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 || *login == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	creds, err := credentials.NewClientTLSFromFile(*cert, "")
+	if err != nil {
+		fail(err)
+	}
+	tokenService := &services.TokenService{}
+	con, err := grpc.Dial(
+		*addr,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithUnaryInterceptor(interceptors.TokenUnaryInterceptor(tokenService)),
+		grpc.WithStreamInterceptor(interceptors.TokenStreamInterceptor(tokenService)),
+	)
+	if err != nil {
+		fail(err)
+	}
+	defer con.Close()
+
+	ctx := context.Background()
+	authService := services.NewAuthService(pb.NewAuthClient(con), tokenService)
+	if _, err := authService.Login(ctx, *login, readPassword()); err != nil {
+		fail(err)
+	}
+
+	if err := run(ctx, services.NewAdminService(pb.NewAdminClient(con)), flag.Args()); err != nil {
+		fail(err)
+	}
+}
+
+func run(ctx context.Context, adminService *services.AdminService, args []string) error {
+	command := args[0]
+	switch command {
+	case "users":
+		users, err := adminService.ListUsers(ctx)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < len(users); i++ {
+			fmt.Println(users[i].Print())
+		}
+		return nil
+	case "gc":
+		result, err := adminService.CollectGarbage(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("removed files: %v, freed bytes: %v, expired uploads: %v\n", result.RemovedFiles, result.FreedBytes, result.ExpiredUploads)
+		return nil
+	case "audit":
+		var user string
+		if len(args) > 1 {
+			user = args[1]
+		}
+		events, err := adminService.ListAudit(ctx, user, 0, 0)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < len(events); i++ {
+			fmt.Println(events[i].Print())
+		}
+		return nil
+	case "audit-verify":
+		result, err := adminService.VerifyAudit(ctx)
+		if err != nil {
+			return err
+		}
+		if result.FirstInvalidSeq != 0 {
+			return fmt.Errorf("audit log is tampered at event %v", result.FirstInvalidSeq)
+		}
+		fmt.Printf("checked events: %v, head: %x\n", result.Checked, result.Head)
+		return nil
+	case "verify":
+		corrupted, err := adminService.Verify(ctx)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < len(corrupted); i++ {
+			fmt.Printf("corrupted file: %v\n", corrupted[i])
+		}
+		if len(corrupted) != 0 {
+			return fmt.Errorf("found %v corrupted files", len(corrupted))
+		}
+		fmt.Println("all files verified")
+		return nil
+	}
+
+	if len(args) != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	user := args[1]
+	switch command {
+	case "user":
+		info, err := adminService.GetUser(ctx, user)
+		if err != nil {
+			return err
+		}
+		fmt.Println(info.Print())
+		return nil
+	case "disable", "enable":
+		return adminService.SetDisabled(ctx, user, command == "disable")
+	case "logout":
+		return adminService.Logout(ctx, user)
+	}
+	flag.Usage()
+	os.Exit(2)
+	return nil
+}
+
+func readPassword() string {
+	if password, ok := os.LookupEnv("SECSTORAGE_PASSWORD"); ok {
+		return password
+	}
+	fmt.Fprint(os.Stderr, "password: ")
+	password, err := term.ReadPassword(syscall.Stdin)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		fail(err)
+	}
+	return string(password)
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "error:", err)
+	os.Exit(1)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"flag"
+	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
@@ -11,19 +12,60 @@ import (
 	"os"
 	. "secstorage/internal/logger"
 	"secstorage/internal/server"
+	"secstorage/internal/server/events"
 	"secstorage/internal/server/modulservers"
 	"secstorage/internal/server/services"
 	"secstorage/internal/server/storage"
+	auditStorage "secstorage/internal/server/storage/audit"
 	authStorage "secstorage/internal/server/storage/auth"
+	emergencyStorage "secstorage/internal/server/storage/emergency"
+	orgStorage "secstorage/internal/server/storage/organization"
 	resourceStorage "secstorage/internal/server/storage/resource"
+	sendStorage "secstorage/internal/server/storage/send"
+	shareStorage "secstorage/internal/server/storage/share"
+	uploadStorage "secstorage/internal/server/storage/upload"
 
 	"strconv"
 )
 
+var verify = flag.Bool("verify", false, "verify checksums of all stored files and exit")
+var grantAdmin = flag.String("grant-admin", "", "make the user with the given login an admin and exit")
+
 func main() {
 	config := mustReadConfig()
 	db := storage.MustInitDB(context.Background(), config.DBURL)
 
+	resourceStore := resourceStorage.NewStore(context.Background(), db)
+	uploadStore := uploadStorage.NewStorage(context.Background(), db)
+	fileStore := services.FileStoreConfig{
+		Path:         config.FileStorePath,
+		Compress:     config.CompressFiles,
+		MaxChunkSize: config.MaxChunkSize,
+	}
+	shareStore := shareStorage.NewStorage(context.Background(), db)
+	orgStore := orgStorage.NewStorage(context.Background(), db)
+	emergencyStore := emergencyStorage.NewStorage(context.Background(), db)
+	changes := newChangeBroker(config, db)
+	resourceService := services.NewResourceStoreService(resourceStore, uploadStore, shareStore, orgStore, emergencyStore, changes, fileStore, services.Quota{
+		MaxItems:    config.MaxItemsPerUser,
+		MaxBytes:    config.MaxBytesPerUser,
+		MaxFileSize: config.MaxFileSize,
+	})
+
+	authStore := authStorage.NewStorage(context.Background(), db)
+
+	if *verify {
+		runVerify(resourceService)
+		return
+	}
+	if *grantAdmin != "" {
+		if err := authStore.SetAdmin(context.Background(), *grantAdmin, true); err != nil {
+			Log.Fatal("error on grant admin", zap.Error(err))
+		}
+		Log.Info("admin granted", zap.String("login", *grantAdmin))
+		return
+	}
+
 	var creds credentials.TransportCredentials
 	if config.UseSecCreds {
 		secCreds, err := credentials.NewServerTLSFromFile("cert/service.pem", "cert/service.key")
@@ -42,24 +84,76 @@ func main() {
 	}
 
 	tokenService := services.NewTokenService(config.Key)
+	tokenService.SetSessionStore(authStore)
 
-	authStore := authStorage.NewStorage(context.Background(), db)
 	authService := services.NewAuthService(authStore)
 	authServer := modulservers.NewAuthServer(authService, tokenService)
 
-	resourceStore := resourceStorage.NewStore(context.Background(), db)
-	resourceService := services.NewResourceStoreService(resourceStore, config.FileStorePath)
 	resourceServer := modulservers.NewResourcesServer(resourceService)
+	shareServer := modulservers.NewShareServer(services.NewShareService(authStore, shareStore, resourceStore, changes))
+
+	orgServer := modulservers.NewOrgServer(services.NewOrgService(orgStore))
+	sendServer := modulservers.NewSendServer(services.NewSendService(sendStorage.NewStorage(context.Background(), db)))
+	emergencyServer := modulservers.NewEmergencyServer(services.NewEmergencyService(emergencyStore))
+	auditService := services.NewAuditService(auditStorage.NewStorage(context.Background(), db))
+	adminServer := modulservers.NewAdminServer(services.NewAdminService(authStore, resourceService, auditService))
+	auditServer := modulservers.NewAuditServer(auditService)
+
+	server.Run(
+		context.Background(),
+		authServer,
+		resourceServer,
+		shareServer,
+		orgServer,
+		sendServer,
+		emergencyServer,
+		adminServer,
+		auditServer,
+		tokenService,
+		auditService,
+		creds,
+		listen,
+	)
+}
+
+// newChangeBroker shares resource changes between server instances through Postgres when pg_notify is set.
+func newChangeBroker(config Config, db *sqlx.DB) services.ChangeBroker {
+	broker := events.NewBroker()
+	if !config.PgNotify {
+		return broker
+	}
+	pgBroker := events.NewPgBroker(broker, db, config.DBURL)
+	go func() {
+		if err := pgBroker.Listen(context.Background()); err != nil {
+			Log.Fatal("error on listen resource changes", zap.Error(err))
+		}
+	}()
+	return pgBroker
+}
 
-	server.Run(context.Background(), authServer, resourceServer, tokenService, creds, listen)
+func runVerify(resourceService *services.ResourceService) {
+	corrupted, err := resourceService.VerifyFiles(context.Background())
+	if err != nil {
+		Log.Fatal("verification failed", zap.Error(err))
+	}
+	if len(corrupted) != 0 {
+		Log.Fatal("found corrupted files", zap.Int("count", len(corrupted)))
+	}
+	Log.Info("all files verified")
 }
 
 type Config struct {
-	DBURL         string `json:"dburl"`
-	Key           string `json:"key"`
-	UseSecCreds   bool   `json:"use_sec_creds"`
-	Port          int    `json:"port"`
-	FileStorePath string `json:"file_store_path"`
+	DBURL           string `json:"dburl"`
+	Key             string `json:"key"`
+	UseSecCreds     bool   `json:"use_sec_creds"`
+	Port            int    `json:"port"`
+	FileStorePath   string `json:"file_store_path"`
+	CompressFiles   bool   `json:"compress_files"`
+	MaxChunkSize    int    `json:"max_chunk_size"`
+	MaxItemsPerUser int64  `json:"max_items_per_user"`
+	MaxBytesPerUser int64  `json:"max_bytes_per_user"`
+	MaxFileSize     int64  `json:"max_file_size"`
+	PgNotify        bool   `json:"pg_notify"`
 }
 
 func mustReadConfig() Config {
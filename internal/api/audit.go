@@ -0,0 +1,27 @@
+package api
+
+// AuditAction is a security-relevant event recorded to the audit log.
+type AuditAction uint
+
+const (
+	AuditRegister AuditAction = iota
+	AuditLogin
+	AuditLoginFailed
+	AuditTokenIssued
+	AuditRecover
+	AuditRecoverFailed
+	AuditGet
+	AuditGetFile
+	AuditSave
+	AuditDelete
+	AuditShare
+	AuditUnshare
+	AuditUpdateShared
+	AuditEmergencyRequest
+	AuditEmergencyApprove
+	AuditEmergencyReject
+	AuditSetDisabled
+	AuditForceLogout
+	AuditSetRecovery
+	AuditSetRecoveryFailed
+)
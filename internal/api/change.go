@@ -0,0 +1,11 @@
+package api
+
+// ChangeKind of a resource change, ChangeReset tells watchers that changes may be lost and resources have to be listed again.
+type ChangeKind uint
+
+const (
+	ChangeCreated ChangeKind = iota
+	ChangeUpdated
+	ChangeDeleted
+	ChangeReset
+)
@@ -0,0 +1,10 @@
+package api
+
+// EmergencyStatus of the emergency access, requested access becomes granted after the waiting period.
+type EmergencyStatus uint
+
+const (
+	EmergencyIdle EmergencyStatus = iota
+	EmergencyRequested
+	EmergencyGranted
+)
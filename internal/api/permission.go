@@ -0,0 +1,8 @@
+package api
+
+type Permission uint
+
+const (
+	Read Permission = iota
+	ReadWrite
+)
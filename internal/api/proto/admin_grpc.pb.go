@@ -0,0 +1,379 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.11
+// source: internal/api/proto/admin.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdminClient interface {
+	ListUsers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Admin_ListUsersClient, error)
+	GetUser(ctx context.Context, in *UserQuery, opts ...grpc.CallOption) (*UserInfo, error)
+	SetDisabled(ctx context.Context, in *AccountState, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Logout revokes all tokens issued to the user so far
+	Logout(ctx context.Context, in *UserQuery, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RunMaintenance(ctx context.Context, in *MaintenanceRequest, opts ...grpc.CallOption) (*MaintenanceResult, error)
+	ListAudit(ctx context.Context, in *AuditQuery, opts ...grpc.CallOption) (Admin_ListAuditClient, error)
+	VerifyAudit(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AuditVerifyResult, error)
+}
+
+type adminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminClient(cc grpc.ClientConnInterface) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) ListUsers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Admin_ListUsersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Admin_ServiceDesc.Streams[0], "/secstorage.Admin/ListUsers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminListUsersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Admin_ListUsersClient interface {
+	Recv() (*UserInfo, error)
+	grpc.ClientStream
+}
+
+type adminListUsersClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminListUsersClient) Recv() (*UserInfo, error) {
+	m := new(UserInfo)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminClient) GetUser(ctx context.Context, in *UserQuery, opts ...grpc.CallOption) (*UserInfo, error) {
+	out := new(UserInfo)
+	err := c.cc.Invoke(ctx, "/secstorage.Admin/GetUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) SetDisabled(ctx context.Context, in *AccountState, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/secstorage.Admin/SetDisabled", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) Logout(ctx context.Context, in *UserQuery, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/secstorage.Admin/Logout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) RunMaintenance(ctx context.Context, in *MaintenanceRequest, opts ...grpc.CallOption) (*MaintenanceResult, error) {
+	out := new(MaintenanceResult)
+	err := c.cc.Invoke(ctx, "/secstorage.Admin/RunMaintenance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ListAudit(ctx context.Context, in *AuditQuery, opts ...grpc.CallOption) (Admin_ListAuditClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Admin_ServiceDesc.Streams[1], "/secstorage.Admin/ListAudit", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminListAuditClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Admin_ListAuditClient interface {
+	Recv() (*AuditEvent, error)
+	grpc.ClientStream
+}
+
+type adminListAuditClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminListAuditClient) Recv() (*AuditEvent, error) {
+	m := new(AuditEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminClient) VerifyAudit(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AuditVerifyResult, error) {
+	out := new(AuditVerifyResult)
+	err := c.cc.Invoke(ctx, "/secstorage.Admin/VerifyAudit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServer is the server API for Admin service.
+// All implementations must embed UnimplementedAdminServer
+// for forward compatibility
+type AdminServer interface {
+	ListUsers(*emptypb.Empty, Admin_ListUsersServer) error
+	GetUser(context.Context, *UserQuery) (*UserInfo, error)
+	SetDisabled(context.Context, *AccountState) (*emptypb.Empty, error)
+	// Logout revokes all tokens issued to the user so far
+	Logout(context.Context, *UserQuery) (*emptypb.Empty, error)
+	RunMaintenance(context.Context, *MaintenanceRequest) (*MaintenanceResult, error)
+	ListAudit(*AuditQuery, Admin_ListAuditServer) error
+	VerifyAudit(context.Context, *emptypb.Empty) (*AuditVerifyResult, error)
+	mustEmbedUnimplementedAdminServer()
+}
+
+// UnimplementedAdminServer must be embedded to have forward compatible implementations.
+type UnimplementedAdminServer struct {
+}
+
+func (UnimplementedAdminServer) ListUsers(*emptypb.Empty, Admin_ListUsersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedAdminServer) GetUser(context.Context, *UserQuery) (*UserInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedAdminServer) SetDisabled(context.Context, *AccountState) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetDisabled not implemented")
+}
+func (UnimplementedAdminServer) Logout(context.Context, *UserQuery) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedAdminServer) RunMaintenance(context.Context, *MaintenanceRequest) (*MaintenanceResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunMaintenance not implemented")
+}
+func (UnimplementedAdminServer) ListAudit(*AuditQuery, Admin_ListAuditServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListAudit not implemented")
+}
+func (UnimplementedAdminServer) VerifyAudit(context.Context, *emptypb.Empty) (*AuditVerifyResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyAudit not implemented")
+}
+func (UnimplementedAdminServer) mustEmbedUnimplementedAdminServer() {}
+
+// UnsafeAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServer will
+// result in compilation errors.
+type UnsafeAdminServer interface {
+	mustEmbedUnimplementedAdminServer()
+}
+
+func RegisterAdminServer(s grpc.ServiceRegistrar, srv AdminServer) {
+	s.RegisterService(&Admin_ServiceDesc, srv)
+}
+
+func _Admin_ListUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServer).ListUsers(m, &adminListUsersServer{stream})
+}
+
+type Admin_ListUsersServer interface {
+	Send(*UserInfo) error
+	grpc.ServerStream
+}
+
+type adminListUsersServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminListUsersServer) Send(m *UserInfo) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Admin_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Admin/GetUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetUser(ctx, req.(*UserQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_SetDisabled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountState)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).SetDisabled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Admin/SetDisabled",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).SetDisabled(ctx, req.(*AccountState))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Admin/Logout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).Logout(ctx, req.(*UserQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_RunMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).RunMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Admin/RunMaintenance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).RunMaintenance(ctx, req.(*MaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ListAudit_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AuditQuery)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServer).ListAudit(m, &adminListAuditServer{stream})
+}
+
+type Admin_ListAuditServer interface {
+	Send(*AuditEvent) error
+	grpc.ServerStream
+}
+
+type adminListAuditServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminListAuditServer) Send(m *AuditEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Admin_VerifyAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).VerifyAudit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Admin/VerifyAudit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).VerifyAudit(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Admin_ServiceDesc is the grpc.ServiceDesc for Admin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Admin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "secstorage.Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetUser",
+			Handler:    _Admin_GetUser_Handler,
+		},
+		{
+			MethodName: "SetDisabled",
+			Handler:    _Admin_SetDisabled_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _Admin_Logout_Handler,
+		},
+		{
+			MethodName: "RunMaintenance",
+			Handler:    _Admin_RunMaintenance_Handler,
+		},
+		{
+			MethodName: "VerifyAudit",
+			Handler:    _Admin_VerifyAudit_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListUsers",
+			Handler:       _Admin_ListUsers_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListAudit",
+			Handler:       _Admin_ListAudit_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "internal/api/proto/admin.proto",
+}
@@ -0,0 +1,448 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.11
+// source: internal/api/proto/audit.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AUDIT_ACTION int32
+
+const (
+	AUDIT_ACTION_REGISTER            AUDIT_ACTION = 0
+	AUDIT_ACTION_LOGIN               AUDIT_ACTION = 1
+	AUDIT_ACTION_LOGIN_FAILED        AUDIT_ACTION = 2
+	AUDIT_ACTION_TOKEN_ISSUED        AUDIT_ACTION = 3
+	AUDIT_ACTION_RECOVER             AUDIT_ACTION = 4
+	AUDIT_ACTION_RECOVER_FAILED      AUDIT_ACTION = 5
+	AUDIT_ACTION_GET                 AUDIT_ACTION = 6
+	AUDIT_ACTION_GET_FILE            AUDIT_ACTION = 7
+	AUDIT_ACTION_SAVE                AUDIT_ACTION = 8
+	AUDIT_ACTION_DELETE              AUDIT_ACTION = 9
+	AUDIT_ACTION_SHARE               AUDIT_ACTION = 10
+	AUDIT_ACTION_UNSHARE             AUDIT_ACTION = 11
+	AUDIT_ACTION_UPDATE_SHARED       AUDIT_ACTION = 12
+	AUDIT_ACTION_EMERGENCY_REQUEST   AUDIT_ACTION = 13
+	AUDIT_ACTION_EMERGENCY_APPROVE   AUDIT_ACTION = 14
+	AUDIT_ACTION_EMERGENCY_REJECT    AUDIT_ACTION = 15
+	AUDIT_ACTION_SET_DISABLED        AUDIT_ACTION = 16
+	AUDIT_ACTION_FORCE_LOGOUT        AUDIT_ACTION = 17
+	AUDIT_ACTION_SET_RECOVERY        AUDIT_ACTION = 18
+	AUDIT_ACTION_SET_RECOVERY_FAILED AUDIT_ACTION = 19
+)
+
+// Enum value maps for AUDIT_ACTION.
+var (
+	AUDIT_ACTION_name = map[int32]string{
+		0:  "REGISTER",
+		1:  "LOGIN",
+		2:  "LOGIN_FAILED",
+		3:  "TOKEN_ISSUED",
+		4:  "RECOVER",
+		5:  "RECOVER_FAILED",
+		6:  "GET",
+		7:  "GET_FILE",
+		8:  "SAVE",
+		9:  "DELETE",
+		10: "SHARE",
+		11: "UNSHARE",
+		12: "UPDATE_SHARED",
+		13: "EMERGENCY_REQUEST",
+		14: "EMERGENCY_APPROVE",
+		15: "EMERGENCY_REJECT",
+		16: "SET_DISABLED",
+		17: "FORCE_LOGOUT",
+		18: "SET_RECOVERY",
+		19: "SET_RECOVERY_FAILED",
+	}
+	AUDIT_ACTION_value = map[string]int32{
+		"REGISTER":            0,
+		"LOGIN":               1,
+		"LOGIN_FAILED":        2,
+		"TOKEN_ISSUED":        3,
+		"RECOVER":             4,
+		"RECOVER_FAILED":      5,
+		"GET":                 6,
+		"GET_FILE":            7,
+		"SAVE":                8,
+		"DELETE":              9,
+		"SHARE":               10,
+		"UNSHARE":             11,
+		"UPDATE_SHARED":       12,
+		"EMERGENCY_REQUEST":   13,
+		"EMERGENCY_APPROVE":   14,
+		"EMERGENCY_REJECT":    15,
+		"SET_DISABLED":        16,
+		"FORCE_LOGOUT":        17,
+		"SET_RECOVERY":        18,
+		"SET_RECOVERY_FAILED": 19,
+	}
+)
+
+func (x AUDIT_ACTION) Enum() *AUDIT_ACTION {
+	p := new(AUDIT_ACTION)
+	*p = x
+	return p
+}
+
+func (x AUDIT_ACTION) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AUDIT_ACTION) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_api_proto_audit_proto_enumTypes[0].Descriptor()
+}
+
+func (AUDIT_ACTION) Type() protoreflect.EnumType {
+	return &file_internal_api_proto_audit_proto_enumTypes[0]
+}
+
+func (x AUDIT_ACTION) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AUDIT_ACTION.Descriptor instead.
+func (AUDIT_ACTION) EnumDescriptor() ([]byte, []int) {
+	return file_internal_api_proto_audit_proto_rawDescGZIP(), []int{0}
+}
+
+type AuditQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// only events with lower seq are returned, 0 returns the latest events
+	Before int64 `protobuf:"varint,1,opt,name=before,proto3" json:"before,omitempty"`
+	Limit  int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// admins can filter events by user, events of all users are returned if empty
+	Login string `protobuf:"bytes,3,opt,name=login,proto3" json:"login,omitempty"`
+}
+
+func (x *AuditQuery) Reset() {
+	*x = AuditQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_api_proto_audit_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditQuery) ProtoMessage() {}
+
+func (x *AuditQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_api_proto_audit_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditQuery.ProtoReflect.Descriptor instead.
+func (*AuditQuery) Descriptor() ([]byte, []int) {
+	return file_internal_api_proto_audit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuditQuery) GetBefore() int64 {
+	if x != nil {
+		return x.Before
+	}
+	return 0
+}
+
+func (x *AuditQuery) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *AuditQuery) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+type AuditEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seq int64 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	// user who did the action, empty for failed logins
+	UserId *UUID        `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"`
+	Login  string       `protobuf:"bytes,3,opt,name=login,proto3" json:"login,omitempty"`
+	Action AUDIT_ACTION `protobuf:"varint,4,opt,name=action,proto3,enum=secstorage.AUDIT_ACTION" json:"action,omitempty"`
+	// resource or emergency access the action is about
+	TargetId *UUID `protobuf:"bytes,5,opt,name=targetId,proto3" json:"targetId,omitempty"`
+	// owner of the target, owners see actions of other users on their resources
+	OwnerId   *UUID                  `protobuf:"bytes,6,opt,name=ownerId,proto3" json:"ownerId,omitempty"`
+	Details   string                 `protobuf:"bytes,7,opt,name=details,proto3" json:"details,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_api_proto_audit_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_api_proto_audit_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_internal_api_proto_audit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AuditEvent) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetUserId() *UUID {
+	if x != nil {
+		return x.UserId
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetAction() AUDIT_ACTION {
+	if x != nil {
+		return x.Action
+	}
+	return AUDIT_ACTION_REGISTER
+}
+
+func (x *AuditEvent) GetTargetId() *UUID {
+	if x != nil {
+		return x.TargetId
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetOwnerId() *UUID {
+	if x != nil {
+		return x.OwnerId
+	}
+	return nil
+}
+
+func (x *AuditEvent) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_internal_api_proto_audit_proto protoreflect.FileDescriptor
+
+var file_internal_api_proto_audit_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x75, 0x64, 0x69, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x0a, 0x73, 0x65, 0x63, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x1a, 0x1f, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x21, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x50, 0x0a, 0x0a, 0x41, 0x75, 0x64, 0x69, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x16,
+	0x0a, 0x06, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x6f, 0x67,
+	0x69, 0x6e, 0x22, 0xbe, 0x02, 0x0a, 0x0a, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03,
+	0x73, 0x65, 0x71, 0x12, 0x28, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x73, 0x65, 0x63, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x2e, 0x55, 0x55, 0x49, 0x44, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x6f,
+	0x67, 0x69, 0x6e, 0x12, 0x30, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x73, 0x65, 0x63, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x2e, 0x41, 0x55, 0x44, 0x49, 0x54, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x52, 0x06, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x73, 0x65, 0x63, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x2e, 0x55, 0x55, 0x49, 0x44, 0x52, 0x08, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x73, 0x65, 0x63, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x2e, 0x55, 0x55, 0x49, 0x44, 0x52, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x38, 0x0a, 0x09, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x2a, 0xd7, 0x02, 0x0a, 0x0c, 0x41, 0x55, 0x44, 0x49, 0x54, 0x5f, 0x41, 0x43,
+	0x54, 0x49, 0x4f, 0x4e, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x45, 0x52,
+	0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x4c, 0x4f, 0x47, 0x49, 0x4e, 0x10, 0x01, 0x12, 0x10, 0x0a,
+	0x0c, 0x4c, 0x4f, 0x47, 0x49, 0x4e, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x12,
+	0x10, 0x0a, 0x0c, 0x54, 0x4f, 0x4b, 0x45, 0x4e, 0x5f, 0x49, 0x53, 0x53, 0x55, 0x45, 0x44, 0x10,
+	0x03, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x43, 0x4f, 0x56, 0x45, 0x52, 0x10, 0x04, 0x12, 0x12,
+	0x0a, 0x0e, 0x52, 0x45, 0x43, 0x4f, 0x56, 0x45, 0x52, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44,
+	0x10, 0x05, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x45, 0x54, 0x10, 0x06, 0x12, 0x0c, 0x0a, 0x08, 0x47,
+	0x45, 0x54, 0x5f, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x07, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x41, 0x56,
+	0x45, 0x10, 0x08, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x09, 0x12,
+	0x09, 0x0a, 0x05, 0x53, 0x48, 0x41, 0x52, 0x45, 0x10, 0x0a, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e,
+	0x53, 0x48, 0x41, 0x52, 0x45, 0x10, 0x0b, 0x12, 0x11, 0x0a, 0x0d, 0x55, 0x50, 0x44, 0x41, 0x54,
+	0x45, 0x5f, 0x53, 0x48, 0x41, 0x52, 0x45, 0x44, 0x10, 0x0c, 0x12, 0x15, 0x0a, 0x11, 0x45, 0x4d,
+	0x45, 0x52, 0x47, 0x45, 0x4e, 0x43, 0x59, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10,
+	0x0d, 0x12, 0x15, 0x0a, 0x11, 0x45, 0x4d, 0x45, 0x52, 0x47, 0x45, 0x4e, 0x43, 0x59, 0x5f, 0x41,
+	0x50, 0x50, 0x52, 0x4f, 0x56, 0x45, 0x10, 0x0e, 0x12, 0x14, 0x0a, 0x10, 0x45, 0x4d, 0x45, 0x52,
+	0x47, 0x45, 0x4e, 0x43, 0x59, 0x5f, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x10, 0x0f, 0x12, 0x10,
+	0x0a, 0x0c, 0x53, 0x45, 0x54, 0x5f, 0x44, 0x49, 0x53, 0x41, 0x42, 0x4c, 0x45, 0x44, 0x10, 0x10,
+	0x12, 0x10, 0x0a, 0x0c, 0x46, 0x4f, 0x52, 0x43, 0x45, 0x5f, 0x4c, 0x4f, 0x47, 0x4f, 0x55, 0x54,
+	0x10, 0x11, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x45, 0x54, 0x5f, 0x52, 0x45, 0x43, 0x4f, 0x56, 0x45,
+	0x52, 0x59, 0x10, 0x12, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x45, 0x54, 0x5f, 0x52, 0x45, 0x43, 0x4f,
+	0x56, 0x45, 0x52, 0x59, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x13, 0x32, 0x41, 0x0a,
+	0x05, 0x41, 0x75, 0x64, 0x69, 0x74, 0x12, 0x38, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x16,
+	0x2e, 0x73, 0x65, 0x63, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x41, 0x75, 0x64, 0x69,
+	0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x16, 0x2e, 0x73, 0x65, 0x63, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01,
+	0x42, 0x1f, 0x5a, 0x1d, 0x73, 0x65, 0x63, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2f, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_internal_api_proto_audit_proto_rawDescOnce sync.Once
+	file_internal_api_proto_audit_proto_rawDescData = file_internal_api_proto_audit_proto_rawDesc
+)
+
+func file_internal_api_proto_audit_proto_rawDescGZIP() []byte {
+	file_internal_api_proto_audit_proto_rawDescOnce.Do(func() {
+		file_internal_api_proto_audit_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_api_proto_audit_proto_rawDescData)
+	})
+	return file_internal_api_proto_audit_proto_rawDescData
+}
+
+var file_internal_api_proto_audit_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_internal_api_proto_audit_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_internal_api_proto_audit_proto_goTypes = []interface{}{
+	(AUDIT_ACTION)(0),             // 0: secstorage.AUDIT_ACTION
+	(*AuditQuery)(nil),            // 1: secstorage.AuditQuery
+	(*AuditEvent)(nil),            // 2: secstorage.AuditEvent
+	(*UUID)(nil),                  // 3: secstorage.UUID
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+}
+var file_internal_api_proto_audit_proto_depIdxs = []int32{
+	3, // 0: secstorage.AuditEvent.userId:type_name -> secstorage.UUID
+	0, // 1: secstorage.AuditEvent.action:type_name -> secstorage.AUDIT_ACTION
+	3, // 2: secstorage.AuditEvent.targetId:type_name -> secstorage.UUID
+	3, // 3: secstorage.AuditEvent.ownerId:type_name -> secstorage.UUID
+	4, // 4: secstorage.AuditEvent.createdAt:type_name -> google.protobuf.Timestamp
+	1, // 5: secstorage.Audit.List:input_type -> secstorage.AuditQuery
+	2, // 6: secstorage.Audit.List:output_type -> secstorage.AuditEvent
+	6, // [6:7] is the sub-list for method output_type
+	5, // [5:6] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_internal_api_proto_audit_proto_init() }
+func file_internal_api_proto_audit_proto_init() {
+	if File_internal_api_proto_audit_proto != nil {
+		return
+	}
+	file_internal_api_proto_resource_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_internal_api_proto_audit_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_api_proto_audit_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_internal_api_proto_audit_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_internal_api_proto_audit_proto_goTypes,
+		DependencyIndexes: file_internal_api_proto_audit_proto_depIdxs,
+		EnumInfos:         file_internal_api_proto_audit_proto_enumTypes,
+		MessageInfos:      file_internal_api_proto_audit_proto_msgTypes,
+	}.Build()
+	File_internal_api_proto_audit_proto = out.File
+	file_internal_api_proto_audit_proto_rawDesc = nil
+	file_internal_api_proto_audit_proto_goTypes = nil
+	file_internal_api_proto_audit_proto_depIdxs = nil
+}
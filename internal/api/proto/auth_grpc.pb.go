@@ -11,6 +11,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -24,6 +25,10 @@ const _ = grpc.SupportPackageIsVersion7
 type AuthClient interface {
 	Register(ctx context.Context, in *AuthData, opts ...grpc.CallOption) (*TokenData, error)
 	Login(ctx context.Context, in *AuthData, opts ...grpc.CallOption) (*TokenData, error)
+	// SetRecovery sets or replaces the recovery key of the caller, the current password is required
+	SetRecovery(ctx context.Context, in *RecoverySetup, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Recover sets the new password when the recovery key matches and revokes tokens issued before, called without a token
+	Recover(ctx context.Context, in *RecoverRequest, opts ...grpc.CallOption) (*RecoverResponse, error)
 }
 
 type authClient struct {
@@ -52,12 +57,34 @@ func (c *authClient) Login(ctx context.Context, in *AuthData, opts ...grpc.CallO
 	return out, nil
 }
 
+func (c *authClient) SetRecovery(ctx context.Context, in *RecoverySetup, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/secstorage.Auth/SetRecovery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authClient) Recover(ctx context.Context, in *RecoverRequest, opts ...grpc.CallOption) (*RecoverResponse, error) {
+	out := new(RecoverResponse)
+	err := c.cc.Invoke(ctx, "/secstorage.Auth/Recover", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthServer is the server API for Auth service.
 // All implementations must embed UnimplementedAuthServer
 // for forward compatibility
 type AuthServer interface {
 	Register(context.Context, *AuthData) (*TokenData, error)
 	Login(context.Context, *AuthData) (*TokenData, error)
+	// SetRecovery sets or replaces the recovery key of the caller, the current password is required
+	SetRecovery(context.Context, *RecoverySetup) (*emptypb.Empty, error)
+	// Recover sets the new password when the recovery key matches and revokes tokens issued before, called without a token
+	Recover(context.Context, *RecoverRequest) (*RecoverResponse, error)
 	mustEmbedUnimplementedAuthServer()
 }
 
@@ -71,6 +98,12 @@ func (UnimplementedAuthServer) Register(context.Context, *AuthData) (*TokenData,
 func (UnimplementedAuthServer) Login(context.Context, *AuthData) (*TokenData, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
 }
+func (UnimplementedAuthServer) SetRecovery(context.Context, *RecoverySetup) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRecovery not implemented")
+}
+func (UnimplementedAuthServer) Recover(context.Context, *RecoverRequest) (*RecoverResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Recover not implemented")
+}
 func (UnimplementedAuthServer) mustEmbedUnimplementedAuthServer() {}
 
 // UnsafeAuthServer may be embedded to opt out of forward compatibility for this service.
@@ -120,6 +153,42 @@ func _Auth_Login_Handler(srv interface{}, ctx context.Context, dec func(interfac
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Auth_SetRecovery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecoverySetup)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).SetRecovery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Auth/SetRecovery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).SetRecovery(ctx, req.(*RecoverySetup))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Auth_Recover_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecoverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServer).Recover(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Auth/Recover",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServer).Recover(ctx, req.(*RecoverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Auth_ServiceDesc is the grpc.ServiceDesc for Auth service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -135,6 +204,14 @@ var Auth_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Login",
 			Handler:    _Auth_Login_Handler,
 		},
+		{
+			MethodName: "SetRecovery",
+			Handler:    _Auth_SetRecovery_Handler,
+		},
+		{
+			MethodName: "Recover",
+			Handler:    _Auth_Recover_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/api/proto/auth.proto",
@@ -0,0 +1,387 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.11
+// source: internal/api/proto/emergency.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type EMERGENCY_STATUS int32
+
+const (
+	EMERGENCY_STATUS_IDLE      EMERGENCY_STATUS = 0
+	EMERGENCY_STATUS_REQUESTED EMERGENCY_STATUS = 1
+	EMERGENCY_STATUS_GRANTED   EMERGENCY_STATUS = 2
+)
+
+// Enum value maps for EMERGENCY_STATUS.
+var (
+	EMERGENCY_STATUS_name = map[int32]string{
+		0: "IDLE",
+		1: "REQUESTED",
+		2: "GRANTED",
+	}
+	EMERGENCY_STATUS_value = map[string]int32{
+		"IDLE":      0,
+		"REQUESTED": 1,
+		"GRANTED":   2,
+	}
+)
+
+func (x EMERGENCY_STATUS) Enum() *EMERGENCY_STATUS {
+	p := new(EMERGENCY_STATUS)
+	*p = x
+	return p
+}
+
+func (x EMERGENCY_STATUS) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EMERGENCY_STATUS) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_api_proto_emergency_proto_enumTypes[0].Descriptor()
+}
+
+func (EMERGENCY_STATUS) Type() protoreflect.EnumType {
+	return &file_internal_api_proto_emergency_proto_enumTypes[0]
+}
+
+func (x EMERGENCY_STATUS) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EMERGENCY_STATUS.Descriptor instead.
+func (EMERGENCY_STATUS) EnumDescriptor() ([]byte, []int) {
+	return file_internal_api_proto_emergency_proto_rawDescGZIP(), []int{0}
+}
+
+type TrustedContact struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Login string `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	// access requested by the contact is granted after the waiting period unless rejected
+	WaitHours int32 `protobuf:"varint,2,opt,name=waitHours,proto3" json:"waitHours,omitempty"`
+}
+
+func (x *TrustedContact) Reset() {
+	*x = TrustedContact{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_api_proto_emergency_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrustedContact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrustedContact) ProtoMessage() {}
+
+func (x *TrustedContact) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_api_proto_emergency_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrustedContact.ProtoReflect.Descriptor instead.
+func (*TrustedContact) Descriptor() ([]byte, []int) {
+	return file_internal_api_proto_emergency_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TrustedContact) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *TrustedContact) GetWaitHours() int32 {
+	if x != nil {
+		return x.WaitHours
+	}
+	return 0
+}
+
+type EmergencyAccess struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          *UUID                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	GrantorId   *UUID                  `protobuf:"bytes,2,opt,name=grantorId,proto3" json:"grantorId,omitempty"`
+	Grantor     string                 `protobuf:"bytes,3,opt,name=grantor,proto3" json:"grantor,omitempty"`
+	Trustee     string                 `protobuf:"bytes,4,opt,name=trustee,proto3" json:"trustee,omitempty"`
+	WaitHours   int32                  `protobuf:"varint,5,opt,name=waitHours,proto3" json:"waitHours,omitempty"`
+	Status      EMERGENCY_STATUS       `protobuf:"varint,6,opt,name=status,proto3,enum=secstorage.EMERGENCY_STATUS" json:"status,omitempty"`
+	RequestedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=requestedAt,proto3" json:"requestedAt,omitempty"`
+}
+
+func (x *EmergencyAccess) Reset() {
+	*x = EmergencyAccess{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_api_proto_emergency_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EmergencyAccess) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmergencyAccess) ProtoMessage() {}
+
+func (x *EmergencyAccess) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_api_proto_emergency_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmergencyAccess.ProtoReflect.Descriptor instead.
+func (*EmergencyAccess) Descriptor() ([]byte, []int) {
+	return file_internal_api_proto_emergency_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EmergencyAccess) GetId() *UUID {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *EmergencyAccess) GetGrantorId() *UUID {
+	if x != nil {
+		return x.GrantorId
+	}
+	return nil
+}
+
+func (x *EmergencyAccess) GetGrantor() string {
+	if x != nil {
+		return x.Grantor
+	}
+	return ""
+}
+
+func (x *EmergencyAccess) GetTrustee() string {
+	if x != nil {
+		return x.Trustee
+	}
+	return ""
+}
+
+func (x *EmergencyAccess) GetWaitHours() int32 {
+	if x != nil {
+		return x.WaitHours
+	}
+	return 0
+}
+
+func (x *EmergencyAccess) GetStatus() EMERGENCY_STATUS {
+	if x != nil {
+		return x.Status
+	}
+	return EMERGENCY_STATUS_IDLE
+}
+
+func (x *EmergencyAccess) GetRequestedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return nil
+}
+
+var File_internal_api_proto_emergency_proto protoreflect.FileDescriptor
+
+var file_internal_api_proto_emergency_proto_rawDesc = []byte{
+	0x0a, 0x22, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x73, 0x65, 0x63, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x21,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x44, 0x0a, 0x0e, 0x54, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x74,
+	0x61, 0x63, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x77, 0x61, 0x69,
+	0x74, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x77, 0x61,
+	0x69, 0x74, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x22, 0xa9, 0x02, 0x0a, 0x0f, 0x45, 0x6d, 0x65, 0x72,
+	0x67, 0x65, 0x6e, 0x63, 0x79, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x20, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x73, 0x65, 0x63, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x2e, 0x55, 0x55, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2e, 0x0a,
+	0x09, 0x67, 0x72, 0x61, 0x6e, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x73, 0x65, 0x63, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x55, 0x55,
+	0x49, 0x44, 0x52, 0x09, 0x67, 0x72, 0x61, 0x6e, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x67, 0x72, 0x61, 0x6e, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x67, 0x72, 0x61, 0x6e, 0x74, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x72, 0x75, 0x73, 0x74,
+	0x65, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x77, 0x61, 0x69, 0x74, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x77, 0x61, 0x69, 0x74, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x12,
+	0x34, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1c, 0x2e, 0x73, 0x65, 0x63, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x45, 0x4d, 0x45,
+	0x52, 0x47, 0x45, 0x4e, 0x43, 0x59, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x3c, 0x0a, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x2a, 0x38, 0x0a, 0x10, 0x45, 0x4d, 0x45, 0x52, 0x47, 0x45, 0x4e, 0x43, 0x59,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x44, 0x4c, 0x45, 0x10,
+	0x00, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x45, 0x44, 0x10, 0x01,
+	0x12, 0x0b, 0x0a, 0x07, 0x47, 0x52, 0x41, 0x4e, 0x54, 0x45, 0x44, 0x10, 0x02, 0x32, 0xd1, 0x02,
+	0x0a, 0x09, 0x45, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x33, 0x0a, 0x03, 0x41,
+	0x64, 0x64, 0x12, 0x1a, 0x2e, 0x73, 0x65, 0x63, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e,
+	0x54, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x1a, 0x10,
+	0x2e, 0x73, 0x65, 0x63, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x55, 0x55, 0x49, 0x44,
+	0x12, 0x3d, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x1b, 0x2e, 0x73, 0x65, 0x63, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x45, 0x6d,
+	0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x79, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x30, 0x01, 0x12,
+	0x33, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x2e, 0x73, 0x65, 0x63,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x55, 0x55, 0x49, 0x44, 0x1a, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x12, 0x33, 0x0a, 0x07, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x12,
+	0x10, 0x2e, 0x73, 0x65, 0x63, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x55, 0x55, 0x49,
+	0x44, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x32, 0x0a, 0x06, 0x52, 0x65, 0x6a,
+	0x65, 0x63, 0x74, 0x12, 0x10, 0x2e, 0x73, 0x65, 0x63, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x2e, 0x55, 0x55, 0x49, 0x44, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x32, 0x0a,
+	0x06, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x10, 0x2e, 0x73, 0x65, 0x63, 0x73, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x2e, 0x55, 0x55, 0x49, 0x44, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x42, 0x1f, 0x5a, 0x1d, 0x73, 0x65, 0x63, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2f,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_internal_api_proto_emergency_proto_rawDescOnce sync.Once
+	file_internal_api_proto_emergency_proto_rawDescData = file_internal_api_proto_emergency_proto_rawDesc
+)
+
+func file_internal_api_proto_emergency_proto_rawDescGZIP() []byte {
+	file_internal_api_proto_emergency_proto_rawDescOnce.Do(func() {
+		file_internal_api_proto_emergency_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_api_proto_emergency_proto_rawDescData)
+	})
+	return file_internal_api_proto_emergency_proto_rawDescData
+}
+
+var file_internal_api_proto_emergency_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_internal_api_proto_emergency_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_internal_api_proto_emergency_proto_goTypes = []interface{}{
+	(EMERGENCY_STATUS)(0),         // 0: secstorage.EMERGENCY_STATUS
+	(*TrustedContact)(nil),        // 1: secstorage.TrustedContact
+	(*EmergencyAccess)(nil),       // 2: secstorage.EmergencyAccess
+	(*UUID)(nil),                  // 3: secstorage.UUID
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 5: google.protobuf.Empty
+}
+var file_internal_api_proto_emergency_proto_depIdxs = []int32{
+	3,  // 0: secstorage.EmergencyAccess.id:type_name -> secstorage.UUID
+	3,  // 1: secstorage.EmergencyAccess.grantorId:type_name -> secstorage.UUID
+	0,  // 2: secstorage.EmergencyAccess.status:type_name -> secstorage.EMERGENCY_STATUS
+	4,  // 3: secstorage.EmergencyAccess.requestedAt:type_name -> google.protobuf.Timestamp
+	1,  // 4: secstorage.Emergency.Add:input_type -> secstorage.TrustedContact
+	5,  // 5: secstorage.Emergency.List:input_type -> google.protobuf.Empty
+	3,  // 6: secstorage.Emergency.Request:input_type -> secstorage.UUID
+	3,  // 7: secstorage.Emergency.Approve:input_type -> secstorage.UUID
+	3,  // 8: secstorage.Emergency.Reject:input_type -> secstorage.UUID
+	3,  // 9: secstorage.Emergency.Remove:input_type -> secstorage.UUID
+	3,  // 10: secstorage.Emergency.Add:output_type -> secstorage.UUID
+	2,  // 11: secstorage.Emergency.List:output_type -> secstorage.EmergencyAccess
+	5,  // 12: secstorage.Emergency.Request:output_type -> google.protobuf.Empty
+	5,  // 13: secstorage.Emergency.Approve:output_type -> google.protobuf.Empty
+	5,  // 14: secstorage.Emergency.Reject:output_type -> google.protobuf.Empty
+	5,  // 15: secstorage.Emergency.Remove:output_type -> google.protobuf.Empty
+	10, // [10:16] is the sub-list for method output_type
+	4,  // [4:10] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_internal_api_proto_emergency_proto_init() }
+func file_internal_api_proto_emergency_proto_init() {
+	if File_internal_api_proto_emergency_proto != nil {
+		return
+	}
+	file_internal_api_proto_resource_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_internal_api_proto_emergency_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrustedContact); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_api_proto_emergency_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmergencyAccess); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_internal_api_proto_emergency_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_internal_api_proto_emergency_proto_goTypes,
+		DependencyIndexes: file_internal_api_proto_emergency_proto_depIdxs,
+		EnumInfos:         file_internal_api_proto_emergency_proto_enumTypes,
+		MessageInfos:      file_internal_api_proto_emergency_proto_msgTypes,
+	}.Build()
+	File_internal_api_proto_emergency_proto = out.File
+	file_internal_api_proto_emergency_proto_rawDesc = nil
+	file_internal_api_proto_emergency_proto_goTypes = nil
+	file_internal_api_proto_emergency_proto_depIdxs = nil
+}
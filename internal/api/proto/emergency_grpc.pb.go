@@ -0,0 +1,316 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.11
+// source: internal/api/proto/emergency.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// EmergencyClient is the client API for Emergency service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EmergencyClient interface {
+	Add(ctx context.Context, in *TrustedContact, opts ...grpc.CallOption) (*UUID, error)
+	// List lists both contacts trusted by the caller and users who trust the caller
+	List(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Emergency_ListClient, error)
+	Request(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Approve(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Reject(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Remove(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type emergencyClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEmergencyClient(cc grpc.ClientConnInterface) EmergencyClient {
+	return &emergencyClient{cc}
+}
+
+func (c *emergencyClient) Add(ctx context.Context, in *TrustedContact, opts ...grpc.CallOption) (*UUID, error) {
+	out := new(UUID)
+	err := c.cc.Invoke(ctx, "/secstorage.Emergency/Add", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyClient) List(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Emergency_ListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Emergency_ServiceDesc.Streams[0], "/secstorage.Emergency/List", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &emergencyListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Emergency_ListClient interface {
+	Recv() (*EmergencyAccess, error)
+	grpc.ClientStream
+}
+
+type emergencyListClient struct {
+	grpc.ClientStream
+}
+
+func (x *emergencyListClient) Recv() (*EmergencyAccess, error) {
+	m := new(EmergencyAccess)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *emergencyClient) Request(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/secstorage.Emergency/Request", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyClient) Approve(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/secstorage.Emergency/Approve", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyClient) Reject(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/secstorage.Emergency/Reject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emergencyClient) Remove(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/secstorage.Emergency/Remove", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EmergencyServer is the server API for Emergency service.
+// All implementations must embed UnimplementedEmergencyServer
+// for forward compatibility
+type EmergencyServer interface {
+	Add(context.Context, *TrustedContact) (*UUID, error)
+	// List lists both contacts trusted by the caller and users who trust the caller
+	List(*emptypb.Empty, Emergency_ListServer) error
+	Request(context.Context, *UUID) (*emptypb.Empty, error)
+	Approve(context.Context, *UUID) (*emptypb.Empty, error)
+	Reject(context.Context, *UUID) (*emptypb.Empty, error)
+	Remove(context.Context, *UUID) (*emptypb.Empty, error)
+	mustEmbedUnimplementedEmergencyServer()
+}
+
+// UnimplementedEmergencyServer must be embedded to have forward compatible implementations.
+type UnimplementedEmergencyServer struct {
+}
+
+func (UnimplementedEmergencyServer) Add(context.Context, *TrustedContact) (*UUID, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Add not implemented")
+}
+func (UnimplementedEmergencyServer) List(*emptypb.Empty, Emergency_ListServer) error {
+	return status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedEmergencyServer) Request(context.Context, *UUID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Request not implemented")
+}
+func (UnimplementedEmergencyServer) Approve(context.Context, *UUID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Approve not implemented")
+}
+func (UnimplementedEmergencyServer) Reject(context.Context, *UUID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reject not implemented")
+}
+func (UnimplementedEmergencyServer) Remove(context.Context, *UUID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Remove not implemented")
+}
+func (UnimplementedEmergencyServer) mustEmbedUnimplementedEmergencyServer() {}
+
+// UnsafeEmergencyServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EmergencyServer will
+// result in compilation errors.
+type UnsafeEmergencyServer interface {
+	mustEmbedUnimplementedEmergencyServer()
+}
+
+func RegisterEmergencyServer(s grpc.ServiceRegistrar, srv EmergencyServer) {
+	s.RegisterService(&Emergency_ServiceDesc, srv)
+}
+
+func _Emergency_Add_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrustedContact)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyServer).Add(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Emergency/Add",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyServer).Add(ctx, req.(*TrustedContact))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emergency_List_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EmergencyServer).List(m, &emergencyListServer{stream})
+}
+
+type Emergency_ListServer interface {
+	Send(*EmergencyAccess) error
+	grpc.ServerStream
+}
+
+type emergencyListServer struct {
+	grpc.ServerStream
+}
+
+func (x *emergencyListServer) Send(m *EmergencyAccess) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Emergency_Request_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UUID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyServer).Request(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Emergency/Request",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyServer).Request(ctx, req.(*UUID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emergency_Approve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UUID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyServer).Approve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Emergency/Approve",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyServer).Approve(ctx, req.(*UUID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emergency_Reject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UUID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyServer).Reject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Emergency/Reject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyServer).Reject(ctx, req.(*UUID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Emergency_Remove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UUID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmergencyServer).Remove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Emergency/Remove",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmergencyServer).Remove(ctx, req.(*UUID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Emergency_ServiceDesc is the grpc.ServiceDesc for Emergency service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Emergency_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "secstorage.Emergency",
+	HandlerType: (*EmergencyServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Add",
+			Handler:    _Emergency_Add_Handler,
+		},
+		{
+			MethodName: "Request",
+			Handler:    _Emergency_Request_Handler,
+		},
+		{
+			MethodName: "Approve",
+			Handler:    _Emergency_Approve_Handler,
+		},
+		{
+			MethodName: "Reject",
+			Handler:    _Emergency_Reject_Handler,
+		},
+		{
+			MethodName: "Remove",
+			Handler:    _Emergency_Remove_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "List",
+			Handler:       _Emergency_List_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "internal/api/proto/emergency.proto",
+}
@@ -0,0 +1,476 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.11
+// source: internal/api/proto/organization.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// OrganizationsClient is the client API for Organizations service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OrganizationsClient interface {
+	Create(ctx context.Context, in *OrganizationRequest, opts ...grpc.CallOption) (*Organization, error)
+	List(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Organizations_ListClient, error)
+	Invite(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Accept(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetRole(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveMember(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListMembers(ctx context.Context, in *UUID, opts ...grpc.CallOption) (Organizations_ListMembersClient, error)
+	CreateCollection(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*Collection, error)
+	ListCollections(ctx context.Context, in *UUID, opts ...grpc.CallOption) (Organizations_ListCollectionsClient, error)
+}
+
+type organizationsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOrganizationsClient(cc grpc.ClientConnInterface) OrganizationsClient {
+	return &organizationsClient{cc}
+}
+
+func (c *organizationsClient) Create(ctx context.Context, in *OrganizationRequest, opts ...grpc.CallOption) (*Organization, error) {
+	out := new(Organization)
+	err := c.cc.Invoke(ctx, "/secstorage.Organizations/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationsClient) List(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Organizations_ListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Organizations_ServiceDesc.Streams[0], "/secstorage.Organizations/List", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &organizationsListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Organizations_ListClient interface {
+	Recv() (*Organization, error)
+	grpc.ClientStream
+}
+
+type organizationsListClient struct {
+	grpc.ClientStream
+}
+
+func (x *organizationsListClient) Recv() (*Organization, error) {
+	m := new(Organization)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *organizationsClient) Invite(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/secstorage.Organizations/Invite", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationsClient) Accept(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/secstorage.Organizations/Accept", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationsClient) SetRole(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/secstorage.Organizations/SetRole", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationsClient) RemoveMember(ctx context.Context, in *MemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/secstorage.Organizations/RemoveMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationsClient) ListMembers(ctx context.Context, in *UUID, opts ...grpc.CallOption) (Organizations_ListMembersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Organizations_ServiceDesc.Streams[1], "/secstorage.Organizations/ListMembers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &organizationsListMembersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Organizations_ListMembersClient interface {
+	Recv() (*OrgMember, error)
+	grpc.ClientStream
+}
+
+type organizationsListMembersClient struct {
+	grpc.ClientStream
+}
+
+func (x *organizationsListMembersClient) Recv() (*OrgMember, error) {
+	m := new(OrgMember)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *organizationsClient) CreateCollection(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*Collection, error) {
+	out := new(Collection)
+	err := c.cc.Invoke(ctx, "/secstorage.Organizations/CreateCollection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *organizationsClient) ListCollections(ctx context.Context, in *UUID, opts ...grpc.CallOption) (Organizations_ListCollectionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Organizations_ServiceDesc.Streams[2], "/secstorage.Organizations/ListCollections", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &organizationsListCollectionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Organizations_ListCollectionsClient interface {
+	Recv() (*Collection, error)
+	grpc.ClientStream
+}
+
+type organizationsListCollectionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *organizationsListCollectionsClient) Recv() (*Collection, error) {
+	m := new(Collection)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// OrganizationsServer is the server API for Organizations service.
+// All implementations must embed UnimplementedOrganizationsServer
+// for forward compatibility
+type OrganizationsServer interface {
+	Create(context.Context, *OrganizationRequest) (*Organization, error)
+	List(*emptypb.Empty, Organizations_ListServer) error
+	Invite(context.Context, *MemberRequest) (*emptypb.Empty, error)
+	Accept(context.Context, *UUID) (*emptypb.Empty, error)
+	SetRole(context.Context, *MemberRequest) (*emptypb.Empty, error)
+	RemoveMember(context.Context, *MemberRequest) (*emptypb.Empty, error)
+	ListMembers(*UUID, Organizations_ListMembersServer) error
+	CreateCollection(context.Context, *CollectionRequest) (*Collection, error)
+	ListCollections(*UUID, Organizations_ListCollectionsServer) error
+	mustEmbedUnimplementedOrganizationsServer()
+}
+
+// UnimplementedOrganizationsServer must be embedded to have forward compatible implementations.
+type UnimplementedOrganizationsServer struct {
+}
+
+func (UnimplementedOrganizationsServer) Create(context.Context, *OrganizationRequest) (*Organization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedOrganizationsServer) List(*emptypb.Empty, Organizations_ListServer) error {
+	return status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedOrganizationsServer) Invite(context.Context, *MemberRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Invite not implemented")
+}
+func (UnimplementedOrganizationsServer) Accept(context.Context, *UUID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Accept not implemented")
+}
+func (UnimplementedOrganizationsServer) SetRole(context.Context, *MemberRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRole not implemented")
+}
+func (UnimplementedOrganizationsServer) RemoveMember(context.Context, *MemberRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMember not implemented")
+}
+func (UnimplementedOrganizationsServer) ListMembers(*UUID, Organizations_ListMembersServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListMembers not implemented")
+}
+func (UnimplementedOrganizationsServer) CreateCollection(context.Context, *CollectionRequest) (*Collection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCollection not implemented")
+}
+func (UnimplementedOrganizationsServer) ListCollections(*UUID, Organizations_ListCollectionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListCollections not implemented")
+}
+func (UnimplementedOrganizationsServer) mustEmbedUnimplementedOrganizationsServer() {}
+
+// UnsafeOrganizationsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OrganizationsServer will
+// result in compilation errors.
+type UnsafeOrganizationsServer interface {
+	mustEmbedUnimplementedOrganizationsServer()
+}
+
+func RegisterOrganizationsServer(s grpc.ServiceRegistrar, srv OrganizationsServer) {
+	s.RegisterService(&Organizations_ServiceDesc, srv)
+}
+
+func _Organizations_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OrganizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationsServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Organizations/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationsServer).Create(ctx, req.(*OrganizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Organizations_List_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrganizationsServer).List(m, &organizationsListServer{stream})
+}
+
+type Organizations_ListServer interface {
+	Send(*Organization) error
+	grpc.ServerStream
+}
+
+type organizationsListServer struct {
+	grpc.ServerStream
+}
+
+func (x *organizationsListServer) Send(m *Organization) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Organizations_Invite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationsServer).Invite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Organizations/Invite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationsServer).Invite(ctx, req.(*MemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Organizations_Accept_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UUID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationsServer).Accept(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Organizations/Accept",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationsServer).Accept(ctx, req.(*UUID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Organizations_SetRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationsServer).SetRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Organizations/SetRole",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationsServer).SetRole(ctx, req.(*MemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Organizations_RemoveMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationsServer).RemoveMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Organizations/RemoveMember",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationsServer).RemoveMember(ctx, req.(*MemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Organizations_ListMembers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UUID)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrganizationsServer).ListMembers(m, &organizationsListMembersServer{stream})
+}
+
+type Organizations_ListMembersServer interface {
+	Send(*OrgMember) error
+	grpc.ServerStream
+}
+
+type organizationsListMembersServer struct {
+	grpc.ServerStream
+}
+
+func (x *organizationsListMembersServer) Send(m *OrgMember) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Organizations_CreateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrganizationsServer).CreateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Organizations/CreateCollection",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrganizationsServer).CreateCollection(ctx, req.(*CollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Organizations_ListCollections_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UUID)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrganizationsServer).ListCollections(m, &organizationsListCollectionsServer{stream})
+}
+
+type Organizations_ListCollectionsServer interface {
+	Send(*Collection) error
+	grpc.ServerStream
+}
+
+type organizationsListCollectionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *organizationsListCollectionsServer) Send(m *Collection) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Organizations_ServiceDesc is the grpc.ServiceDesc for Organizations service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Organizations_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "secstorage.Organizations",
+	HandlerType: (*OrganizationsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _Organizations_Create_Handler,
+		},
+		{
+			MethodName: "Invite",
+			Handler:    _Organizations_Invite_Handler,
+		},
+		{
+			MethodName: "Accept",
+			Handler:    _Organizations_Accept_Handler,
+		},
+		{
+			MethodName: "SetRole",
+			Handler:    _Organizations_SetRole_Handler,
+		},
+		{
+			MethodName: "RemoveMember",
+			Handler:    _Organizations_RemoveMember_Handler,
+		},
+		{
+			MethodName: "CreateCollection",
+			Handler:    _Organizations_CreateCollection_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "List",
+			Handler:       _Organizations_List_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListMembers",
+			Handler:       _Organizations_ListMembers_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListCollections",
+			Handler:       _Organizations_ListCollections_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "internal/api/proto/organization.proto",
+}
@@ -28,7 +28,20 @@ type ResourcesClient interface {
 	ListByUserId(ctx context.Context, in *Query, opts ...grpc.CallOption) (Resources_ListByUserIdClient, error)
 	Get(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*Resource, error)
 	SaveFile(ctx context.Context, opts ...grpc.CallOption) (Resources_SaveFileClient, error)
-	GetFile(ctx context.Context, in *UUID, opts ...grpc.CallOption) (Resources_GetFileClient, error)
+	GetFile(ctx context.Context, in *FileRequest, opts ...grpc.CallOption) (Resources_GetFileClient, error)
+	Usage(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*UsageInfo, error)
+	InitUpload(ctx context.Context, in *UploadInit, opts ...grpc.CallOption) (*UploadSession, error)
+	UploadChunk(ctx context.Context, in *FilePart, opts ...grpc.CallOption) (*UploadSession, error)
+	GetUploadOffset(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*UploadSession, error)
+	CompleteUpload(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*UUID, error)
+	// Watch streams changes of resources the caller can see until the call is canceled, the first change is RESET
+	Watch(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Resources_WatchClient, error)
+	// Sync returns the latest changes of resources the caller can see made after the since sequence, deletes are kept as tombstones
+	Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponse, error)
+	// Update replaces the resource, an update based on an older revision is kept as a conflict
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResult, error)
+	ListConflicts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Resources_ListConflictsClient, error)
+	ResolveConflict(ctx context.Context, in *ConflictResolution, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type resourcesClient struct {
@@ -132,7 +145,7 @@ func (x *resourcesSaveFileClient) CloseAndRecv() (*UUID, error) {
 	return m, nil
 }
 
-func (c *resourcesClient) GetFile(ctx context.Context, in *UUID, opts ...grpc.CallOption) (Resources_GetFileClient, error) {
+func (c *resourcesClient) GetFile(ctx context.Context, in *FileRequest, opts ...grpc.CallOption) (Resources_GetFileClient, error) {
 	stream, err := c.cc.NewStream(ctx, &Resources_ServiceDesc.Streams[2], "/secstorage.Resources/GetFile", opts...)
 	if err != nil {
 		return nil, err
@@ -164,6 +177,142 @@ func (x *resourcesGetFileClient) Recv() (*FileChunk, error) {
 	return m, nil
 }
 
+func (c *resourcesClient) Usage(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*UsageInfo, error) {
+	out := new(UsageInfo)
+	err := c.cc.Invoke(ctx, "/secstorage.Resources/Usage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourcesClient) InitUpload(ctx context.Context, in *UploadInit, opts ...grpc.CallOption) (*UploadSession, error) {
+	out := new(UploadSession)
+	err := c.cc.Invoke(ctx, "/secstorage.Resources/InitUpload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourcesClient) UploadChunk(ctx context.Context, in *FilePart, opts ...grpc.CallOption) (*UploadSession, error) {
+	out := new(UploadSession)
+	err := c.cc.Invoke(ctx, "/secstorage.Resources/UploadChunk", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourcesClient) GetUploadOffset(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*UploadSession, error) {
+	out := new(UploadSession)
+	err := c.cc.Invoke(ctx, "/secstorage.Resources/GetUploadOffset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourcesClient) CompleteUpload(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*UUID, error) {
+	out := new(UUID)
+	err := c.cc.Invoke(ctx, "/secstorage.Resources/CompleteUpload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourcesClient) Watch(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Resources_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Resources_ServiceDesc.Streams[3], "/secstorage.Resources/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &resourcesWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Resources_WatchClient interface {
+	Recv() (*ResourceChange, error)
+	grpc.ClientStream
+}
+
+type resourcesWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *resourcesWatchClient) Recv() (*ResourceChange, error) {
+	m := new(ResourceChange)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *resourcesClient) Sync(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (*SyncResponse, error) {
+	out := new(SyncResponse)
+	err := c.cc.Invoke(ctx, "/secstorage.Resources/Sync", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourcesClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResult, error) {
+	out := new(UpdateResult)
+	err := c.cc.Invoke(ctx, "/secstorage.Resources/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourcesClient) ListConflicts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Resources_ListConflictsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Resources_ServiceDesc.Streams[4], "/secstorage.Resources/ListConflicts", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &resourcesListConflictsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Resources_ListConflictsClient interface {
+	Recv() (*Conflict, error)
+	grpc.ClientStream
+}
+
+type resourcesListConflictsClient struct {
+	grpc.ClientStream
+}
+
+func (x *resourcesListConflictsClient) Recv() (*Conflict, error) {
+	m := new(Conflict)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *resourcesClient) ResolveConflict(ctx context.Context, in *ConflictResolution, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/secstorage.Resources/ResolveConflict", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ResourcesServer is the server API for Resources service.
 // All implementations must embed UnimplementedResourcesServer
 // for forward compatibility
@@ -173,7 +322,20 @@ type ResourcesServer interface {
 	ListByUserId(*Query, Resources_ListByUserIdServer) error
 	Get(context.Context, *UUID) (*Resource, error)
 	SaveFile(Resources_SaveFileServer) error
-	GetFile(*UUID, Resources_GetFileServer) error
+	GetFile(*FileRequest, Resources_GetFileServer) error
+	Usage(context.Context, *emptypb.Empty) (*UsageInfo, error)
+	InitUpload(context.Context, *UploadInit) (*UploadSession, error)
+	UploadChunk(context.Context, *FilePart) (*UploadSession, error)
+	GetUploadOffset(context.Context, *UUID) (*UploadSession, error)
+	CompleteUpload(context.Context, *UUID) (*UUID, error)
+	// Watch streams changes of resources the caller can see until the call is canceled, the first change is RESET
+	Watch(*emptypb.Empty, Resources_WatchServer) error
+	// Sync returns the latest changes of resources the caller can see made after the since sequence, deletes are kept as tombstones
+	Sync(context.Context, *SyncRequest) (*SyncResponse, error)
+	// Update replaces the resource, an update based on an older revision is kept as a conflict
+	Update(context.Context, *UpdateRequest) (*UpdateResult, error)
+	ListConflicts(*emptypb.Empty, Resources_ListConflictsServer) error
+	ResolveConflict(context.Context, *ConflictResolution) (*emptypb.Empty, error)
 	mustEmbedUnimplementedResourcesServer()
 }
 
@@ -196,9 +358,39 @@ func (UnimplementedResourcesServer) Get(context.Context, *UUID) (*Resource, erro
 func (UnimplementedResourcesServer) SaveFile(Resources_SaveFileServer) error {
 	return status.Errorf(codes.Unimplemented, "method SaveFile not implemented")
 }
-func (UnimplementedResourcesServer) GetFile(*UUID, Resources_GetFileServer) error {
+func (UnimplementedResourcesServer) GetFile(*FileRequest, Resources_GetFileServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetFile not implemented")
 }
+func (UnimplementedResourcesServer) Usage(context.Context, *emptypb.Empty) (*UsageInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Usage not implemented")
+}
+func (UnimplementedResourcesServer) InitUpload(context.Context, *UploadInit) (*UploadSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InitUpload not implemented")
+}
+func (UnimplementedResourcesServer) UploadChunk(context.Context, *FilePart) (*UploadSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UploadChunk not implemented")
+}
+func (UnimplementedResourcesServer) GetUploadOffset(context.Context, *UUID) (*UploadSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUploadOffset not implemented")
+}
+func (UnimplementedResourcesServer) CompleteUpload(context.Context, *UUID) (*UUID, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteUpload not implemented")
+}
+func (UnimplementedResourcesServer) Watch(*emptypb.Empty, Resources_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedResourcesServer) Sync(context.Context, *SyncRequest) (*SyncResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sync not implemented")
+}
+func (UnimplementedResourcesServer) Update(context.Context, *UpdateRequest) (*UpdateResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedResourcesServer) ListConflicts(*emptypb.Empty, Resources_ListConflictsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListConflicts not implemented")
+}
+func (UnimplementedResourcesServer) ResolveConflict(context.Context, *ConflictResolution) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveConflict not implemented")
+}
 func (UnimplementedResourcesServer) mustEmbedUnimplementedResourcesServer() {}
 
 // UnsafeResourcesServer may be embedded to opt out of forward compatibility for this service.
@@ -314,7 +506,7 @@ func (x *resourcesSaveFileServer) Recv() (*FileChunk, error) {
 }
 
 func _Resources_GetFile_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(UUID)
+	m := new(FileRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
@@ -334,6 +526,192 @@ func (x *resourcesGetFileServer) Send(m *FileChunk) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Resources_Usage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourcesServer).Usage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Resources/Usage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourcesServer).Usage(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Resources_InitUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadInit)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourcesServer).InitUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Resources/InitUpload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourcesServer).InitUpload(ctx, req.(*UploadInit))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Resources_UploadChunk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FilePart)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourcesServer).UploadChunk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Resources/UploadChunk",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourcesServer).UploadChunk(ctx, req.(*FilePart))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Resources_GetUploadOffset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UUID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourcesServer).GetUploadOffset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Resources/GetUploadOffset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourcesServer).GetUploadOffset(ctx, req.(*UUID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Resources_CompleteUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UUID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourcesServer).CompleteUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Resources/CompleteUpload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourcesServer).CompleteUpload(ctx, req.(*UUID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Resources_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ResourcesServer).Watch(m, &resourcesWatchServer{stream})
+}
+
+type Resources_WatchServer interface {
+	Send(*ResourceChange) error
+	grpc.ServerStream
+}
+
+type resourcesWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *resourcesWatchServer) Send(m *ResourceChange) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Resources_Sync_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourcesServer).Sync(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Resources/Sync",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourcesServer).Sync(ctx, req.(*SyncRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Resources_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourcesServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Resources/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourcesServer).Update(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Resources_ListConflicts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ResourcesServer).ListConflicts(m, &resourcesListConflictsServer{stream})
+}
+
+type Resources_ListConflictsServer interface {
+	Send(*Conflict) error
+	grpc.ServerStream
+}
+
+type resourcesListConflictsServer struct {
+	grpc.ServerStream
+}
+
+func (x *resourcesListConflictsServer) Send(m *Conflict) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Resources_ResolveConflict_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConflictResolution)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourcesServer).ResolveConflict(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Resources/ResolveConflict",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourcesServer).ResolveConflict(ctx, req.(*ConflictResolution))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Resources_ServiceDesc is the grpc.ServiceDesc for Resources service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -353,6 +731,38 @@ var Resources_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Get",
 			Handler:    _Resources_Get_Handler,
 		},
+		{
+			MethodName: "Usage",
+			Handler:    _Resources_Usage_Handler,
+		},
+		{
+			MethodName: "InitUpload",
+			Handler:    _Resources_InitUpload_Handler,
+		},
+		{
+			MethodName: "UploadChunk",
+			Handler:    _Resources_UploadChunk_Handler,
+		},
+		{
+			MethodName: "GetUploadOffset",
+			Handler:    _Resources_GetUploadOffset_Handler,
+		},
+		{
+			MethodName: "CompleteUpload",
+			Handler:    _Resources_CompleteUpload_Handler,
+		},
+		{
+			MethodName: "Sync",
+			Handler:    _Resources_Sync_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _Resources_Update_Handler,
+		},
+		{
+			MethodName: "ResolveConflict",
+			Handler:    _Resources_ResolveConflict_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -370,6 +780,16 @@ var Resources_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Resources_GetFile_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Watch",
+			Handler:       _Resources_Watch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListConflicts",
+			Handler:       _Resources_ListConflicts_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "internal/api/proto/resource.proto",
 }
@@ -0,0 +1,244 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.11
+// source: internal/api/proto/send.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SendsClient is the client API for Sends service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SendsClient interface {
+	Create(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*UUID, error)
+	// Open doesn't require a token, every call counts as a view
+	Open(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*SendData, error)
+	List(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Sends_ListClient, error)
+	Delete(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type sendsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSendsClient(cc grpc.ClientConnInterface) SendsClient {
+	return &sendsClient{cc}
+}
+
+func (c *sendsClient) Create(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*UUID, error) {
+	out := new(UUID)
+	err := c.cc.Invoke(ctx, "/secstorage.Sends/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sendsClient) Open(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*SendData, error) {
+	out := new(SendData)
+	err := c.cc.Invoke(ctx, "/secstorage.Sends/Open", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sendsClient) List(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (Sends_ListClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Sends_ServiceDesc.Streams[0], "/secstorage.Sends/List", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sendsListClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Sends_ListClient interface {
+	Recv() (*SendInfo, error)
+	grpc.ClientStream
+}
+
+type sendsListClient struct {
+	grpc.ClientStream
+}
+
+func (x *sendsListClient) Recv() (*SendInfo, error) {
+	m := new(SendInfo)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sendsClient) Delete(ctx context.Context, in *UUID, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/secstorage.Sends/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SendsServer is the server API for Sends service.
+// All implementations must embed UnimplementedSendsServer
+// for forward compatibility
+type SendsServer interface {
+	Create(context.Context, *SendRequest) (*UUID, error)
+	// Open doesn't require a token, every call counts as a view
+	Open(context.Context, *UUID) (*SendData, error)
+	List(*emptypb.Empty, Sends_ListServer) error
+	Delete(context.Context, *UUID) (*emptypb.Empty, error)
+	mustEmbedUnimplementedSendsServer()
+}
+
+// UnimplementedSendsServer must be embedded to have forward compatible implementations.
+type UnimplementedSendsServer struct {
+}
+
+func (UnimplementedSendsServer) Create(context.Context, *SendRequest) (*UUID, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedSendsServer) Open(context.Context, *UUID) (*SendData, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Open not implemented")
+}
+func (UnimplementedSendsServer) List(*emptypb.Empty, Sends_ListServer) error {
+	return status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedSendsServer) Delete(context.Context, *UUID) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedSendsServer) mustEmbedUnimplementedSendsServer() {}
+
+// UnsafeSendsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SendsServer will
+// result in compilation errors.
+type UnsafeSendsServer interface {
+	mustEmbedUnimplementedSendsServer()
+}
+
+func RegisterSendsServer(s grpc.ServiceRegistrar, srv SendsServer) {
+	s.RegisterService(&Sends_ServiceDesc, srv)
+}
+
+func _Sends_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SendsServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Sends/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SendsServer).Create(ctx, req.(*SendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sends_Open_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UUID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SendsServer).Open(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Sends/Open",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SendsServer).Open(ctx, req.(*UUID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sends_List_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SendsServer).List(m, &sendsListServer{stream})
+}
+
+type Sends_ListServer interface {
+	Send(*SendInfo) error
+	grpc.ServerStream
+}
+
+type sendsListServer struct {
+	grpc.ServerStream
+}
+
+func (x *sendsListServer) Send(m *SendInfo) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Sends_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UUID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SendsServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Sends/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SendsServer).Delete(ctx, req.(*UUID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Sends_ServiceDesc is the grpc.ServiceDesc for Sends service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Sends_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "secstorage.Sends",
+	HandlerType: (*SendsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _Sends_Create_Handler,
+		},
+		{
+			MethodName: "Open",
+			Handler:    _Sends_Open_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _Sends_Delete_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "List",
+			Handler:       _Sends_List_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "internal/api/proto/send.proto",
+}
@@ -0,0 +1,388 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.11
+// source: internal/api/proto/share.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SharesClient is the client API for Shares service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SharesClient interface {
+	SetKeys(ctx context.Context, in *KeyPair, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetKeys(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*KeyPair, error)
+	// UpdateKeys replaces the encrypted private key, the public key must stay the same
+	UpdateKeys(ctx context.Context, in *KeyPair, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetPublicKey(ctx context.Context, in *UserQuery, opts ...grpc.CallOption) (*PublicKey, error)
+	Share(ctx context.Context, in *ShareRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Unshare(ctx context.Context, in *UnshareRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateShared(ctx context.Context, in *SharedUpdate, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListGrants(ctx context.Context, in *UUID, opts ...grpc.CallOption) (Shares_ListGrantsClient, error)
+}
+
+type sharesClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSharesClient(cc grpc.ClientConnInterface) SharesClient {
+	return &sharesClient{cc}
+}
+
+func (c *sharesClient) SetKeys(ctx context.Context, in *KeyPair, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/secstorage.Shares/SetKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sharesClient) GetKeys(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*KeyPair, error) {
+	out := new(KeyPair)
+	err := c.cc.Invoke(ctx, "/secstorage.Shares/GetKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sharesClient) UpdateKeys(ctx context.Context, in *KeyPair, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/secstorage.Shares/UpdateKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sharesClient) GetPublicKey(ctx context.Context, in *UserQuery, opts ...grpc.CallOption) (*PublicKey, error) {
+	out := new(PublicKey)
+	err := c.cc.Invoke(ctx, "/secstorage.Shares/GetPublicKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sharesClient) Share(ctx context.Context, in *ShareRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/secstorage.Shares/Share", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sharesClient) Unshare(ctx context.Context, in *UnshareRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/secstorage.Shares/Unshare", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sharesClient) UpdateShared(ctx context.Context, in *SharedUpdate, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/secstorage.Shares/UpdateShared", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sharesClient) ListGrants(ctx context.Context, in *UUID, opts ...grpc.CallOption) (Shares_ListGrantsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Shares_ServiceDesc.Streams[0], "/secstorage.Shares/ListGrants", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sharesListGrantsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Shares_ListGrantsClient interface {
+	Recv() (*Grant, error)
+	grpc.ClientStream
+}
+
+type sharesListGrantsClient struct {
+	grpc.ClientStream
+}
+
+func (x *sharesListGrantsClient) Recv() (*Grant, error) {
+	m := new(Grant)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SharesServer is the server API for Shares service.
+// All implementations must embed UnimplementedSharesServer
+// for forward compatibility
+type SharesServer interface {
+	SetKeys(context.Context, *KeyPair) (*emptypb.Empty, error)
+	GetKeys(context.Context, *emptypb.Empty) (*KeyPair, error)
+	// UpdateKeys replaces the encrypted private key, the public key must stay the same
+	UpdateKeys(context.Context, *KeyPair) (*emptypb.Empty, error)
+	GetPublicKey(context.Context, *UserQuery) (*PublicKey, error)
+	Share(context.Context, *ShareRequest) (*emptypb.Empty, error)
+	Unshare(context.Context, *UnshareRequest) (*emptypb.Empty, error)
+	UpdateShared(context.Context, *SharedUpdate) (*emptypb.Empty, error)
+	ListGrants(*UUID, Shares_ListGrantsServer) error
+	mustEmbedUnimplementedSharesServer()
+}
+
+// UnimplementedSharesServer must be embedded to have forward compatible implementations.
+type UnimplementedSharesServer struct {
+}
+
+func (UnimplementedSharesServer) SetKeys(context.Context, *KeyPair) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetKeys not implemented")
+}
+func (UnimplementedSharesServer) GetKeys(context.Context, *emptypb.Empty) (*KeyPair, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetKeys not implemented")
+}
+func (UnimplementedSharesServer) UpdateKeys(context.Context, *KeyPair) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateKeys not implemented")
+}
+func (UnimplementedSharesServer) GetPublicKey(context.Context, *UserQuery) (*PublicKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPublicKey not implemented")
+}
+func (UnimplementedSharesServer) Share(context.Context, *ShareRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Share not implemented")
+}
+func (UnimplementedSharesServer) Unshare(context.Context, *UnshareRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unshare not implemented")
+}
+func (UnimplementedSharesServer) UpdateShared(context.Context, *SharedUpdate) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateShared not implemented")
+}
+func (UnimplementedSharesServer) ListGrants(*UUID, Shares_ListGrantsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListGrants not implemented")
+}
+func (UnimplementedSharesServer) mustEmbedUnimplementedSharesServer() {}
+
+// UnsafeSharesServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SharesServer will
+// result in compilation errors.
+type UnsafeSharesServer interface {
+	mustEmbedUnimplementedSharesServer()
+}
+
+func RegisterSharesServer(s grpc.ServiceRegistrar, srv SharesServer) {
+	s.RegisterService(&Shares_ServiceDesc, srv)
+}
+
+func _Shares_SetKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyPair)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharesServer).SetKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Shares/SetKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharesServer).SetKeys(ctx, req.(*KeyPair))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shares_GetKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharesServer).GetKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Shares/GetKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharesServer).GetKeys(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shares_UpdateKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyPair)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharesServer).UpdateKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Shares/UpdateKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharesServer).UpdateKeys(ctx, req.(*KeyPair))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shares_GetPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharesServer).GetPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Shares/GetPublicKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharesServer).GetPublicKey(ctx, req.(*UserQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shares_Share_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharesServer).Share(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Shares/Share",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharesServer).Share(ctx, req.(*ShareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shares_Unshare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharesServer).Unshare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Shares/Unshare",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharesServer).Unshare(ctx, req.(*UnshareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shares_UpdateShared_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SharedUpdate)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SharesServer).UpdateShared(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/secstorage.Shares/UpdateShared",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SharesServer).UpdateShared(ctx, req.(*SharedUpdate))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Shares_ListGrants_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UUID)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SharesServer).ListGrants(m, &sharesListGrantsServer{stream})
+}
+
+type Shares_ListGrantsServer interface {
+	Send(*Grant) error
+	grpc.ServerStream
+}
+
+type sharesListGrantsServer struct {
+	grpc.ServerStream
+}
+
+func (x *sharesListGrantsServer) Send(m *Grant) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Shares_ServiceDesc is the grpc.ServiceDesc for Shares service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Shares_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "secstorage.Shares",
+	HandlerType: (*SharesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetKeys",
+			Handler:    _Shares_SetKeys_Handler,
+		},
+		{
+			MethodName: "GetKeys",
+			Handler:    _Shares_GetKeys_Handler,
+		},
+		{
+			MethodName: "UpdateKeys",
+			Handler:    _Shares_UpdateKeys_Handler,
+		},
+		{
+			MethodName: "GetPublicKey",
+			Handler:    _Shares_GetPublicKey_Handler,
+		},
+		{
+			MethodName: "Share",
+			Handler:    _Shares_Share_Handler,
+		},
+		{
+			MethodName: "Unshare",
+			Handler:    _Shares_Unshare_Handler,
+		},
+		{
+			MethodName: "UpdateShared",
+			Handler:    _Shares_UpdateShared_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListGrants",
+			Handler:       _Shares_ListGrants_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "internal/api/proto/share.proto",
+}
@@ -7,4 +7,17 @@ const (
 	LoginPassword
 	File
 	BankCard
+	SecureNote
+	OTP
+	SSHKey
+	Template
+)
+
+type FieldType uint
+
+const (
+	Text FieldType = iota
+	Hidden
+	URL
+	Date
 )
@@ -0,0 +1,11 @@
+package api
+
+// Role of an organization member, roles are ordered by privileges.
+type Role uint
+
+const (
+	ReadOnly Role = iota
+	Member
+	Admin
+	Owner
+)
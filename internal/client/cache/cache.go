@@ -0,0 +1,387 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/google/uuid"
+	"os"
+	"path/filepath"
+	"secstorage/internal/api"
+	"secstorage/internal/client/keys"
+	"sync"
+)
+
+type OperationKind int
+
+const (
+	SaveOperation OperationKind = iota
+	DeleteOperation
+	UpdateOperation
+)
+
+// Entry is a resource known to the client, Data is the fetched resource and is empty until the resource is got.
+type Entry struct {
+	Id           api.ResourceId
+	Type         api.ResourceType
+	Meta         string
+	Shared       bool
+	ParentId     uuid.NullUUID
+	CollectionId uuid.NullUUID
+	Data         []byte
+	// Pending is set for resources saved offline, their ids are local until the save is replayed
+	Pending bool
+}
+
+// Operation is a write made offline, Data is the saved resource.
+type Operation struct {
+	Kind OperationKind
+	Id   api.ResourceId
+	Data []byte
+	// BaseRevision is the revision an update is made from
+	BaseRevision int64
+}
+
+type state struct {
+	Entries map[api.ResourceId]*Entry
+	Pending []Operation
+	SyncSeq int64
+}
+
+// file is the format on disk, the key of the cache is sealed with the password of the user.
+type file struct {
+	Key  []byte
+	Data []byte
+}
+
+// Cache keeps resources and offline writes of the user in a file encrypted with a key derived from the password.
+type Cache struct {
+	mutex sync.Mutex
+	path  string
+	key   *[keys.KeySize]byte
+	// sealedKey is written with every save, so the password is derived once on open
+	sealedKey []byte
+	state     state
+}
+
+// Path returns the path of the cache of the user under the user config dir.
+func Path(login string) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "secstorage", fileName(login)), nil
+}
+
+// New creates an empty cache replacing the existing one.
+func New(path, password string) (*Cache, error) {
+	key, err := keys.NewItemKey()
+	if err != nil {
+		return nil, err
+	}
+	sealedKey, err := keys.SealPrivateKey(key, password)
+	if err != nil {
+		return nil, err
+	}
+	c := &Cache{path: path, key: key, sealedKey: sealedKey, state: state{Entries: make(map[api.ResourceId]*Entry)}}
+	if err := c.save(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Open reads the cache, the error is os.ErrNotExist when there is no cache and keys.ErrDecrypt when the password is wrong.
+func Open(path, password string) (*Cache, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f file
+	if err := json.Unmarshal(raw, &f); err != nil {
+		return nil, err
+	}
+	key, err := keys.OpenPrivateKey(f.Key, password)
+	if err != nil {
+		return nil, err
+	}
+	data, err := keys.Decrypt(key, f.Data)
+	if err != nil {
+		return nil, err
+	}
+	c := &Cache{path: path, key: key, sealedKey: f.Key}
+	if err := json.Unmarshal(data, &c.state); err != nil {
+		return nil, err
+	}
+	if c.state.Entries == nil {
+		c.state.Entries = make(map[api.ResourceId]*Entry)
+	}
+	return c, nil
+}
+
+// Reseal opens the cache with the old password and seals its key with the new one, e.g. after the password is recovered,
+// so resources and offline writes are kept.
+func Reseal(path, oldPassword, newPassword string) (*Cache, error) {
+	c, err := Open(path, oldPassword)
+	if err != nil {
+		return nil, err
+	}
+	sealedKey, err := keys.SealPrivateKey(c.key, newPassword)
+	if err != nil {
+		return nil, err
+	}
+	c.sealedKey = sealedKey
+	if err := c.save(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// List returns resources of the type in the collection or in the personal vault, attachments are not listed.
+func (c *Cache) List(rType api.ResourceType, collectionId uuid.NullUUID) []Entry {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	results := make([]Entry, 0)
+	for _, entry := range c.state.Entries {
+		if entry.Type == rType && entry.CollectionId == collectionId && !entry.ParentId.Valid {
+			results = append(results, *entry)
+		}
+	}
+	return results
+}
+
+// ReplaceList sets the listed resources of the type in the collection or in the personal vault,
+// fetched data is kept for resources whose meta didn't change.
+func (c *Cache) ReplaceList(rType api.ResourceType, collectionId uuid.NullUUID, entries []Entry) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	listed := make(map[api.ResourceId]bool, len(entries))
+	for i := 0; i < len(entries); i++ {
+		listed[entries[i].Id] = true
+		c.put(entries[i])
+	}
+	for id, entry := range c.state.Entries {
+		if entry.Type == rType && entry.CollectionId == collectionId && !entry.ParentId.Valid && !entry.Pending && !listed[id] {
+			c.remove(id)
+		}
+	}
+	return c.save()
+}
+
+// Get returns the resource and its attachments, ok is false when the resource was never fetched.
+func (c *Cache) Get(id api.ResourceId) (Entry, []Entry, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, ok := c.state.Entries[id]
+	if !ok || entry.Data == nil {
+		return Entry{}, nil, false
+	}
+	attachments := make([]Entry, 0)
+	for _, attachment := range c.state.Entries {
+		if attachment.ParentId.Valid && attachment.ParentId.UUID == id {
+			attachments = append(attachments, *attachment)
+		}
+	}
+	return *entry, attachments, true
+}
+
+// Put adds or updates the resource, empty data of the entry keeps the fetched data unless the meta changed.
+func (c *Cache) Put(entries ...Entry) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for i := 0; i < len(entries); i++ {
+		c.put(entries[i])
+	}
+	return c.save()
+}
+
+// Fetched keeps the data of the got resource and lists its attachments, how the resource was listed is kept.
+func (c *Cache) Fetched(entry Entry, attachments []Entry) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if current, ok := c.state.Entries[entry.Id]; ok {
+		entry.Shared = current.Shared
+		entry.ParentId = current.ParentId
+		entry.CollectionId = current.CollectionId
+		entry.Pending = current.Pending
+	}
+	c.state.Entries[entry.Id] = &entry
+	for i := 0; i < len(attachments); i++ {
+		c.put(attachments[i])
+	}
+	return c.save()
+}
+
+// IsPending reports whether the resource was saved offline and the server doesn't know it yet.
+func (c *Cache) IsPending(id api.ResourceId) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, ok := c.state.Entries[id]
+	return ok && entry.Pending
+}
+
+// Replace sets the resource changed elsewhere, the fetched data is dropped.
+func (c *Cache) Replace(entry Entry) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.state.Entries[entry.Id] = &entry
+	return c.save()
+}
+
+// Remove removes the resource and its attachments.
+func (c *Cache) Remove(id api.ResourceId) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.remove(id)
+	return c.save()
+}
+
+// Queue keeps the write to replay it when the server is reachable again.
+// Updating a resource saved or updated offline replaces the queued data, so the update isn't taken
+// for a conflict with the previous one, and deleting a resource saved offline drops the queued save.
+func (c *Cache) Queue(operation Operation) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if operation.Kind == UpdateOperation {
+		for i := 0; i < len(c.state.Pending); i++ {
+			if c.state.Pending[i].Kind != DeleteOperation && c.state.Pending[i].Id == operation.Id {
+				c.state.Pending[i].Data = operation.Data
+				return c.save()
+			}
+		}
+	}
+	if operation.Kind == DeleteOperation {
+		for i := 0; i < len(c.state.Pending); i++ {
+			if c.state.Pending[i].Kind == SaveOperation && c.state.Pending[i].Id == operation.Id {
+				c.state.Pending = append(c.state.Pending[:i], c.state.Pending[i+1:]...)
+				c.remove(operation.Id)
+				return c.save()
+			}
+		}
+	}
+	c.state.Pending = append(c.state.Pending, operation)
+	if operation.Kind == DeleteOperation {
+		c.remove(operation.Id)
+	}
+	return c.save()
+}
+
+// Pending returns the first queued write.
+func (c *Cache) Pending() (Operation, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(c.state.Pending) == 0 {
+		return Operation{}, false
+	}
+	return c.state.Pending[0], true
+}
+
+// Done removes the first queued write, a replayed save moves the resource from the local id to the id given by the server.
+func (c *Cache) Done(savedId api.ResourceId) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(c.state.Pending) == 0 {
+		return nil
+	}
+	operation := c.state.Pending[0]
+	c.state.Pending = c.state.Pending[1:]
+	entry, ok := c.state.Entries[operation.Id]
+	if ok && operation.Kind == SaveOperation {
+		delete(c.state.Entries, operation.Id)
+		entry.Id = savedId
+		entry.Pending = false
+		c.state.Entries[savedId] = entry
+	}
+	if ok && operation.Kind == UpdateOperation {
+		// the revision is changed by the update, the resource is fetched again
+		entry.Data = nil
+	}
+	return c.save()
+}
+
+// Rejected removes the first queued write the server refused, a resource saved offline is dropped with its attachments,
+// the fetched data of an updated resource is dropped, so the server version is fetched again.
+func (c *Cache) Rejected() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(c.state.Pending) == 0 {
+		return nil
+	}
+	operation := c.state.Pending[0]
+	c.state.Pending = c.state.Pending[1:]
+	if operation.Kind == SaveOperation {
+		c.remove(operation.Id)
+	}
+	if entry, ok := c.state.Entries[operation.Id]; ok && operation.Kind == UpdateOperation {
+		entry.Data = nil
+	}
+	return c.save()
+}
+
+func (c *Cache) PendingCount() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.state.Pending)
+}
+
+func (c *Cache) SyncSeq() int64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.state.SyncSeq
+}
+
+func (c *Cache) SetSyncSeq(seq int64) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.state.SyncSeq = seq
+	return c.save()
+}
+
+func (c *Cache) put(entry Entry) {
+	if current, ok := c.state.Entries[entry.Id]; ok && entry.Data == nil && current.Meta == entry.Meta {
+		entry.Data = current.Data
+	}
+	c.state.Entries[entry.Id] = &entry
+}
+
+func (c *Cache) remove(id api.ResourceId) {
+	delete(c.state.Entries, id)
+	for attachmentId, entry := range c.state.Entries {
+		if entry.ParentId.Valid && entry.ParentId.UUID == id {
+			delete(c.state.Entries, attachmentId)
+		}
+	}
+}
+
+// save writes the cache to a temporary file and renames it, so a crash doesn't leave a broken cache.
+func (c *Cache) save() error {
+	data, err := json.Marshal(&c.state)
+	if err != nil {
+		return err
+	}
+	sealed, err := keys.Encrypt(c.key, data)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(&file{Key: c.sealedKey, Data: sealed})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return err
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// fileName hides the login, it is the only plain data the cache would reveal.
+func fileName(login string) string {
+	sum := sha256.Sum256([]byte(login))
+	return hex.EncodeToString(sum[:]) + ".cache"
+}
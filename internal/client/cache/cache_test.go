@@ -0,0 +1,145 @@
+package cache
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"secstorage/internal/api"
+	"secstorage/internal/client/keys"
+	"testing"
+)
+
+func TestCache_OpenWithPassword(t *testing.T) {
+	path := filepath.Join(t.TempDir(), fileName("login"))
+	_, err := Open(path, "password")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	c, err := New(path, "password")
+	assert.NoError(t, err)
+	id := uuid.New()
+	assert.NoError(t, c.Put(Entry{Id: id, Type: api.LoginPassword, Meta: "meta", Data: []byte("secret")}))
+
+	raw, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(raw), "meta")
+
+	_, err = Open(path, "wrong")
+	assert.ErrorIs(t, err, keys.ErrDecrypt)
+	c, err = Open(path, "password")
+	assert.NoError(t, err)
+	entry, attachments, ok := c.Get(id)
+	assert.True(t, ok)
+	assert.Equal(t, "secret", string(entry.Data))
+	assert.Equal(t, 0, len(attachments))
+}
+
+func TestCache_Reseal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), fileName("login"))
+	c, err := New(path, "old")
+	assert.NoError(t, err)
+	assert.NoError(t, c.Queue(Operation{Kind: SaveOperation, Id: uuid.New(), Data: []byte("offline")}))
+
+	_, err = Reseal(path, "wrong", "new")
+	assert.ErrorIs(t, err, keys.ErrDecrypt)
+	_, err = Reseal(path, "old", "new")
+	assert.NoError(t, err)
+
+	_, err = Open(path, "old")
+	assert.ErrorIs(t, err, keys.ErrDecrypt)
+	c, err = Open(path, "new")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, c.PendingCount())
+}
+
+func TestCache_ListKeepsFetchedData(t *testing.T) {
+	c, err := New(filepath.Join(t.TempDir(), fileName("login")), "password")
+	assert.NoError(t, err)
+	kept, changed, removed := uuid.New(), uuid.New(), uuid.New()
+	assert.NoError(t, c.Put(
+		Entry{Id: kept, Type: api.LoginPassword, Meta: "kept", Data: []byte("kept")},
+		Entry{Id: changed, Type: api.LoginPassword, Meta: "old", Data: []byte("old")},
+		Entry{Id: removed, Type: api.LoginPassword, Meta: "removed", Data: []byte("removed")},
+		Entry{Id: uuid.New(), Type: api.File, ParentId: uuid.NullUUID{UUID: removed, Valid: true}},
+	))
+
+	assert.NoError(t, c.ReplaceList(api.LoginPassword, uuid.NullUUID{}, []Entry{
+		{Id: kept, Type: api.LoginPassword, Meta: "kept"},
+		{Id: changed, Type: api.LoginPassword, Meta: "new"},
+	}))
+	assert.Equal(t, 2, len(c.List(api.LoginPassword, uuid.NullUUID{})))
+	_, _, ok := c.Get(kept)
+	assert.True(t, ok)
+	_, _, ok = c.Get(changed)
+	assert.False(t, ok)
+	assert.Equal(t, 2, len(c.state.Entries))
+}
+
+func TestCache_Queue(t *testing.T) {
+	c, err := New(filepath.Join(t.TempDir(), fileName("login")), "password")
+	assert.NoError(t, err)
+	saved, dropped, deleted := uuid.New(), uuid.New(), uuid.New()
+	assert.NoError(t, c.Queue(Operation{Kind: SaveOperation, Id: saved}))
+	assert.NoError(t, c.Put(Entry{Id: saved, Meta: "saved", Data: []byte("saved"), Pending: true}))
+	assert.NoError(t, c.Queue(Operation{Kind: SaveOperation, Id: dropped}))
+	assert.NoError(t, c.Queue(Operation{Kind: DeleteOperation, Id: dropped}))
+	assert.NoError(t, c.Queue(Operation{Kind: DeleteOperation, Id: deleted}))
+	assert.Equal(t, 2, c.PendingCount())
+
+	operation, ok := c.Pending()
+	assert.True(t, ok)
+	assert.Equal(t, saved, operation.Id)
+	serverId := uuid.New()
+	assert.NoError(t, c.Done(serverId))
+	entry, _, ok := c.Get(serverId)
+	assert.True(t, ok)
+	assert.False(t, entry.Pending)
+
+	operation, _ = c.Pending()
+	assert.Equal(t, DeleteOperation, operation.Kind)
+	assert.Equal(t, deleted, operation.Id)
+	assert.NoError(t, c.Done(uuid.Nil))
+	_, ok = c.Pending()
+	assert.False(t, ok)
+}
+
+func TestCache_Rejected(t *testing.T) {
+	c, err := New(filepath.Join(t.TempDir(), fileName("login")), "password")
+	assert.NoError(t, err)
+	saved, attachment := uuid.New(), uuid.New()
+	assert.NoError(t, c.Queue(Operation{Kind: SaveOperation, Id: saved, Data: []byte("saved")}))
+	assert.NoError(t, c.Put(
+		Entry{Id: saved, Type: api.LoginPassword, Meta: "saved", Data: []byte("saved"), Pending: true},
+		Entry{Id: attachment, Type: api.File, ParentId: uuid.NullUUID{UUID: saved, Valid: true}},
+	))
+
+	assert.NoError(t, c.Rejected())
+	assert.Equal(t, 0, c.PendingCount())
+	assert.Equal(t, 0, len(c.List(api.LoginPassword, uuid.NullUUID{})))
+	_, _, ok := c.Get(uuid.Nil)
+	assert.False(t, ok)
+	assert.Equal(t, 0, len(c.state.Entries))
+}
+
+func TestCache_QueueUpdate(t *testing.T) {
+	c, err := New(filepath.Join(t.TempDir(), fileName("login")), "password")
+	assert.NoError(t, err)
+	updated, saved := uuid.New(), uuid.New()
+	assert.NoError(t, c.Put(Entry{Id: updated, Meta: "updated", Data: []byte("first")}))
+	assert.NoError(t, c.Queue(Operation{Kind: UpdateOperation, Id: updated, Data: []byte("second"), BaseRevision: 3}))
+	assert.NoError(t, c.Queue(Operation{Kind: UpdateOperation, Id: updated, Data: []byte("third"), BaseRevision: 3}))
+	assert.NoError(t, c.Queue(Operation{Kind: SaveOperation, Id: saved, Data: []byte("saved")}))
+	assert.NoError(t, c.Queue(Operation{Kind: UpdateOperation, Id: saved, Data: []byte("changed")}))
+	assert.Equal(t, 2, c.PendingCount())
+
+	operation, _ := c.Pending()
+	assert.Equal(t, "third", string(operation.Data))
+	assert.Equal(t, int64(3), operation.BaseRevision)
+	assert.NoError(t, c.Done(uuid.Nil))
+	_, _, ok := c.Get(updated)
+	assert.False(t, ok)
+
+	operation, _ = c.Pending()
+	assert.Equal(t, SaveOperation, operation.Kind)
+	assert.Equal(t, "changed", string(operation.Data))
+}
@@ -0,0 +1,124 @@
+package keys
+
+import (
+	"crypto/rand"
+	"errors"
+	"golang.org/x/crypto/nacl/box"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+	"io"
+)
+
+const (
+	KeySize   = 32
+	nonceSize = 24
+	saltSize  = 16
+)
+
+var ErrDecrypt = errors.New("decryption failed")
+
+func GenerateKeyPair() (publicKey, privateKey *[KeySize]byte, err error) {
+	return box.GenerateKey(rand.Reader)
+}
+
+// SealPrivateKey encrypts the private key with a key derived from the password, the salt is kept in the result.
+func SealPrivateKey(privateKey *[KeySize]byte, password string) ([]byte, error) {
+	salt := make([]byte, saltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	key, err := deriveKey(password, salt)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := Encrypt(key, privateKey[:])
+	if err != nil {
+		return nil, err
+	}
+	return append(salt, sealed...), nil
+}
+
+func OpenPrivateKey(sealed []byte, password string) (*[KeySize]byte, error) {
+	if len(sealed) < saltSize {
+		return nil, ErrDecrypt
+	}
+	key, err := deriveKey(password, sealed[:saltSize])
+	if err != nil {
+		return nil, err
+	}
+	opened, err := Decrypt(key, sealed[saltSize:])
+	if err != nil {
+		return nil, err
+	}
+	if len(opened) != KeySize {
+		return nil, ErrDecrypt
+	}
+	var privateKey [KeySize]byte
+	copy(privateKey[:], opened)
+	return &privateKey, nil
+}
+
+func deriveKey(password string, salt []byte) (*[KeySize]byte, error) {
+	derived, err := scrypt.Key([]byte(password), salt, 1<<15, 8, 1, KeySize)
+	if err != nil {
+		return nil, err
+	}
+	var key [KeySize]byte
+	copy(key[:], derived)
+	return &key, nil
+}
+
+// NewItemKey generates a random key to encrypt a single item.
+func NewItemKey() (*[KeySize]byte, error) {
+	var key [KeySize]byte
+	if _, err := io.ReadFull(rand.Reader, key[:]); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// Encrypt seals data with the key, the random nonce is prepended to the result.
+func Encrypt(key *[KeySize]byte, data []byte) ([]byte, error) {
+	var nonce [nonceSize]byte
+	if _, err := io.ReadFull(rand.Reader, nonce[:]); err != nil {
+		return nil, err
+	}
+	return secretbox.Seal(nonce[:], data, &nonce, key), nil
+}
+
+func Decrypt(key *[KeySize]byte, sealed []byte) ([]byte, error) {
+	if len(sealed) < nonceSize {
+		return nil, ErrDecrypt
+	}
+	var nonce [nonceSize]byte
+	copy(nonce[:], sealed[:nonceSize])
+	data, ok := secretbox.Open(nil, sealed[nonceSize:], &nonce, key)
+	if !ok {
+		return nil, ErrDecrypt
+	}
+	return data, nil
+}
+
+// Wrap encrypts the item key for the owner of the public key.
+func Wrap(itemKey *[KeySize]byte, publicKey *[KeySize]byte) ([]byte, error) {
+	return box.SealAnonymous(nil, itemKey[:], publicKey, rand.Reader)
+}
+
+func Unwrap(wrapped []byte, publicKey, privateKey *[KeySize]byte) (*[KeySize]byte, error) {
+	opened, ok := box.OpenAnonymous(nil, wrapped, publicKey, privateKey)
+	if !ok || len(opened) != KeySize {
+		return nil, ErrDecrypt
+	}
+	var key [KeySize]byte
+	copy(key[:], opened)
+	return &key, nil
+}
+
+func ToKey(b []byte) (*[KeySize]byte, error) {
+	if len(b) != KeySize {
+		return nil, errors.New("invalid key size")
+	}
+	var key [KeySize]byte
+	copy(key[:], b)
+	return &key, nil
+}
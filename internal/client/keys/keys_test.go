@@ -0,0 +1,67 @@
+package keys
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestWrapAndEncrypt(t *testing.T) {
+	ownerPublic, ownerPrivate, err := GenerateKeyPair()
+	assert.NoError(t, err)
+	recipientPublic, recipientPrivate, err := GenerateKeyPair()
+	assert.NoError(t, err)
+
+	itemKey, err := NewItemKey()
+	assert.NoError(t, err)
+	sealed, err := Encrypt(itemKey, []byte("secret"))
+	assert.NoError(t, err)
+
+	wrapped, err := Wrap(itemKey, recipientPublic)
+	assert.NoError(t, err)
+	_, err = Unwrap(wrapped, ownerPublic, ownerPrivate)
+	assert.ErrorIs(t, err, ErrDecrypt)
+
+	unwrapped, err := Unwrap(wrapped, recipientPublic, recipientPrivate)
+	assert.NoError(t, err)
+	data, err := Decrypt(unwrapped, sealed)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", string(data))
+}
+
+func TestSealPrivateKey(t *testing.T) {
+	_, privateKey, err := GenerateKeyPair()
+	assert.NoError(t, err)
+
+	sealed, err := SealPrivateKey(privateKey, "password")
+	assert.NoError(t, err)
+
+	_, err = OpenPrivateKey(sealed, "wrong")
+	assert.ErrorIs(t, err, ErrDecrypt)
+	opened, err := OpenPrivateKey(sealed, "password")
+	assert.NoError(t, err)
+	assert.Equal(t, privateKey, opened)
+}
+
+func TestRecoveryKey(t *testing.T) {
+	publicKey, privateKey, err := GenerateKeyPair()
+	assert.NoError(t, err)
+	recoveryKey, printed, err := NewRecoveryKey()
+	assert.NoError(t, err)
+
+	parsed, err := ParseRecoveryKey(strings.ToLower(printed))
+	assert.NoError(t, err)
+	assert.Equal(t, recoveryKey, parsed)
+	_, err = ParseRecoveryKey(printed[:10])
+	assert.ErrorIs(t, err, ErrInvalidRecoveryKey)
+
+	sealed, err := SealWithRecovery(privateKey, recoveryKey)
+	assert.NoError(t, err)
+	opened, err := OpenWithRecovery(sealed, parsed)
+	assert.NoError(t, err)
+	assert.Equal(t, privateKey, opened)
+
+	computed, err := PublicKey(opened)
+	assert.NoError(t, err)
+	assert.Equal(t, publicKey, computed)
+}
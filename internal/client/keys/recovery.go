@@ -0,0 +1,85 @@
+package keys
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	"errors"
+	"golang.org/x/crypto/curve25519"
+	"strings"
+)
+
+const recoveryGroupSize = 4
+
+var ErrInvalidRecoveryKey = errors.New("invalid recovery key")
+
+// NewRecoveryKey generates a random recovery key and returns it with its printable form.
+func NewRecoveryKey() (*[KeySize]byte, string, error) {
+	key, err := NewItemKey()
+	if err != nil {
+		return nil, "", err
+	}
+	return key, FormatRecoveryKey(key), nil
+}
+
+// FormatRecoveryKey encodes the key in base32 split into groups of 4 characters.
+func FormatRecoveryKey(key *[KeySize]byte) string {
+	encoded := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(key[:])
+	groups := make([]string, 0, len(encoded)/recoveryGroupSize+1)
+	for i := 0; i < len(encoded); i += recoveryGroupSize {
+		end := i + recoveryGroupSize
+		if end > len(encoded) {
+			end = len(encoded)
+		}
+		groups = append(groups, encoded[i:end])
+	}
+	return strings.Join(groups, "-")
+}
+
+// ParseRecoveryKey parses the printed recovery key ignoring case, dashes and spaces.
+func ParseRecoveryKey(s string) (*[KeySize]byte, error) {
+	cleaned := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(s))
+	decoded, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(cleaned)
+	if err != nil || len(decoded) != KeySize {
+		return nil, ErrInvalidRecoveryKey
+	}
+	return ToKey(decoded)
+}
+
+// RecoveryAuth derives the proof of possession of the recovery key sent to the server,
+// it is independent of the key encrypting the private key.
+func RecoveryAuth(recoveryKey *[KeySize]byte) []byte {
+	return deriveRecovery(recoveryKey, "secstorage recovery auth")[:]
+}
+
+func SealWithRecovery(privateKey *[KeySize]byte, recoveryKey *[KeySize]byte) ([]byte, error) {
+	return Encrypt(deriveRecovery(recoveryKey, "secstorage recovery encryption"), privateKey[:])
+}
+
+func OpenWithRecovery(sealed []byte, recoveryKey *[KeySize]byte) (*[KeySize]byte, error) {
+	opened, err := Decrypt(deriveRecovery(recoveryKey, "secstorage recovery encryption"), sealed)
+	if err != nil {
+		return nil, err
+	}
+	if len(opened) != KeySize {
+		return nil, ErrDecrypt
+	}
+	return ToKey(opened)
+}
+
+// PublicKey computes the public key of the private key.
+func PublicKey(privateKey *[KeySize]byte) (*[KeySize]byte, error) {
+	publicKey, err := curve25519.X25519(privateKey[:], curve25519.Basepoint)
+	if err != nil {
+		return nil, err
+	}
+	return ToKey(publicKey)
+}
+
+func deriveRecovery(recoveryKey *[KeySize]byte, purpose string) *[KeySize]byte {
+	mac := hmac.New(sha256.New, recoveryKey[:])
+	mac.Write([]byte(purpose))
+	var key [KeySize]byte
+	copy(key[:], mac.Sum(nil))
+	return &key
+}
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+type AuditEvent struct {
+	Seq       int64
+	Login     string
+	Action    string
+	TargetId  uuid.NullUUID
+	Details   string
+	CreatedAt time.Time
+}
+
+func (e *AuditEvent) Print() string {
+	result := fmt.Sprintf("%v: %v %v %v", e.Seq, e.CreatedAt.Local().Format(time.RFC822), e.Login, strings.ToLower(e.Action))
+	if e.TargetId.Valid {
+		result += fmt.Sprintf(" %v", e.TargetId.UUID)
+	}
+	if e.Details != "" {
+		result += " - " + e.Details
+	}
+	return result
+}
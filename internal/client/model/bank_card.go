@@ -5,6 +5,7 @@ import (
 )
 
 type BankCard struct {
+	CustomFields
 	Number  string `json:"number,omitempty"`
 	Until   string `json:"until"`
 	Name    string `json:"name,omitempty"`
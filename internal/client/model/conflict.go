@@ -0,0 +1,97 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"reflect"
+	"secstorage/internal/api"
+	"sort"
+	"time"
+)
+
+// Conflict is a change of the resource made without knowing a later change,
+// e.g. the same resource was edited on two devices while one of them was offline.
+type Conflict struct {
+	Id              uuid.UUID
+	ResourceId      api.ResourceId
+	Current         Resource
+	CurrentMeta     string
+	CurrentRevision int64
+	Conflicting     Resource
+	ConflictingMeta string
+	BaseRevision    int64
+	CreatedAt       time.Time
+}
+
+func (c *Conflict) Print() string {
+	return fmt.Sprintf(
+		"id: %v, resource: %v, made at %v on revision %v, current revision %v\ncurrent:%v\nconflicting:%v",
+		c.Id,
+		c.ResourceId,
+		c.CreatedAt.Local().Format(time.RFC3339),
+		c.BaseRevision,
+		c.CurrentRevision,
+		c.Current.Print(c.CurrentMeta),
+		c.Conflicting.Print(c.ConflictingMeta),
+	)
+}
+
+// Merge builds the resource from the current and conflicting versions, choose is called for every field
+// the versions differ in with both values and returns true to take the conflicting one.
+func Merge(current, conflicting Resource, choose func(name, current, conflicting string) bool) (Resource, error) {
+	if reflect.TypeOf(current) != reflect.TypeOf(conflicting) {
+		return nil, fmt.Errorf("versions of different types %T and %T can't be merged", current, conflicting)
+	}
+	currentFields, err := toFieldMap(current)
+	if err != nil {
+		return nil, err
+	}
+	conflictingFields, err := toFieldMap(conflicting)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(currentFields)+len(conflictingFields))
+	for name := range currentFields {
+		names = append(names, name)
+	}
+	for name := range conflictingFields {
+		if _, ok := currentFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	merged := make(map[string]json.RawMessage, len(names))
+	for _, name := range names {
+		value, other := currentFields[name], conflictingFields[name]
+		if !bytes.Equal(value, other) && choose(name, string(value), string(other)) {
+			value = other
+		}
+		if value != nil {
+			merged[name] = value
+		}
+	}
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	result := reflect.New(reflect.TypeOf(current).Elem()).Interface().(Resource)
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func toFieldMap(resource Resource) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"fmt"
+	"secstorage/internal/api"
+	"strings"
+)
+
+type CustomField struct {
+	Name  string        `json:"name"`
+	Type  api.FieldType `json:"type"`
+	Value string        `json:"value,omitempty"`
+}
+
+type CustomFields struct {
+	Fields []CustomField `json:"fields,omitempty"`
+}
+
+func (f *CustomFields) GetFields() []CustomField {
+	return f.Fields
+}
+
+func (f *CustomFields) SetFields(fields []CustomField) {
+	f.Fields = fields
+}
+
+func ParseFieldType(s string) (api.FieldType, error) {
+	switch s {
+	case "text", "":
+		return api.Text, nil
+	case "hidden":
+		return api.Hidden, nil
+	case "url":
+		return api.URL, nil
+	case "date":
+		return api.Date, nil
+	}
+	return api.Text, fmt.Errorf("unknown field type %v", s)
+}
+
+func FieldTypeName(t api.FieldType) string {
+	switch t {
+	case api.Hidden:
+		return "hidden"
+	case api.URL:
+		return "url"
+	case api.Date:
+		return "date"
+	}
+	return "text"
+}
+
+// PrintFields renders custom fields, values of hidden fields are masked unless reveal is set.
+func PrintFields(fields []CustomField, reveal bool) string {
+	var writer strings.Builder
+	for i := 0; i < len(fields); i++ {
+		value := fields[i].Value
+		if fields[i].Type == api.Hidden && !reveal && len(value) != 0 {
+			value = "********"
+		}
+		writer.WriteString(fmt.Sprintf("\n%v:%v", fields[i].Name, value))
+	}
+	return writer.String()
+}
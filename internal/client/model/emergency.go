@@ -0,0 +1,43 @@
+package model
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"secstorage/internal/api"
+	"time"
+)
+
+type EmergencyAccess struct {
+	Id          uuid.UUID
+	GrantorId   uuid.UUID
+	Grantor     string
+	Trustee     string
+	WaitHours   int
+	Status      api.EmergencyStatus
+	RequestedAt *time.Time
+}
+
+// GrantsAt is when the requested access is granted unless it is rejected.
+func (a *EmergencyAccess) GrantsAt() time.Time {
+	if a.RequestedAt == nil {
+		return time.Time{}
+	}
+	return a.RequestedAt.Add(time.Duration(a.WaitHours) * time.Hour)
+}
+
+// Print prints the access from the point of view of the user with the login.
+func (a *EmergencyAccess) Print(login string) string {
+	var result string
+	if a.Grantor == login {
+		result = fmt.Sprintf("id: %v - %v is your trusted contact, waiting period %vh", a.Id, a.Trustee, a.WaitHours)
+	} else {
+		result = fmt.Sprintf("id: %v - you are trusted by %v (vault id %v), waiting period %vh", a.Id, a.Grantor, a.GrantorId, a.WaitHours)
+	}
+	switch a.Status {
+	case api.EmergencyRequested:
+		result += fmt.Sprintf(" - access requested, granted at %v", a.GrantsAt().Local().Format(time.RFC822))
+	case api.EmergencyGranted:
+		result += " - access granted"
+	}
+	return result
+}
@@ -0,0 +1,16 @@
+package model
+
+import "secstorage/internal/api"
+
+type Grant struct {
+	Recipient  string
+	Permission api.Permission
+}
+
+func (g *Grant) Print() string {
+	permission := "read"
+	if g.Permission == api.ReadWrite {
+		permission = "read-write"
+	}
+	return g.Recipient + " - " + permission
+}
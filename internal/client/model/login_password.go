@@ -1,16 +1,89 @@
 package model
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// StalePasswordAge is the age after which a password is reported as stale.
+const StalePasswordAge = 180 * 24 * time.Hour
 
 type LoginPassword struct {
-	Login    string `json:"login,omitempty"`
-	Password string `json:"password,omitempty"`
+	CustomFields
+	Login             string     `json:"login,omitempty"`
+	Password          string     `json:"password,omitempty"`
+	URLs              []string   `json:"urls,omitempty"`
+	Notes             string     `json:"notes,omitempty"`
+	TOTP              *OTP       `json:"totp,omitempty"`
+	PasswordChangedAt *time.Time `json:"passwordChangedAt,omitempty"`
 }
 
 func NewLoginPassword(login string, password string) *LoginPassword {
 	return &LoginPassword{Login: login, Password: password}
 }
 
+func (p *LoginPassword) IsStale(now time.Time) bool {
+	return p.PasswordChangedAt != nil && now.Sub(*p.PasswordChangedAt) > StalePasswordAge
+}
+
+// KeepPasswordAge takes the time the password was changed from the previous version when the password is the same,
+// otherwise the server stamps the change.
+func (p *LoginPassword) KeepPasswordAge(previous *LoginPassword) {
+	if p.Password == previous.Password {
+		p.PasswordChangedAt = previous.PasswordChangedAt
+	}
+}
+
+// Matches tells whether one of the entry URLs belongs to the same host as site.
+func (p *LoginPassword) Matches(site string) bool {
+	host := hostOf(site)
+	if len(host) == 0 {
+		return false
+	}
+	for _, u := range p.URLs {
+		h := hostOf(u)
+		if h == host || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+	return false
+}
+
+func hostOf(s string) string {
+	if !strings.Contains(s, "://") {
+		s = "https://" + s
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
 func (p *LoginPassword) Print(description string) string {
-	return fmt.Sprintf("\nlogin:%v\npassword:%v\ndescription:%v", p.Login, p.Password, description)
+	result := fmt.Sprintf("\nlogin:%v\npassword:%v", p.Login, p.Password)
+	if len(p.URLs) != 0 {
+		result += fmt.Sprintf("\nurls:%v", strings.Join(p.URLs, ", "))
+	}
+	if p.TOTP != nil {
+		now := time.Now()
+		key := p.TOTP.Key()
+		code, err := key.Code(now)
+		if err != nil {
+			code = "ERR: " + err.Error()
+		}
+		result += fmt.Sprintf("\ncode:%v (%vs)", code, int(key.Remaining(now).Seconds()))
+	}
+	if p.PasswordChangedAt != nil {
+		result += fmt.Sprintf("\npassword changed:%v", p.PasswordChangedAt.Local().Format("2006-01-02"))
+		if p.IsStale(time.Now()) {
+			result += " (stale, consider changing it)"
+		}
+	}
+	if len(p.Notes) != 0 {
+		result += fmt.Sprintf("\nnotes:\n%v", p.Notes)
+	}
+	return result + fmt.Sprintf("\ndescription:%v", description)
 }
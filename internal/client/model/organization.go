@@ -0,0 +1,70 @@
+package model
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"secstorage/internal/api"
+)
+
+var roleNames = map[api.Role]string{
+	api.ReadOnly: "read-only",
+	api.Member:   "member",
+	api.Admin:    "admin",
+	api.Owner:    "owner",
+}
+
+var roleAliases = map[string]api.Role{
+	"ro":        api.ReadOnly,
+	"read-only": api.ReadOnly,
+	"member":    api.Member,
+	"admin":     api.Admin,
+	"owner":     api.Owner,
+}
+
+func ParseRole(name string) (api.Role, bool) {
+	role, ok := roleAliases[name]
+	return role, ok
+}
+
+func RoleName(role api.Role) string {
+	return roleNames[role]
+}
+
+type Organization struct {
+	Id       uuid.UUID
+	Name     string
+	Role     api.Role
+	Accepted bool
+}
+
+func (o *Organization) Print() string {
+	result := fmt.Sprintf("id: %v - %v (%v)", o.Id, o.Name, RoleName(o.Role))
+	if !o.Accepted {
+		result += " - invited, run org accept to join"
+	}
+	return result
+}
+
+type OrgMember struct {
+	Login    string
+	Role     api.Role
+	Accepted bool
+}
+
+func (m *OrgMember) Print() string {
+	result := m.Login + " - " + RoleName(m.Role)
+	if !m.Accepted {
+		result += " (invited)"
+	}
+	return result
+}
+
+type Collection struct {
+	Id    uuid.UUID
+	OrgId uuid.UUID
+	Name  string
+}
+
+func (c *Collection) Print() string {
+	return fmt.Sprintf("id: %v - %v", c.Id, c.Name)
+}
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"fmt"
+	"secstorage/internal/otp"
+	"time"
+)
+
+type OTP struct {
+	CustomFields
+	Secret    string `json:"secret"`
+	Issuer    string `json:"issuer,omitempty"`
+	Account   string `json:"account,omitempty"`
+	Digits    int    `json:"digits"`
+	Period    int    `json:"period"`
+	Algorithm string `json:"algorithm"`
+}
+
+func NewOTP(key *otp.Key) *OTP {
+	return &OTP{
+		Secret:    key.Secret,
+		Issuer:    key.Issuer,
+		Account:   key.Account,
+		Digits:    key.Digits,
+		Period:    key.Period,
+		Algorithm: key.Algorithm,
+	}
+}
+
+func (o *OTP) Key() *otp.Key {
+	return &otp.Key{
+		Secret:    o.Secret,
+		Issuer:    o.Issuer,
+		Account:   o.Account,
+		Digits:    o.Digits,
+		Period:    o.Period,
+		Algorithm: o.Algorithm,
+	}
+}
+
+func (o *OTP) Print(description string) string {
+	now := time.Now()
+	key := o.Key()
+	code, err := key.Code(now)
+	if err != nil {
+		code = "ERR: " + err.Error()
+	}
+	return fmt.Sprintf(
+		"\nissuer:%v\naccount:%v\ncode:%v\nvalid for:%vs\ndescription:%v",
+		o.Issuer,
+		o.Account,
+		code,
+		int(key.Remaining(now).Seconds()),
+		description,
+	)
+}
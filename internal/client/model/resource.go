@@ -2,4 +2,6 @@ package model
 
 type Resource interface {
 	Print(description string) string
+	GetFields() []CustomField
+	SetFields([]CustomField)
 }
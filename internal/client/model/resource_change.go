@@ -0,0 +1,41 @@
+package model
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"secstorage/internal/api"
+)
+
+var changeNames = map[api.ChangeKind]string{
+	api.ChangeCreated: "created",
+	api.ChangeUpdated: "updated",
+	api.ChangeDeleted: "deleted",
+}
+
+var typeNames = map[api.ResourceType]string{
+	api.LoginPassword: "login password",
+	api.File:          "file",
+	api.BankCard:      "bank card",
+	api.SecureNote:    "secure note",
+	api.OTP:           "OTP",
+	api.SSHKey:        "SSH key",
+	api.Template:      "template",
+}
+
+type ResourceChange struct {
+	Kind api.ChangeKind
+	Id   api.ResourceId
+	Type api.ResourceType
+	// Seq, Meta and the ids are set by sync only
+	Seq          int64
+	Meta         string
+	ParentId     uuid.NullUUID
+	CollectionId uuid.NullUUID
+}
+
+func (c *ResourceChange) Print() string {
+	if c.Kind == api.ChangeReset {
+		return "resources may have changed, list them again"
+	}
+	return fmt.Sprintf("%v %v, id: %v", changeNames[c.Kind], typeNames[c.Type], c.Id)
+}
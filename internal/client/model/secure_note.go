@@ -0,0 +1,17 @@
+package model
+
+import "fmt"
+
+type SecureNote struct {
+	CustomFields
+	Title string `json:"title"`
+	Body  string `json:"body,omitempty"`
+}
+
+func NewSecureNote(title string, body string) *SecureNote {
+	return &SecureNote{Title: title, Body: body}
+}
+
+func (n *SecureNote) Print(description string) string {
+	return fmt.Sprintf("\ntitle:%v\n%v\ndescription:%v", n.Title, n.Body, description)
+}
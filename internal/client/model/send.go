@@ -0,0 +1,18 @@
+package model
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Send struct {
+	Id        uuid.UUID
+	MaxViews  int
+	Views     int
+	ExpiresAt time.Time
+}
+
+func (s *Send) Print() string {
+	return fmt.Sprintf("id: %v - viewed %v of %v times, expires at %v", s.Id, s.Views, s.MaxViews, s.ExpiresAt.Local().Format(time.RFC822))
+}
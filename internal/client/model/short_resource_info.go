@@ -5,4 +5,6 @@ import "secstorage/internal/api"
 type ShortResourceInfo struct {
 	Id   api.ResourceId
 	Meta string
+	// Shared is set for resources shared with the user by others
+	Shared bool
 }
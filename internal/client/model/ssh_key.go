@@ -0,0 +1,62 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/ssh"
+	"strings"
+)
+
+type SSHKey struct {
+	CustomFields
+	PrivateKey string `json:"privateKey"`
+	PublicKey  string `json:"publicKey,omitempty"`
+	Comment    string `json:"comment,omitempty"`
+	Passphrase string `json:"passphrase,omitempty"`
+}
+
+// NewSSHKey creates the key filling the public key from the private one when it can be decrypted.
+func NewSSHKey(privateKey string, passphrase string, comment string) (*SSHKey, error) {
+	key := &SSHKey{PrivateKey: privateKey, Comment: comment, Passphrase: passphrase}
+	var publicKey ssh.PublicKey
+	raw, err := key.Raw("")
+	var missing *ssh.PassphraseMissingError
+	switch {
+	case errors.As(err, &missing):
+		publicKey = missing.PublicKey
+	case err != nil:
+		return nil, err
+	default:
+		signer, err := ssh.NewSignerFromKey(raw)
+		if err != nil {
+			return nil, err
+		}
+		publicKey = signer.PublicKey()
+	}
+	if publicKey != nil {
+		key.PublicKey = strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey)))
+		if len(comment) != 0 {
+			key.PublicKey += " " + comment
+		}
+	}
+	return key, nil
+}
+
+// Raw returns the parsed private key, passphrase is used when the key has no stored one.
+func (k *SSHKey) Raw(passphrase string) (any, error) {
+	if len(k.Passphrase) != 0 {
+		passphrase = k.Passphrase
+	}
+	if len(passphrase) != 0 {
+		return ssh.ParseRawPrivateKeyWithPassphrase([]byte(k.PrivateKey), []byte(passphrase))
+	}
+	return ssh.ParseRawPrivateKey([]byte(k.PrivateKey))
+}
+
+func (k *SSHKey) Print(description string) string {
+	fingerprint := "N/A"
+	if publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(k.PublicKey)); err == nil {
+		fingerprint = ssh.FingerprintSHA256(publicKey)
+	}
+	return fmt.Sprintf("\ncomment:%v\nfingerprint:%v\npublic key:%v\ndescription:%v", k.Comment, fingerprint, k.PublicKey, description)
+}
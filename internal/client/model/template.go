@@ -0,0 +1,26 @@
+package model
+
+import (
+	"fmt"
+	"secstorage/internal/api"
+	"strings"
+)
+
+// Template keeps fields with default values to fill for new resources of ResourceType.
+type Template struct {
+	CustomFields
+	Name         string           `json:"name"`
+	ResourceType api.ResourceType `json:"resourceType"`
+}
+
+func NewTemplate(name string, resourceType api.ResourceType, fields []CustomField) *Template {
+	return &Template{CustomFields: CustomFields{Fields: fields}, Name: name, ResourceType: resourceType}
+}
+
+func (t *Template) Print(description string) string {
+	var writer strings.Builder
+	for i := 0; i < len(t.Fields); i++ {
+		writer.WriteString(fmt.Sprintf("\n  %v (%v) %v", t.Fields[i].Name, FieldTypeName(t.Fields[i].Type), t.Fields[i].Value))
+	}
+	return fmt.Sprintf("\nname:%v\ntype:%v\nfields:%v\ndescription:%v", t.Name, t.ResourceType, writer.String(), description)
+}
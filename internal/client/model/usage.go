@@ -0,0 +1,29 @@
+package model
+
+import "fmt"
+
+type Usage struct {
+	Items       int64
+	Bytes       int64
+	MaxItems    int64
+	MaxBytes    int64
+	MaxFileSize int64
+}
+
+func (u *Usage) Print() string {
+	return fmt.Sprintf(
+		"\nitems:%v/%v\nbytes:%v/%v\nmax file size:%v",
+		u.Items,
+		limitOrUnlimited(u.MaxItems),
+		u.Bytes,
+		limitOrUnlimited(u.MaxBytes),
+		limitOrUnlimited(u.MaxFileSize),
+	)
+}
+
+func limitOrUnlimited(limit int64) string {
+	if limit <= 0 {
+		return "unlimited"
+	}
+	return fmt.Sprint(limit)
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+)
+
+type UserInfo struct {
+	Id       uuid.UUID
+	Login    string
+	IsAdmin  bool
+	Disabled bool
+	Items    int64
+	Bytes    int64
+}
+
+func (u *UserInfo) Print() string {
+	result := fmt.Sprintf("id: %v - %v, items: %v, bytes: %v", u.Id, u.Login, u.Items, u.Bytes)
+	if u.IsAdmin {
+		result += " - admin"
+	}
+	if u.Disabled {
+		result += " - disabled"
+	}
+	return result
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"io"
+	pb "secstorage/internal/api/proto"
+	"secstorage/internal/client/model"
+)
+
+type AdminService struct {
+	client pb.AdminClient
+}
+
+func NewAdminService(client pb.AdminClient) *AdminService {
+	return &AdminService{client: client}
+}
+
+func (s *AdminService) ListUsers(ctx context.Context) ([]model.UserInfo, error) {
+	stream, err := s.client.ListUsers(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]model.UserInfo, 0)
+	for {
+		user, err := stream.Recv()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		info, err := fromPbUserInfo(user)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, *info)
+	}
+}
+
+func (s *AdminService) GetUser(ctx context.Context, login string) (*model.UserInfo, error) {
+	user, err := s.client.GetUser(ctx, &pb.UserQuery{Login: login})
+	if err != nil {
+		return nil, err
+	}
+	return fromPbUserInfo(user)
+}
+
+func (s *AdminService) SetDisabled(ctx context.Context, login string, disabled bool) error {
+	_, err := s.client.SetDisabled(ctx, &pb.AccountState{Login: login, Disabled: disabled})
+	return err
+}
+
+func (s *AdminService) Logout(ctx context.Context, login string) error {
+	_, err := s.client.Logout(ctx, &pb.UserQuery{Login: login})
+	return err
+}
+
+func (s *AdminService) CollectGarbage(ctx context.Context) (*pb.MaintenanceResult, error) {
+	return s.client.RunMaintenance(ctx, &pb.MaintenanceRequest{Task: pb.MAINTENANCE_TASK_BLOB_GC})
+}
+
+func (s *AdminService) Verify(ctx context.Context) ([]uuid.UUID, error) {
+	result, err := s.client.RunMaintenance(ctx, &pb.MaintenanceRequest{Task: pb.MAINTENANCE_TASK_VERIFY})
+	if err != nil {
+		return nil, err
+	}
+	corrupted := make([]uuid.UUID, 0, len(result.Corrupted))
+	for i := 0; i < len(result.Corrupted); i++ {
+		id, err := uuid.FromBytes(result.Corrupted[i].Value)
+		if err != nil {
+			return nil, err
+		}
+		corrupted = append(corrupted, id)
+	}
+	return corrupted, nil
+}
+
+func fromPbUserInfo(user *pb.UserInfo) (*model.UserInfo, error) {
+	id, err := uuid.FromBytes(user.Id.GetValue())
+	if err != nil {
+		return nil, err
+	}
+	return &model.UserInfo{
+		Id:       id,
+		Login:    user.Login,
+		IsAdmin:  user.IsAdmin,
+		Disabled: user.Disabled,
+		Items:    user.Items,
+		Bytes:    user.Bytes,
+	}, nil
+}
+
+func (s *AdminService) ListAudit(ctx context.Context, login string, before int64, limit int) ([]model.AuditEvent, error) {
+	stream, err := s.client.ListAudit(ctx, &pb.AuditQuery{Login: login, Before: before, Limit: int32(limit)})
+	if err != nil {
+		return nil, err
+	}
+	return receiveAuditEvents(stream.Recv)
+}
+
+func (s *AdminService) VerifyAudit(ctx context.Context) (*pb.AuditVerifyResult, error) {
+	return s.client.VerifyAudit(ctx, &emptypb.Empty{})
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"io"
+	pb "secstorage/internal/api/proto"
+	"secstorage/internal/client/model"
+)
+
+type AuditService struct {
+	client pb.AuditClient
+}
+
+func NewAuditService(client pb.AuditClient) *AuditService {
+	return &AuditService{client: client}
+}
+
+// List lists the latest events with seq lower than before, 0 lists from the latest event.
+func (s *AuditService) List(ctx context.Context, before int64, limit int) ([]model.AuditEvent, error) {
+	stream, err := s.client.List(ctx, &pb.AuditQuery{Before: before, Limit: int32(limit)})
+	if err != nil {
+		return nil, err
+	}
+	return receiveAuditEvents(stream.Recv)
+}
+
+func receiveAuditEvents(recv func() (*pb.AuditEvent, error)) ([]model.AuditEvent, error) {
+	results := make([]model.AuditEvent, 0)
+	for {
+		event, err := recv()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		result := model.AuditEvent{
+			Seq:       event.Seq,
+			Login:     event.Login,
+			Action:    event.Action.String(),
+			Details:   event.Details,
+			CreatedAt: event.CreatedAt.AsTime(),
+		}
+		if event.TargetId != nil {
+			targetId, err := uuid.FromBytes(event.TargetId.Value)
+			if err != nil {
+				return nil, err
+			}
+			result.TargetId = uuid.NullUUID{UUID: targetId, Valid: true}
+		}
+		results = append(results, result)
+	}
+}
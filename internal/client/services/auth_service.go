@@ -7,6 +7,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	pb "secstorage/internal/api/proto"
+	"secstorage/internal/client/keys"
 	. "secstorage/internal/logger"
 	"sync"
 	"time"
@@ -14,12 +15,14 @@ import (
 
 type TokenServiceSetter interface {
 	Set(string)
+	Unlock()
 }
 
 type AuthService struct {
 	authClient       pb.AuthClient
 	refreshTokenOnce sync.Once
 	tokenService     TokenServiceSetter
+	login            string
 }
 
 func NewAuthService(cl pb.AuthClient, tokenService TokenServiceSetter) *AuthService {
@@ -40,6 +43,7 @@ func (s *AuthService) Register(ctx context.Context, login, password string) (*pb
 		return nil, err
 	}
 	s.tokenService.Set(tokenData.Token)
+	s.login = login
 
 	go s.refreshToken(login, password, tokenData.ExpireAt.AsTime())
 
@@ -61,12 +65,71 @@ func (s *AuthService) Login(ctx context.Context, login, password string) (*pb.To
 	}
 
 	s.tokenService.Set(tokenData.Token)
+	s.login = login
 
 	go s.refreshToken(login, password, tokenData.ExpireAt.AsTime())
 
 	return tokenData, nil
 }
 
+// SetupRecovery generates a new recovery key replacing the previous one, the key is returned
+// in printable form and has to be written down by the user, it is not stored anywhere. The current password is required.
+func (s *AuthService) SetupRecovery(ctx context.Context, password string, keyService *KeyService) (string, error) {
+	recoveryKey, printed, err := keys.NewRecoveryKey()
+	if err != nil {
+		return "", err
+	}
+	sealed, err := keyService.SealForRecovery(recoveryKey)
+	if err != nil {
+		return "", err
+	}
+	_, err = s.authClient.SetRecovery(ctx, &pb.RecoverySetup{
+		RecoveryAuth:       keys.RecoveryAuth(recoveryKey),
+		RecoveryPrivateKey: sealed,
+		Password:           password,
+	})
+	if err != nil {
+		return "", err
+	}
+	return printed, nil
+}
+
+// Recover sets the new password proving possession of the recovery key and logs in.
+func (s *AuthService) Recover(ctx context.Context, login, printedKey, newPassword string, keyService *KeyService) error {
+	recoveryKey, err := keys.ParseRecoveryKey(printedKey)
+	if err != nil {
+		return err
+	}
+	recovered, err := s.authClient.Recover(ctx, &pb.RecoverRequest{
+		Login:        login,
+		RecoveryAuth: keys.RecoveryAuth(recoveryKey),
+		NewPassword:  newPassword,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.tokenService.Set(recovered.Token.Token)
+	s.login = login
+	go s.refreshToken(login, newPassword, recovered.Token.ExpireAt.AsTime())
+
+	return keyService.Recover(ctx, recovered.RecoveryPrivateKey, recoveryKey, newPassword)
+}
+
+// CurrentLogin returns the login of the logged in user.
+func (s *AuthService) CurrentLogin() string {
+	return s.login
+}
+
+// Unlock checks the password of the logged in user again and unlocks the session.
+func (s *AuthService) Unlock(ctx context.Context, password string) error {
+	if _, err := s.Login(ctx, s.login, password); err != nil {
+		return err
+	}
+	s.tokenService.Unlock()
+	return nil
+}
+
 func (s *AuthService) refreshToken(login, password string, expiredAt time.Time) {
 	calcRefreshTime := func(expiredAt time.Time) time.Duration {
 		return expiredAt.Sub(time.Now().UTC()) / 2
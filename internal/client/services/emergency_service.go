@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"io"
+	"secstorage/internal/api"
+	pb "secstorage/internal/api/proto"
+	"secstorage/internal/client/model"
+)
+
+type EmergencyService struct {
+	client pb.EmergencyClient
+}
+
+func NewEmergencyService(client pb.EmergencyClient) *EmergencyService {
+	return &EmergencyService{client: client}
+}
+
+func (s *EmergencyService) Add(ctx context.Context, login string, waitHours int) (uuid.UUID, error) {
+	id, err := s.client.Add(ctx, &pb.TrustedContact{Login: login, WaitHours: int32(waitHours)})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return uuid.FromBytes(id.Value)
+}
+
+func (s *EmergencyService) List(ctx context.Context) ([]model.EmergencyAccess, error) {
+	stream, err := s.client.List(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]model.EmergencyAccess, 0)
+	for {
+		access, err := stream.Recv()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		id, err := uuid.FromBytes(access.Id.Value)
+		if err != nil {
+			return nil, err
+		}
+		grantorId, err := uuid.FromBytes(access.GrantorId.Value)
+		if err != nil {
+			return nil, err
+		}
+		result := model.EmergencyAccess{
+			Id:        id,
+			GrantorId: grantorId,
+			Grantor:   access.Grantor,
+			Trustee:   access.Trustee,
+			WaitHours: int(access.WaitHours),
+			Status:    api.EmergencyStatus(access.Status),
+		}
+		if access.RequestedAt != nil {
+			requestedAt := access.RequestedAt.AsTime()
+			result.RequestedAt = &requestedAt
+		}
+		results = append(results, result)
+	}
+}
+
+// PendingRequests lists requests for access to the vault of the user with the login.
+func (s *EmergencyService) PendingRequests(ctx context.Context, login string) ([]model.EmergencyAccess, error) {
+	accesses, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]model.EmergencyAccess, 0)
+	for i := 0; i < len(accesses); i++ {
+		if accesses[i].Grantor == login && accesses[i].Status != api.EmergencyIdle {
+			results = append(results, accesses[i])
+		}
+	}
+	return results, nil
+}
+
+func (s *EmergencyService) Request(ctx context.Context, id uuid.UUID) error {
+	_, err := s.client.Request(ctx, &pb.UUID{Value: id[:]})
+	return err
+}
+
+func (s *EmergencyService) Approve(ctx context.Context, id uuid.UUID) error {
+	_, err := s.client.Approve(ctx, &pb.UUID{Value: id[:]})
+	return err
+}
+
+func (s *EmergencyService) Reject(ctx context.Context, id uuid.UUID) error {
+	_, err := s.client.Reject(ctx, &pb.UUID{Value: id[:]})
+	return err
+}
+
+func (s *EmergencyService) Remove(ctx context.Context, id uuid.UUID) error {
+	_, err := s.client.Remove(ctx, &pb.UUID{Value: id[:]})
+	return err
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	pb "secstorage/internal/api/proto"
+	"secstorage/internal/client/keys"
+	"sync"
+)
+
+var ErrNoKeys = errors.New("keys are not loaded, login again")
+
+// KeyService keeps the key pair of the user used to open shared resources.
+type KeyService struct {
+	shareClient pb.SharesClient
+	mutex       sync.RWMutex
+	publicKey   *[keys.KeySize]byte
+	privateKey  *[keys.KeySize]byte
+}
+
+func NewKeyService(cl pb.SharesClient) *KeyService {
+	return &KeyService{shareClient: cl}
+}
+
+// Init loads the key pair of the user creating it on the first login.
+func (s *KeyService) Init(ctx context.Context, password string) error {
+	keyPair, err := s.shareClient.GetKeys(ctx, &emptypb.Empty{})
+	if status.Code(err) == codes.NotFound {
+		return s.create(ctx, password)
+	}
+	if err != nil {
+		return err
+	}
+	publicKey, err := keys.ToKey(keyPair.PublicKey)
+	if err != nil {
+		return err
+	}
+	privateKey, err := keys.OpenPrivateKey(keyPair.EncryptedPrivateKey, password)
+	if err != nil {
+		return err
+	}
+	s.set(publicKey, privateKey)
+	return nil
+}
+
+func (s *KeyService) create(ctx context.Context, password string) error {
+	publicKey, privateKey, err := keys.GenerateKeyPair()
+	if err != nil {
+		return err
+	}
+	sealed, err := keys.SealPrivateKey(privateKey, password)
+	if err != nil {
+		return err
+	}
+	_, err = s.shareClient.SetKeys(ctx, &pb.KeyPair{PublicKey: publicKey[:], EncryptedPrivateKey: sealed})
+	if err != nil {
+		return err
+	}
+	s.set(publicKey, privateKey)
+	return nil
+}
+
+// SealForRecovery encrypts the private key with the recovery key.
+func (s *KeyService) SealForRecovery(recoveryKey *[keys.KeySize]byte) ([]byte, error) {
+	_, privateKey, err := s.get()
+	if err != nil {
+		return nil, err
+	}
+	return keys.SealWithRecovery(privateKey, recoveryKey)
+}
+
+// Recover opens the private key sealed with the recovery key and seals it again with the new password.
+func (s *KeyService) Recover(ctx context.Context, sealed []byte, recoveryKey *[keys.KeySize]byte, newPassword string) error {
+	privateKey, err := keys.OpenWithRecovery(sealed, recoveryKey)
+	if err != nil {
+		return err
+	}
+	publicKey, err := keys.PublicKey(privateKey)
+	if err != nil {
+		return err
+	}
+	resealed, err := keys.SealPrivateKey(privateKey, newPassword)
+	if err != nil {
+		return err
+	}
+	_, err = s.shareClient.UpdateKeys(ctx, &pb.KeyPair{PublicKey: publicKey[:], EncryptedPrivateKey: resealed})
+	if err != nil {
+		return err
+	}
+	s.set(publicKey, privateKey)
+	return nil
+}
+
+func (s *KeyService) set(publicKey, privateKey *[keys.KeySize]byte) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.publicKey = publicKey
+	s.privateKey = privateKey
+}
+
+func (s *KeyService) get() (*[keys.KeySize]byte, *[keys.KeySize]byte, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if s.privateKey == nil {
+		return nil, nil, ErrNoKeys
+	}
+	return s.publicKey, s.privateKey, nil
+}
+
+// OpenItemKey unwraps the item key wrapped for the user.
+func (s *KeyService) OpenItemKey(wrapped []byte) (*[keys.KeySize]byte, error) {
+	publicKey, privateKey, err := s.get()
+	if err != nil {
+		return nil, err
+	}
+	return keys.Unwrap(wrapped, publicKey, privateKey)
+}
+
+func (s *KeyService) WrapForSelf(itemKey *[keys.KeySize]byte) ([]byte, error) {
+	publicKey, _, err := s.get()
+	if err != nil {
+		return nil, err
+	}
+	return keys.Wrap(itemKey, publicKey)
+}
+
+// OpenShared decrypts the shared copy of the resource.
+func (s *KeyService) OpenShared(shared *pb.SharedData) (*pb.Resource, *[keys.KeySize]byte, error) {
+	itemKey, err := s.OpenItemKey(shared.WrappedKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := keys.Decrypt(itemKey, shared.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+	var resource pb.Resource
+	if err := proto.Unmarshal(data, &resource); err != nil {
+		return nil, nil, err
+	}
+	return &resource, itemKey, nil
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"secstorage/internal/api"
+	pb "secstorage/internal/api/proto"
+	"secstorage/internal/client/cache"
+	"secstorage/internal/client/model"
+	. "secstorage/internal/logger"
+)
+
+var ErrNotCached = errors.New("the server is unreachable and the resource was not fetched before")
+var ErrConflict = errors.New("the resource was changed on another device, the change is kept as a conflict")
+
+// SetCache enables the offline mode, fetched resources are kept in the cache and writes are queued
+// while the server is unreachable.
+func (s *ResourceService) SetCache(c *cache.Cache) {
+	s.cache = c
+}
+
+// Offline reports whether the last call was served from the cache.
+func (s *ResourceService) Offline() bool {
+	return s.offline.Load()
+}
+
+// SetOffline is called when the user logs in without the server, calls go to the cache until one succeeds.
+func (s *ResourceService) SetOffline() {
+	s.offline.Store(true)
+}
+
+// PendingCount returns the number of writes waiting for the server.
+func (s *ResourceService) PendingCount() int {
+	if s.cache == nil {
+		return 0
+	}
+	return s.cache.PendingCount()
+}
+
+// unreachable reports whether the call failed because the server is unreachable and the cache has to be used.
+func (s *ResourceService) unreachable(err error) bool {
+	if s.cache == nil {
+		return false
+	}
+	offline := status.Code(err) == codes.Unavailable || status.Code(err) == codes.Unauthenticated && s.offline.Load()
+	s.offline.Store(offline)
+	return offline
+}
+
+// Replay sends writes queued offline in order, it stops at the first transient error and keeps the rest.
+// Writes rejected by the server are dropped and returned as failed.
+func (s *ResourceService) Replay(ctx context.Context) (int, []error, error) {
+	if s.cache == nil {
+		return 0, nil, nil
+	}
+	var replayed int
+	var failed []error
+	for {
+		operation, ok := s.cache.Pending()
+		if !ok {
+			return replayed, failed, nil
+		}
+		savedId, err := s.replay(ctx, operation)
+		if isTransient(err) || status.Code(err) == codes.Unauthenticated {
+			return replayed, failed, err
+		}
+		if err != nil {
+			Log.Error("queued write is rejected", zap.String("id", operation.Id.String()), zap.Error(err))
+			failed = append(failed, fmt.Errorf("%v of %v: %w", operationNames[operation.Kind], operation.Id, err))
+			err = s.cache.Rejected()
+		} else {
+			replayed++
+			err = s.cache.Done(savedId)
+		}
+		if err != nil {
+			return replayed, failed, err
+		}
+	}
+}
+
+var operationNames = map[cache.OperationKind]string{
+	cache.SaveOperation:   "save",
+	cache.DeleteOperation: "delete",
+	cache.UpdateOperation: "update",
+}
+
+func (s *ResourceService) replay(ctx context.Context, operation cache.Operation) (api.ResourceId, error) {
+	if operation.Kind == cache.DeleteOperation {
+		_, err := s.resourceClient.Delete(ctx, &pb.UUID{Value: operation.Id[:]})
+		if status.Code(err) == codes.NotFound {
+			return uuid.Nil, nil
+		}
+		return uuid.Nil, err
+	}
+	var request pb.Resource
+	if err := proto.Unmarshal(operation.Data, &request); err != nil {
+		return uuid.Nil, err
+	}
+	if operation.Kind == cache.UpdateOperation {
+		request.Revision = 0
+		sharedData, err := s.sharedData(ctx, operation.Id, &request)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		result, err := s.resourceClient.Update(ctx, &pb.UpdateRequest{
+			Id:           &pb.UUID{Value: operation.Id[:]},
+			Resource:     &request,
+			BaseRevision: operation.BaseRevision,
+			SharedData:   sharedData,
+		})
+		if err == nil && result.ConflictId != nil {
+			err = ErrConflict
+		}
+		return uuid.Nil, err
+	}
+	id, err := s.resourceClient.Save(ctx, &request)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return uuid.FromBytes(id.Value)
+}
+
+// SyncCache applies changes of the user's resources made since the last sync to the cache.
+func (s *ResourceService) SyncCache(ctx context.Context) error {
+	if s.cache == nil {
+		return nil
+	}
+	changes, highWaterMark, err := s.Sync(ctx, s.cache.SyncSeq())
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(changes); i++ {
+		change := &changes[i]
+		entry := cache.Entry{
+			Id:           change.Id,
+			Type:         change.Type,
+			Meta:         change.Meta,
+			ParentId:     change.ParentId,
+			CollectionId: change.CollectionId,
+		}
+		switch change.Kind {
+		case api.ChangeDeleted:
+			err = s.cache.Remove(change.Id)
+		case api.ChangeUpdated:
+			err = s.cache.Replace(entry)
+		default:
+			err = s.cache.Put(entry)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return s.cache.SetSyncSeq(highWaterMark)
+}
+
+// queueSave keeps the resource saved offline under a local id until it is replayed.
+func (s *ResourceService) queueSave(request *pb.Resource, collectionId uuid.NullUUID) (api.ResourceId, error) {
+	data, err := proto.Marshal(request)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	id := uuid.New()
+	if err := s.cache.Queue(cache.Operation{Kind: cache.SaveOperation, Id: id, Data: data}); err != nil {
+		return uuid.Nil, err
+	}
+	err = s.cache.Put(cache.Entry{
+		Id:           id,
+		Type:         api.ResourceType(request.Type),
+		Meta:         string(request.Meta),
+		CollectionId: collectionId,
+		Data:         data,
+		Pending:      true,
+	})
+	return id, err
+}
+
+// queueUpdate keeps the update made offline, the cached resource shows it until it is replayed.
+func (s *ResourceService) queueUpdate(id api.ResourceId, request *pb.Resource, baseRevision int64) error {
+	request.Revision = baseRevision
+	data, err := proto.Marshal(request)
+	if err != nil {
+		return err
+	}
+	err = s.cache.Queue(cache.Operation{Kind: cache.UpdateOperation, Id: id, Data: data, BaseRevision: baseRevision})
+	if err != nil {
+		return err
+	}
+	return s.cache.Fetched(cache.Entry{Id: id, Type: api.ResourceType(request.Type), Meta: string(request.Meta), Data: data}, nil)
+}
+
+func (s *ResourceService) cachedList(rType api.ResourceType, collectionId uuid.NullUUID) []model.ShortResourceInfo {
+	entries := s.cache.List(rType, collectionId)
+	results := make([]model.ShortResourceInfo, 0, len(entries))
+	for i := 0; i < len(entries); i++ {
+		results = append(results, model.ShortResourceInfo{Id: entries[i].Id, Meta: entries[i].Meta, Shared: entries[i].Shared})
+	}
+	return results
+}
+
+func (s *ResourceService) cacheList(rType api.ResourceType, collectionId uuid.NullUUID, infos []model.ShortResourceInfo) {
+	entries := make([]cache.Entry, 0, len(infos))
+	for i := 0; i < len(infos); i++ {
+		entries = append(entries, cache.Entry{
+			Id:           infos[i].Id,
+			Type:         rType,
+			Meta:         infos[i].Meta,
+			Shared:       infos[i].Shared,
+			CollectionId: collectionId,
+		})
+	}
+	if err := s.cache.ReplaceList(rType, collectionId, entries); err != nil {
+		Log.Error("failed to cache resources", zap.Error(err))
+	}
+}
+
+// cachedGet returns the resource as it was fetched, shared resources are cached already opened.
+func (s *ResourceService) cachedGet(id api.ResourceId) (*pb.Resource, error) {
+	entry, attachments, ok := s.cache.Get(id)
+	if !ok {
+		return nil, ErrNotCached
+	}
+	var resource pb.Resource
+	if err := proto.Unmarshal(entry.Data, &resource); err != nil {
+		return nil, err
+	}
+	for i := 0; i < len(attachments); i++ {
+		resource.Attachments = append(resource.Attachments, &pb.ShortResourceInfo{
+			Id:   &pb.UUID{Value: attachments[i].Id[:]},
+			Meta: []byte(attachments[i].Meta),
+		})
+	}
+	return &resource, nil
+}
+
+func (s *ResourceService) cacheGet(id api.ResourceId, resource *pb.Resource, attachments []model.ShortResourceInfo) {
+	stored := proto.Clone(resource).(*pb.Resource)
+	stored.Attachments = nil
+	data, err := proto.Marshal(stored)
+	if err == nil {
+		entries := make([]cache.Entry, 0, len(attachments))
+		for i := 0; i < len(attachments); i++ {
+			entries = append(entries, cache.Entry{
+				Id:       attachments[i].Id,
+				Type:     api.File,
+				Meta:     attachments[i].Meta,
+				ParentId: uuid.NullUUID{UUID: id, Valid: true},
+			})
+		}
+		err = s.cache.Fetched(cache.Entry{Id: id, Type: api.ResourceType(resource.Type), Meta: string(resource.Meta), Data: data}, entries)
+	}
+	if err != nil {
+		Log.Error("failed to cache resource", zap.String("id", id.String()), zap.Error(err))
+	}
+}
+
+// isLocal reports whether the resource is saved offline, the server doesn't know its id yet.
+func (s *ResourceService) isLocal(id api.ResourceId) bool {
+	return s.cache != nil && s.cache.IsPending(id)
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"path/filepath"
+	"secstorage/internal/api"
+	pb "secstorage/internal/api/proto"
+	"secstorage/internal/client/cache"
+	"secstorage/internal/client/model"
+	"testing"
+)
+
+// resourcesClient answers saves with err, other calls are not expected.
+type resourcesClient struct {
+	pb.ResourcesClient
+	err error
+}
+
+func (c *resourcesClient) Save(context.Context, *pb.Resource, ...grpc.CallOption) (*pb.UUID, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	id := uuid.New()
+	return &pb.UUID{Value: id[:]}, nil
+}
+
+func TestResourceService_ReplayRejectedSave(t *testing.T) {
+	client := &resourcesClient{err: status.Error(codes.Unavailable, "down")}
+	service := NewResourceService(client, nil, t.TempDir(), false, 0)
+	c, err := cache.New(filepath.Join(t.TempDir(), "cache"), "password")
+	assert.NoError(t, err)
+	service.SetCache(c)
+
+	id, err := service.Save(context.Background(), model.NewSecureNote("title", "body"), []byte("meta"), uuid.NullUUID{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, service.PendingCount())
+	_, _, ok := c.Get(id)
+	assert.True(t, ok)
+
+	client.err = status.Error(codes.ResourceExhausted, "quota exceeded")
+	replayed, failed, err := service.Replay(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, replayed)
+	assert.Equal(t, 1, len(failed))
+	assert.Equal(t, 0, service.PendingCount())
+	assert.Equal(t, 0, len(c.List(api.SecureNote, uuid.NullUUID{})))
+	_, _, ok = c.Get(uuid.Nil)
+	assert.False(t, ok)
+}
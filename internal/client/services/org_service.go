@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"io"
+	"secstorage/internal/api"
+	pb "secstorage/internal/api/proto"
+	"secstorage/internal/client/model"
+)
+
+type OrgService struct {
+	client pb.OrganizationsClient
+}
+
+func NewOrgService(client pb.OrganizationsClient) *OrgService {
+	return &OrgService{client: client}
+}
+
+func (s *OrgService) Create(ctx context.Context, name string) (uuid.UUID, error) {
+	org, err := s.client.Create(ctx, &pb.OrganizationRequest{Name: name})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return uuid.FromBytes(org.Id.Value)
+}
+
+// List lists organizations of the user including the ones the user is invited to.
+func (s *OrgService) List(ctx context.Context) ([]model.Organization, error) {
+	stream, err := s.client.List(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]model.Organization, 0)
+	for {
+		org, err := stream.Recv()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		id, err := uuid.FromBytes(org.Id.Value)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, model.Organization{Id: id, Name: org.Name, Role: api.Role(org.Role), Accepted: org.Accepted})
+	}
+}
+
+func (s *OrgService) Invite(ctx context.Context, orgId uuid.UUID, login string, role api.Role) error {
+	_, err := s.client.Invite(ctx, &pb.MemberRequest{OrgId: &pb.UUID{Value: orgId[:]}, Login: login, Role: pb.ROLE(role)})
+	return err
+}
+
+func (s *OrgService) Accept(ctx context.Context, orgId uuid.UUID) error {
+	_, err := s.client.Accept(ctx, &pb.UUID{Value: orgId[:]})
+	return err
+}
+
+func (s *OrgService) SetRole(ctx context.Context, orgId uuid.UUID, login string, role api.Role) error {
+	_, err := s.client.SetRole(ctx, &pb.MemberRequest{OrgId: &pb.UUID{Value: orgId[:]}, Login: login, Role: pb.ROLE(role)})
+	return err
+}
+
+func (s *OrgService) Remove(ctx context.Context, orgId uuid.UUID, login string) error {
+	_, err := s.client.RemoveMember(ctx, &pb.MemberRequest{OrgId: &pb.UUID{Value: orgId[:]}, Login: login})
+	return err
+}
+
+func (s *OrgService) Members(ctx context.Context, orgId uuid.UUID) ([]model.OrgMember, error) {
+	stream, err := s.client.ListMembers(ctx, &pb.UUID{Value: orgId[:]})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]model.OrgMember, 0)
+	for {
+		member, err := stream.Recv()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, model.OrgMember{Login: member.Login, Role: api.Role(member.Role), Accepted: member.Accepted})
+	}
+}
+
+func (s *OrgService) CreateCollection(ctx context.Context, orgId uuid.UUID, name string) (uuid.UUID, error) {
+	collection, err := s.client.CreateCollection(ctx, &pb.CollectionRequest{OrgId: &pb.UUID{Value: orgId[:]}, Name: name})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return uuid.FromBytes(collection.Id.Value)
+}
+
+func (s *OrgService) Collections(ctx context.Context, orgId uuid.UUID) ([]model.Collection, error) {
+	stream, err := s.client.ListCollections(ctx, &pb.UUID{Value: orgId[:]})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]model.Collection, 0)
+	for {
+		collection, err := stream.Recv()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		id, err := uuid.FromBytes(collection.Id.Value)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, model.Collection{Id: id, OrgId: orgId, Name: collection.Name})
+	}
+}
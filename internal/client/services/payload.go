@@ -0,0 +1,226 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"secstorage/internal/api"
+	pb "secstorage/internal/api/proto"
+	"secstorage/internal/client/model"
+)
+
+func toPb(resource model.Resource, meta []byte) (*pb.Resource, error) {
+	result := &pb.Resource{Meta: meta, Fields: toPbFields(resource.GetFields())}
+	switch r := resource.(type) {
+	case *model.LoginPassword:
+		result.Type = pb.TYPE_LOGIN_PASSWORD
+		data := &pb.LoginPasswordData{
+			Login:    r.Login,
+			Password: r.Password,
+			Urls:     r.URLs,
+			Notes:    r.Notes,
+		}
+		if r.TOTP != nil {
+			data.Totp = toPbOTP(r.TOTP)
+		}
+		if r.PasswordChangedAt != nil {
+			data.PasswordChangedAt = timestamppb.New(*r.PasswordChangedAt)
+		}
+		result.Payload = &pb.Resource_LoginPassword{LoginPassword: data}
+
+	case *model.BankCard:
+		result.Type = pb.TYPE_BANK_CARD
+		result.Payload = &pb.Resource_BankCard{BankCard: &pb.BankCardData{
+			Number:  r.Number,
+			Until:   r.Until,
+			Name:    r.Name,
+			Surname: r.Surname,
+		}}
+
+	case *model.SecureNote:
+		result.Type = pb.TYPE_SECURE_NOTE
+		result.Payload = &pb.Resource_SecureNote{SecureNote: &pb.SecureNoteData{
+			Title: r.Title,
+			Body:  r.Body,
+		}}
+
+	case *model.OTP:
+		result.Type = pb.TYPE_OTP
+		result.Payload = &pb.Resource_Otp{Otp: toPbOTP(r)}
+
+	case *model.SSHKey:
+		result.Type = pb.TYPE_SSH_KEY
+		result.Payload = &pb.Resource_SshKey{SshKey: &pb.SSHKeyData{
+			PrivateKey: r.PrivateKey,
+			PublicKey:  r.PublicKey,
+			Comment:    r.Comment,
+			Passphrase: r.Passphrase,
+		}}
+
+	case *model.Template:
+		result.Type = pb.TYPE_TEMPLATE
+		result.Payload = &pb.Resource_Template{Template: &pb.TemplateData{
+			Name:         r.Name,
+			ResourceType: pb.TYPE(r.ResourceType),
+		}}
+
+	default:
+		return nil, fmt.Errorf("unsupported resource %T", resource)
+	}
+	return result, nil
+}
+
+// fromPb reads the typed payload, falling back to json data sent by older servers.
+func fromPb(resource *pb.Resource) (model.Resource, error) {
+	result, err := fromPbPayload(resource)
+	if err != nil {
+		return nil, err
+	}
+	if len(resource.Fields) != 0 {
+		result.SetFields(fromPbFields(resource.Fields))
+	}
+	return result, nil
+}
+
+func fromPbPayload(resource *pb.Resource) (model.Resource, error) {
+	switch p := resource.Payload.(type) {
+	case *pb.Resource_LoginPassword:
+		lp := model.NewLoginPassword(p.LoginPassword.Login, p.LoginPassword.Password)
+		lp.URLs = p.LoginPassword.Urls
+		lp.Notes = p.LoginPassword.Notes
+		if p.LoginPassword.Totp != nil {
+			lp.TOTP = fromPbOTP(p.LoginPassword.Totp)
+		}
+		if p.LoginPassword.PasswordChangedAt != nil {
+			changedAt := p.LoginPassword.PasswordChangedAt.AsTime()
+			lp.PasswordChangedAt = &changedAt
+		}
+		return lp, nil
+
+	case *pb.Resource_BankCard:
+		return model.NewBankCard(p.BankCard.Number, p.BankCard.Until, p.BankCard.Name, p.BankCard.Surname), nil
+
+	case *pb.Resource_SecureNote:
+		return model.NewSecureNote(p.SecureNote.Title, p.SecureNote.Body), nil
+
+	case *pb.Resource_Otp:
+		return fromPbOTP(p.Otp), nil
+
+	case *pb.Resource_SshKey:
+		return &model.SSHKey{
+			PrivateKey: p.SshKey.PrivateKey,
+			PublicKey:  p.SshKey.PublicKey,
+			Comment:    p.SshKey.Comment,
+			Passphrase: p.SshKey.Passphrase,
+		}, nil
+
+	case *pb.Resource_Template:
+		return model.NewTemplate(p.Template.Name, api.ResourceType(p.Template.ResourceType), nil), nil
+	}
+
+	switch api.ResourceType(resource.Type) {
+	case api.LoginPassword:
+		var lp model.LoginPassword
+		if err := json.Unmarshal(resource.Data, &lp); err != nil {
+			return nil, err
+		}
+		return &lp, nil
+
+	case api.BankCard:
+		var bc model.BankCard
+		if err := json.Unmarshal(resource.Data, &bc); err != nil {
+			return nil, err
+		}
+		return &bc, nil
+
+	case api.SecureNote:
+		var sn model.SecureNote
+		if err := json.Unmarshal(resource.Data, &sn); err != nil {
+			return nil, err
+		}
+		return &sn, nil
+
+	case api.OTP:
+		var key model.OTP
+		if err := json.Unmarshal(resource.Data, &key); err != nil {
+			return nil, err
+		}
+		return &key, nil
+
+	case api.SSHKey:
+		var key model.SSHKey
+		if err := json.Unmarshal(resource.Data, &key); err != nil {
+			return nil, err
+		}
+		return &key, nil
+
+	case api.Template:
+		var template model.Template
+		if err := json.Unmarshal(resource.Data, &template); err != nil {
+			return nil, err
+		}
+		return &template, nil
+	}
+	return nil, fmt.Errorf("undefined type %v", resource.Type)
+}
+
+func fromPbOTP(key *pb.OTPData) *model.OTP {
+	return &model.OTP{
+		Secret:    key.Secret,
+		Issuer:    key.Issuer,
+		Account:   key.Account,
+		Digits:    int(key.Digits),
+		Period:    int(key.Period),
+		Algorithm: key.Algorithm,
+	}
+}
+
+func toPbOTP(key *model.OTP) *pb.OTPData {
+	return &pb.OTPData{
+		Secret:    key.Secret,
+		Issuer:    key.Issuer,
+		Account:   key.Account,
+		Digits:    int32(key.Digits),
+		Period:    int32(key.Period),
+		Algorithm: key.Algorithm,
+	}
+}
+
+func fromPbFields(fields []*pb.CustomField) []model.CustomField {
+	result := make([]model.CustomField, len(fields))
+	for i := 0; i < len(fields); i++ {
+		result[i] = model.CustomField{
+			Name:  fields[i].Name,
+			Type:  api.FieldType(fields[i].Type),
+			Value: fields[i].Value,
+		}
+	}
+	return result
+}
+
+func toPbFields(fields []model.CustomField) []*pb.CustomField {
+	if len(fields) == 0 {
+		return nil
+	}
+	result := make([]*pb.CustomField, len(fields))
+	for i := 0; i < len(fields); i++ {
+		result[i] = &pb.CustomField{
+			Name:  fields[i].Name,
+			Type:  pb.FIELD_TYPE(fields[i].Type),
+			Value: fields[i].Value,
+		}
+	}
+	return result
+}
+
+func fromPbOptionalId(id *pb.UUID) (uuid.NullUUID, error) {
+	if id == nil {
+		return uuid.NullUUID{}, nil
+	}
+	result, err := uuid.FromBytes(id.Value)
+	if err != nil {
+		return uuid.NullUUID{}, err
+	}
+	return uuid.NullUUID{UUID: result, Valid: true}, nil
+}
@@ -1,32 +1,66 @@
 package services
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
+	"errors"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/protobuf/types/known/emptypb"
 	"io"
+	"os"
 	"secstorage/internal/api"
 	pb "secstorage/internal/api/proto"
+	"secstorage/internal/client/cache"
 	"secstorage/internal/client/model"
 	"secstorage/internal/fileutil"
+	. "secstorage/internal/logger"
+	"sync/atomic"
+	"time"
 )
 
 type ResourceService struct {
-	resourceClient pb.ResourcesClient
-	fileStorePath  string
+	resourceClient  pb.ResourcesClient
+	fileStorePath   string
+	fileCallOptions []grpc.CallOption
+	chunkSize       int
+	keys            *KeyService
+	// cache is set for the offline mode, resources are served from it when the server is unreachable
+	cache   *cache.Cache
+	offline atomic.Bool
 }
 
-func NewResourceService(cl pb.ResourcesClient, fileStorePath string) *ResourceService {
-	return &ResourceService{resourceClient: cl, fileStorePath: fileStorePath}
+// NewResourceService creates the service, compress enables gzip compression of file transfers
+// and chunkSize is the preferred size of file chunks, the server may lower it.
+func NewResourceService(cl pb.ResourcesClient, keys *KeyService, fileStorePath string, compress bool, chunkSize int) *ResourceService {
+	var fileCallOptions []grpc.CallOption
+	if compress {
+		fileCallOptions = append(fileCallOptions, grpc.UseCompressor(gzip.Name))
+	}
+	return &ResourceService{
+		resourceClient:  cl,
+		fileStorePath:   fileStorePath,
+		fileCallOptions: fileCallOptions,
+		chunkSize:       chunkSize,
+		keys:            keys,
+	}
 }
 
-func (s *ResourceService) Save(ctx context.Context, dType api.ResourceType, data []byte, meta []byte) (api.ResourceId, error) {
-	id, err := s.resourceClient.Save(ctx, &pb.Resource{
-		Type: pb.TYPE(dType),
-		Data: data,
-		Meta: meta,
-	})
+// Save saves the resource to the collection when collectionId is valid or to the personal vault otherwise.
+func (s *ResourceService) Save(ctx context.Context, resource model.Resource, meta []byte, collectionId uuid.NullUUID) (api.ResourceId, error) {
+	request, err := toPb(resource, meta)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if collectionId.Valid {
+		request.CollectionId = &pb.UUID{Value: collectionId.UUID[:]}
+	}
+	id, err := s.resourceClient.Save(ctx, request)
+	if s.unreachable(err) {
+		return s.queueSave(request, collectionId)
+	}
 	if err != nil {
 		return uuid.Nil, err
 	}
@@ -38,12 +72,42 @@ func (s *ResourceService) Save(ctx context.Context, dType api.ResourceType, data
 }
 
 func (s *ResourceService) Delete(ctx context.Context, resourceId api.ResourceId) error {
+	if s.isLocal(resourceId) {
+		return s.cache.Queue(cache.Operation{Kind: cache.DeleteOperation, Id: resourceId})
+	}
 	_, err := s.resourceClient.Delete(ctx, &pb.UUID{Value: resourceId[:]})
+	if s.unreachable(err) {
+		return s.cache.Queue(cache.Operation{Kind: cache.DeleteOperation, Id: resourceId})
+	}
+	if err == nil && s.cache != nil {
+		return s.cache.Remove(resourceId)
+	}
 	return err
 }
 
-func (s *ResourceService) ListByUserId(ctx context.Context, rType api.ResourceType) ([]model.ShortResourceInfo, error) {
-	stream, err := s.resourceClient.ListByUserId(ctx, &pb.Query{ResourceType: pb.TYPE(rType)})
+// ListByUserId lists resources of the collection when collectionId is valid or own and shared resources otherwise.
+func (s *ResourceService) ListByUserId(ctx context.Context, rType api.ResourceType, collectionId uuid.NullUUID) ([]model.ShortResourceInfo, error) {
+	query := &pb.Query{ResourceType: pb.TYPE(rType)}
+	if collectionId.Valid {
+		query.CollectionId = &pb.UUID{Value: collectionId.UUID[:]}
+	}
+	results, err := s.list(ctx, query)
+	if s.unreachable(err) {
+		return s.cachedList(rType, collectionId), nil
+	}
+	if err == nil && s.cache != nil {
+		s.cacheList(rType, collectionId, results)
+	}
+	return results, err
+}
+
+// ListOwnedBy lists resources of the user who gave the caller emergency access.
+func (s *ResourceService) ListOwnedBy(ctx context.Context, rType api.ResourceType, ownerId api.UserId) ([]model.ShortResourceInfo, error) {
+	return s.list(ctx, &pb.Query{ResourceType: pb.TYPE(rType), OwnerId: &pb.UUID{Value: ownerId[:]}})
+}
+
+func (s *ResourceService) list(ctx context.Context, query *pb.Query) ([]model.ShortResourceInfo, error) {
+	stream, err := s.resourceClient.ListByUserId(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -53,83 +117,402 @@ func (s *ResourceService) ListByUserId(ctx context.Context, rType api.ResourceTy
 		if err == io.EOF {
 			break
 		}
+		if err != nil {
+			return nil, err
+		}
 		id, err := uuid.FromBytes(info.Id.Value)
 		if err != nil {
 			return nil, err
 		}
 		results = append(results, model.ShortResourceInfo{
-			Id:   id,
-			Meta: string(info.Meta),
+			Id:     id,
+			Meta:   string(info.Meta),
+			Shared: info.Shared,
 		})
 	}
 	return results, nil
 }
 
+// Watch passes changes of the user's resources to handler until ctx is done. The stream is reopened
+// after transient errors, every stream starts with a reset, so the handler knows changes may be lost.
+func (s *ResourceService) Watch(ctx context.Context, handler func(model.ResourceChange)) error {
+	for {
+		err := s.watch(ctx, handler)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != io.EOF && !isTransient(err) {
+			return err
+		}
+		Log.Warn("watch stream is closed, reopening", zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(retryBackoff):
+		}
+	}
+}
+
+func (s *ResourceService) watch(ctx context.Context, handler func(model.ResourceChange)) error {
+	stream, err := s.resourceClient.Watch(ctx, &emptypb.Empty{})
+	if err != nil {
+		return err
+	}
+	for {
+		change, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		var id uuid.UUID
+		if change.Id != nil {
+			if id, err = uuid.FromBytes(change.Id.Value); err != nil {
+				return err
+			}
+		}
+		handler(model.ResourceChange{Kind: api.ChangeKind(change.Kind), Id: id, Type: api.ResourceType(change.Type)})
+	}
+}
+
+// Sync returns changes of the user's own resources made after the since sequence and the sequence to sync from next time.
+func (s *ResourceService) Sync(ctx context.Context, since int64) ([]model.ResourceChange, int64, error) {
+	results := make([]model.ResourceChange, 0)
+	for {
+		response, err := s.resourceClient.Sync(ctx, &pb.SyncRequest{Since: since})
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, change := range response.Changes {
+			id, err := uuid.FromBytes(change.Id.GetValue())
+			if err != nil {
+				return nil, 0, err
+			}
+			parentId, err := fromPbOptionalId(change.ParentId)
+			if err != nil {
+				return nil, 0, err
+			}
+			collectionId, err := fromPbOptionalId(change.CollectionId)
+			if err != nil {
+				return nil, 0, err
+			}
+			results = append(results, model.ResourceChange{
+				Kind:         api.ChangeKind(change.Kind),
+				Id:           id,
+				Type:         api.ResourceType(change.Type),
+				Seq:          change.Seq,
+				Meta:         string(change.Meta),
+				ParentId:     parentId,
+				CollectionId: collectionId,
+			})
+		}
+		since = response.HighWaterMark
+		if !response.More {
+			return results, since, nil
+		}
+	}
+}
+
 func (s *ResourceService) Get(ctx context.Context, id api.ResourceId) (model.Resource, []byte, error) {
-	resource, err := s.resourceClient.Get(ctx, &pb.UUID{Value: id[:]})
+	data, meta, _, err := s.GetWithAttachments(ctx, id)
+	return data, meta, err
+}
+
+// GetWithAttachments gets the resource and the list of files attached to it.
+func (s *ResourceService) GetWithAttachments(ctx context.Context, id api.ResourceId) (model.Resource, []byte, []model.ShortResourceInfo, error) {
+	resource, attachments, err := s.fetch(ctx, id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	data, err := fromPb(resource)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return data, resource.Meta, attachments, nil
+}
+
+// GetRevision gets the resource and its revision, the revision is the base of the next update.
+func (s *ResourceService) GetRevision(ctx context.Context, id api.ResourceId) (model.Resource, []byte, int64, error) {
+	resource, _, err := s.fetch(ctx, id)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	data, err := fromPb(resource)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return data, resource.Meta, resource.Revision, nil
+}
+
+func (s *ResourceService) fetch(ctx context.Context, id api.ResourceId) (*pb.Resource, []model.ShortResourceInfo, error) {
+	var resource *pb.Resource
+	var err error
+	if s.isLocal(id) {
+		resource, err = s.cachedGet(id)
+	} else if resource, err = s.resourceClient.Get(ctx, &pb.UUID{Value: id[:]}); s.unreachable(err) {
+		resource, err = s.cachedGet(id)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
-	switch api.ResourceType(resource.Type) {
-	case api.LoginPassword:
-		var lp model.LoginPassword
-		if err := json.Unmarshal(resource.Data, &lp); err != nil {
+	if resource.Shared != nil {
+		// the shared copy is the current one, it is updated together with the resource
+		// and recipients with write access may have changed it
+		content, _, err := s.keys.OpenShared(resource.Shared)
+		if err != nil {
 			return nil, nil, err
 		}
-
-		return &lp, resource.Meta, nil
-
-	case api.BankCard:
-		var bc model.BankCard
-		if err := json.Unmarshal(resource.Data, &bc); err != nil {
+		content.Attachments = resource.Attachments
+		content.Revision = resource.Revision
+		resource = content
+	}
+	attachments := make([]model.ShortResourceInfo, 0, len(resource.Attachments))
+	for i := 0; i < len(resource.Attachments); i++ {
+		attachmentId, err := uuid.FromBytes(resource.Attachments[i].Id.Value)
+		if err != nil {
 			return nil, nil, err
 		}
-		return &bc, resource.Meta, nil
+		attachments = append(attachments, model.ShortResourceInfo{
+			Id:   attachmentId,
+			Meta: string(resource.Attachments[i].Meta),
+		})
+	}
+	if s.cache != nil && !s.offline.Load() && !s.isLocal(id) {
+		s.cacheGet(id, resource, attachments)
 	}
-	return nil, nil, fmt.Errorf("undefined type %v", resource.Type)
+	return resource, attachments, nil
 }
 
-func (s *ResourceService) SaveFile(ctx context.Context, description, path string) (api.ResourceId, error) {
-	stream, err := s.resourceClient.SaveFile(ctx)
+// Update replaces the resource if it is still at the base revision and returns the new revision.
+// Otherwise the server keeps the update as a conflict and its id is returned.
+func (s *ResourceService) Update(ctx context.Context, id api.ResourceId, resource model.Resource, meta []byte, baseRevision int64) (int64, uuid.NullUUID, error) {
+	request, err := toPb(resource, meta)
 	if err != nil {
-		return uuid.Nil, err
+		return 0, uuid.NullUUID{}, err
+	}
+	if s.isLocal(id) {
+		// the update replaces the queued save, which knows the collection
+		if saved, err := s.cachedGet(id); err == nil {
+			request.CollectionId = saved.CollectionId
+		}
+		return baseRevision, uuid.NullUUID{}, s.queueUpdate(id, request, baseRevision)
+	}
+	sharedData, err := s.sharedData(ctx, id, request)
+	if s.unreachable(err) {
+		return baseRevision, uuid.NullUUID{}, s.queueUpdate(id, request, baseRevision)
 	}
-	err = stream.Send(&pb.FileChunk{
-		Meta: []byte(description),
-		Data: nil,
+	if err != nil {
+		return 0, uuid.NullUUID{}, err
+	}
+	result, err := s.resourceClient.Update(ctx, &pb.UpdateRequest{
+		Id:           &pb.UUID{Value: id[:]},
+		Resource:     request,
+		BaseRevision: baseRevision,
+		SharedData:   sharedData,
 	})
+	if s.unreachable(err) {
+		return baseRevision, uuid.NullUUID{}, s.queueUpdate(id, request, baseRevision)
+	}
+	if err != nil {
+		return 0, uuid.NullUUID{}, err
+	}
+	conflictId, err := fromPbOptionalId(result.ConflictId)
+	if err != nil {
+		return 0, uuid.NullUUID{}, err
+	}
+	if s.cache != nil && !conflictId.Valid {
+		request.Revision = result.Revision
+		s.cacheGet(id, request, nil)
+	}
+	return result.Revision, conflictId, nil
+}
+
+// ListConflicts lists updates made without knowing a later change of the resource.
+func (s *ResourceService) ListConflicts(ctx context.Context) ([]model.Conflict, error) {
+	stream, err := s.resourceClient.ListConflicts(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]model.Conflict, 0)
+	for {
+		conflict, err := stream.Recv()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		id, err := uuid.FromBytes(conflict.Id.GetValue())
+		if err != nil {
+			return nil, err
+		}
+		resourceId, err := uuid.FromBytes(conflict.ResourceId.GetValue())
+		if err != nil {
+			return nil, err
+		}
+		current, err := fromPb(conflict.Current)
+		if err != nil {
+			return nil, err
+		}
+		conflicting, err := fromPb(conflict.Conflicting)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, model.Conflict{
+			Id:              id,
+			ResourceId:      resourceId,
+			Current:         current,
+			CurrentMeta:     string(conflict.Current.Meta),
+			CurrentRevision: conflict.Current.Revision,
+			Conflicting:     conflicting,
+			ConflictingMeta: string(conflict.Conflicting.Meta),
+			BaseRevision:    conflict.BaseRevision,
+			CreatedAt:       conflict.CreatedAt.AsTime(),
+		})
+	}
+}
+
+// ResolveConflict drops the conflict keeping the resolved version or the current one when resolved is nil,
+// baseRevision is the current revision the resolved version is made from.
+func (s *ResourceService) ResolveConflict(ctx context.Context, conflict *model.Conflict, resolved model.Resource, meta []byte) error {
+	resolution := &pb.ConflictResolution{Id: &pb.UUID{Value: conflict.Id[:]}, BaseRevision: conflict.CurrentRevision}
+	if resolved != nil {
+		request, err := toPb(resolved, meta)
+		if err != nil {
+			return err
+		}
+		resolution.Resource = request
+		if resolution.SharedData, err = s.sharedData(ctx, conflict.ResourceId, request); err != nil {
+			return err
+		}
+	}
+	_, err := s.resourceClient.ResolveConflict(ctx, resolution)
+	return err
+}
+
+// sharedData encrypts the new version of the resource shared by the user with its item key,
+// the server replaces the shared copy together with the resource. It is nil when the resource isn't shared.
+func (s *ResourceService) sharedData(ctx context.Context, id api.ResourceId, request *pb.Resource) ([]byte, error) {
+	current, err := s.resourceClient.Get(ctx, &pb.UUID{Value: id[:]})
+	if err != nil {
+		return nil, err
+	}
+	if current.Shared == nil {
+		return nil, nil
+	}
+	itemKey, err := s.keys.OpenItemKey(current.Shared.WrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	return encryptResource(itemKey, request)
+}
+
+func (s *ResourceService) SaveFile(ctx context.Context, description, path string) (api.ResourceId, error) {
+	return s.uploadFile(ctx, &pb.UploadInit{Meta: []byte(description), ChunkSize: int32(s.chunkSize)}, path)
+}
+
+// SaveAttachment uploads the file attached to the parent resource, it is deleted together with the parent.
+func (s *ResourceService) SaveAttachment(ctx context.Context, parentId api.ResourceId, description, path string) (api.ResourceId, error) {
+	return s.uploadFile(ctx, &pb.UploadInit{
+		Meta:      []byte(description),
+		ChunkSize: int32(s.chunkSize),
+		ParentId:  &pb.UUID{Value: parentId[:]},
+	}, path)
+}
+
+// uploadFile uploads the file through an upload session, so a transient failure resumes from the committed offset.
+func (s *ResourceService) uploadFile(ctx context.Context, init *pb.UploadInit, path string) (api.ResourceId, error) {
+	session, err := s.resourceClient.InitUpload(ctx, init)
 	if err != nil {
 		return uuid.Nil, err
 	}
-	err = fileutil.Send(path, func(bytes []byte) error {
-		return stream.Send(&pb.FileChunk{
-			Meta: nil,
-			Data: bytes,
+
+	err = withRetries(func() error {
+		committed, err := s.resourceClient.GetUploadOffset(ctx, session.Id)
+		if err != nil {
+			return err
+		}
+		offset := committed.Offset
+		return fileutil.SendRange(path, false, offset, 0, int(session.ChunkSize), func(bytes []byte) error {
+			part, err := s.resourceClient.UploadChunk(ctx, &pb.FilePart{
+				SessionId: session.Id,
+				Offset:    offset,
+				Data:      bytes,
+			}, s.fileCallOptions...)
+			if err != nil {
+				return err
+			}
+			offset = part.Offset
+			return nil
 		})
 	})
 	if err != nil {
 		return uuid.Nil, err
 	}
-	id, err := stream.CloseAndRecv()
+
+	var id *pb.UUID
+	err = withRetries(func() error {
+		id, err = s.resourceClient.CompleteUpload(ctx, session.Id)
+		return err
+	})
 	if err != nil {
 		return uuid.Nil, err
 	}
 	return uuid.FromBytes(id.Value)
 }
 
+// GetFile downloads the file, on a transient failure the download continues from the already received offset.
 func (s *ResourceService) GetFile(ctx context.Context, id api.ResourceId) (string, error) {
-	stream, err := s.resourceClient.GetFile(ctx, &pb.UUID{Value: id[:]})
-	if err != nil {
+	path := s.fileStorePath + "/" + id.String()
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
 		return "", err
 	}
 
-	path := s.fileStorePath + "/" + id.String()
-	err = fileutil.Get(path, func() ([]byte, error) {
-		chunk, err := stream.Recv()
+	var checksum []byte
+	var received int64
+	err := withRetries(func() error {
+		request := &pb.FileRequest{Id: &pb.UUID{Value: id[:]}, Offset: received, ChunkSize: int32(s.chunkSize)}
+		stream, err := s.resourceClient.GetFile(ctx, request, s.fileCallOptions...)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		return chunk.Data, nil
+		return fileutil.Append(path, func() ([]byte, error) {
+			chunk, err := stream.Recv()
+			if err != nil {
+				return nil, err
+			}
+			if len(chunk.Checksum) != 0 {
+				checksum = chunk.Checksum
+			}
+			received += int64(len(chunk.Data))
+			return chunk.Data, nil
+		})
 	})
-	return path, err
+	if err != nil {
+		return "", err
+	}
+
+	if len(checksum) != 0 {
+		actual, err := fileutil.Checksum(path, false)
+		if err != nil {
+			return "", err
+		}
+		if !bytes.Equal(checksum, actual) {
+			_ = os.Remove(path)
+			return "", errors.New("downloaded file checksum mismatch")
+		}
+	}
+	return path, nil
+}
+
+func (s *ResourceService) Usage(ctx context.Context) (*model.Usage, error) {
+	usage, err := s.resourceClient.Usage(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return &model.Usage{
+		Items:       usage.Items,
+		Bytes:       usage.Bytes,
+		MaxItems:    usage.MaxItems,
+		MaxBytes:    usage.MaxBytes,
+		MaxFileSize: usage.MaxFileSize,
+	}, nil
 }
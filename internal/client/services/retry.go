@@ -0,0 +1,34 @@
+package services
+
+import (
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	. "secstorage/internal/logger"
+	"time"
+)
+
+const maxRetries = 5
+const retryBackoff = time.Second
+
+func isTransient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Aborted:
+		return true
+	}
+	return false
+}
+
+func withRetries(op func() error) error {
+	var err error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			Log.Warn("transient error, retrying", zap.Int("attempt", attempt), zap.Error(err))
+			time.Sleep(retryBackoff * time.Duration(attempt))
+		}
+		if err = op(); err == nil || !isTransient(err) {
+			return err
+		}
+	}
+	return err
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
+	pb "secstorage/internal/api/proto"
+	"secstorage/internal/client/keys"
+	"secstorage/internal/client/model"
+	"strings"
+	"time"
+)
+
+// SendLinkPrefix starts links of sends, the key is kept in the fragment and never sent to the server.
+const SendLinkPrefix = "secstorage://send/"
+
+var ErrInvalidLink = errors.New("invalid send link")
+
+type SendService struct {
+	client pb.SendsClient
+}
+
+func NewSendService(client pb.SendsClient) *SendService {
+	return &SendService{client: client}
+}
+
+// Send encrypts the resource with a random key and returns the link to open it maxViews times before ttl passes.
+func (s *SendService) Send(ctx context.Context, resource model.Resource, meta []byte, maxViews int, ttl time.Duration) (string, error) {
+	content, err := toPb(resource, meta)
+	if err != nil {
+		return "", err
+	}
+	data, err := proto.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	key, err := keys.NewItemKey()
+	if err != nil {
+		return "", err
+	}
+	encrypted, err := keys.Encrypt(key, data)
+	if err != nil {
+		return "", err
+	}
+	id, err := s.client.Create(ctx, &pb.SendRequest{
+		Data:      encrypted,
+		MaxViews:  int32(maxViews),
+		ExpiresAt: timestamppb.New(time.Now().Add(ttl)),
+	})
+	if err != nil {
+		return "", err
+	}
+	sendId, err := uuid.FromBytes(id.Value)
+	if err != nil {
+		return "", err
+	}
+	return SendLinkPrefix + sendId.String() + "#" + base64.RawURLEncoding.EncodeToString(key[:]), nil
+}
+
+// Open opens the send by the link, it works without login. Every call uses one of the views.
+func (s *SendService) Open(ctx context.Context, link string) (model.Resource, []byte, int, error) {
+	id, key, err := parseSendLink(link)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	send, err := s.client.Open(ctx, &pb.UUID{Value: id[:]})
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	data, err := keys.Decrypt(key, send.Data)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	var content pb.Resource
+	if err := proto.Unmarshal(data, &content); err != nil {
+		return nil, nil, 0, err
+	}
+	resource, err := fromPb(&content)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return resource, content.Meta, int(send.ViewsLeft), nil
+}
+
+func (s *SendService) List(ctx context.Context) ([]model.Send, error) {
+	stream, err := s.client.List(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]model.Send, 0)
+	for {
+		info, err := stream.Recv()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		id, err := uuid.FromBytes(info.Id.Value)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, model.Send{
+			Id:        id,
+			MaxViews:  int(info.MaxViews),
+			Views:     int(info.Views),
+			ExpiresAt: info.ExpiresAt.AsTime(),
+		})
+	}
+}
+
+func (s *SendService) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := s.client.Delete(ctx, &pb.UUID{Value: id[:]})
+	return err
+}
+
+func parseSendLink(link string) (uuid.UUID, *[keys.KeySize]byte, error) {
+	rest := strings.TrimPrefix(strings.TrimSpace(link), SendLinkPrefix)
+	idPart, keyPart, ok := strings.Cut(rest, "#")
+	if !ok {
+		return uuid.Nil, nil, ErrInvalidLink
+	}
+	id, err := uuid.Parse(idPart)
+	if err != nil {
+		return uuid.Nil, nil, ErrInvalidLink
+	}
+	rawKey, err := base64.RawURLEncoding.DecodeString(keyPart)
+	if err != nil {
+		return uuid.Nil, nil, ErrInvalidLink
+	}
+	key, err := keys.ToKey(rawKey)
+	if err != nil {
+		return uuid.Nil, nil, ErrInvalidLink
+	}
+	return id, key, nil
+}
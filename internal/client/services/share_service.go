@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"secstorage/internal/api"
+	pb "secstorage/internal/api/proto"
+	"secstorage/internal/client/keys"
+	"secstorage/internal/client/model"
+)
+
+var ErrNotShared = errors.New("resource is not shared")
+
+type ShareService struct {
+	shareClient    pb.SharesClient
+	resourceClient pb.ResourcesClient
+	keys           *KeyService
+}
+
+func NewShareService(shareClient pb.SharesClient, resourceClient pb.ResourcesClient, keyService *KeyService) *ShareService {
+	return &ShareService{shareClient: shareClient, resourceClient: resourceClient, keys: keyService}
+}
+
+// Share encrypts the resource with its item key and wraps the key for the recipient.
+// The item key is created when the resource is shared for the first time.
+func (s *ShareService) Share(ctx context.Context, id api.ResourceId, recipient string, permission api.Permission) error {
+	resource, err := s.resourceClient.Get(ctx, &pb.UUID{Value: id[:]})
+	if err != nil {
+		return err
+	}
+
+	var itemKey *[keys.KeySize]byte
+	content := &pb.Resource{Type: resource.Type, Data: resource.Data, Meta: resource.Meta, Payload: resource.Payload, Fields: resource.Fields}
+	if resource.Shared != nil {
+		content, itemKey, err = s.keys.OpenShared(resource.Shared)
+	} else {
+		itemKey, err = keys.NewItemKey()
+	}
+	if err != nil {
+		return err
+	}
+
+	recipientKey, err := s.shareClient.GetPublicKey(ctx, &pb.UserQuery{Login: recipient})
+	if err != nil {
+		return err
+	}
+	publicKey, err := keys.ToKey(recipientKey.PublicKey)
+	if err != nil {
+		return err
+	}
+	wrappedKey, err := keys.Wrap(itemKey, publicKey)
+	if err != nil {
+		return err
+	}
+	ownerKey, err := s.keys.WrapForSelf(itemKey)
+	if err != nil {
+		return err
+	}
+	data, err := encryptResource(itemKey, content)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.shareClient.Share(ctx, &pb.ShareRequest{
+		ResourceId: &pb.UUID{Value: id[:]},
+		Recipient:  recipient,
+		Permission: pb.PERMISSION(permission),
+		WrappedKey: wrappedKey,
+		OwnerKey:   ownerKey,
+		Data:       data,
+	})
+	return err
+}
+
+func (s *ShareService) Unshare(ctx context.Context, id api.ResourceId, recipient string) error {
+	_, err := s.shareClient.Unshare(ctx, &pb.UnshareRequest{ResourceId: &pb.UUID{Value: id[:]}, Recipient: recipient})
+	return err
+}
+
+// UpdateShared replaces the shared copy of the resource, the owner and recipients with read-write permission may do it.
+func (s *ShareService) UpdateShared(ctx context.Context, id api.ResourceId, resource model.Resource, meta []byte) error {
+	current, err := s.resourceClient.Get(ctx, &pb.UUID{Value: id[:]})
+	if err != nil {
+		return err
+	}
+	if current.Shared == nil {
+		return ErrNotShared
+	}
+	itemKey, err := s.keys.OpenItemKey(current.Shared.WrappedKey)
+	if err != nil {
+		return err
+	}
+	content, err := toPb(resource, meta)
+	if err != nil {
+		return err
+	}
+	data, err := encryptResource(itemKey, content)
+	if err != nil {
+		return err
+	}
+	_, err = s.shareClient.UpdateShared(ctx, &pb.SharedUpdate{ResourceId: &pb.UUID{Value: id[:]}, Data: data})
+	return err
+}
+
+func (s *ShareService) Grants(ctx context.Context, id api.ResourceId) ([]model.Grant, error) {
+	stream, err := s.shareClient.ListGrants(ctx, &pb.UUID{Value: id[:]})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]model.Grant, 0)
+	for {
+		grant, err := stream.Recv()
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, model.Grant{Recipient: grant.Recipient, Permission: api.Permission(grant.Permission)})
+	}
+}
+
+func encryptResource(itemKey *[keys.KeySize]byte, resource *pb.Resource) ([]byte, error) {
+	data, err := proto.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	return keys.Encrypt(itemKey, data)
+}
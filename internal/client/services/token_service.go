@@ -1,13 +1,43 @@
 package services
 
+import "sync"
+
 type TokenService struct {
-	token string
+	mutex  sync.RWMutex
+	token  string
+	locked bool
 }
 
 func (s *TokenService) Set(token string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	s.token = token
 }
 
+// Get returns the token, a locked session has no token so requests to the server are rejected.
 func (s *TokenService) Get() string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if s.locked {
+		return ""
+	}
 	return s.token
 }
+
+func (s *TokenService) Lock() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.locked = true
+}
+
+func (s *TokenService) Unlock() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.locked = false
+}
+
+func (s *TokenService) Unlocked() bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return !s.locked && len(s.token) != 0
+}
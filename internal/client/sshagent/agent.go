@@ -0,0 +1,130 @@
+package sshagent
+
+import (
+	"errors"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"net"
+	"os"
+	. "secstorage/internal/logger"
+	"sync"
+)
+
+var ErrLocked = errors.New("session is locked")
+var ErrReadOnly = errors.New("keys are managed by the storage")
+
+type Session interface {
+	Unlocked() bool
+}
+
+// Agent serves keys kept in memory and refuses to list or use them while the session is locked.
+// Keys are added only by the client, ssh-add can't change them.
+type Agent struct {
+	keyring agent.ExtendedAgent
+	session Session
+}
+
+func New(session Session) *Agent {
+	return &Agent{keyring: agent.NewKeyring().(agent.ExtendedAgent), session: session}
+}
+
+// AddKey adds the raw private key as returned by ssh.ParseRawPrivateKey.
+func (a *Agent) AddKey(key any, comment string) error {
+	return a.keyring.Add(agent.AddedKey{PrivateKey: key, Comment: comment})
+}
+
+func (a *Agent) List() ([]*agent.Key, error) {
+	if !a.session.Unlocked() {
+		return nil, nil
+	}
+	return a.keyring.List()
+}
+
+func (a *Agent) Sign(key ssh.PublicKey, data []byte) (*ssh.Signature, error) {
+	return a.SignWithFlags(key, data, 0)
+}
+
+func (a *Agent) SignWithFlags(key ssh.PublicKey, data []byte, flags agent.SignatureFlags) (*ssh.Signature, error) {
+	if !a.session.Unlocked() {
+		return nil, ErrLocked
+	}
+	return a.keyring.SignWithFlags(key, data, flags)
+}
+
+func (a *Agent) Signers() ([]ssh.Signer, error) {
+	if !a.session.Unlocked() {
+		return nil, ErrLocked
+	}
+	return a.keyring.Signers()
+}
+
+func (a *Agent) Add(agent.AddedKey) error {
+	return ErrReadOnly
+}
+
+func (a *Agent) Remove(ssh.PublicKey) error {
+	return ErrReadOnly
+}
+
+func (a *Agent) RemoveAll() error {
+	return ErrReadOnly
+}
+
+func (a *Agent) Lock([]byte) error {
+	return ErrReadOnly
+}
+
+func (a *Agent) Unlock([]byte) error {
+	return ErrReadOnly
+}
+
+func (a *Agent) Extension(string, []byte) ([]byte, error) {
+	return nil, agent.ErrExtensionUnsupported
+}
+
+type Server struct {
+	listener net.Listener
+	wg       sync.WaitGroup
+}
+
+// Listen serves the agent on the unix socket at path, the socket is accessible only by the current user.
+func Listen(path string, a *Agent) (*Server, error) {
+	_ = os.Remove(path)
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+	server := &Server{listener: listener}
+	server.wg.Add(1)
+	go server.serve(a)
+	return server, nil
+}
+
+func (s *Server) serve(a *Agent) {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+		if err != nil {
+			Log.Error("ssh agent accept failed", zap.Error(err))
+			return
+		}
+		go func() {
+			defer conn.Close()
+			_ = agent.ServeAgent(a, conn)
+		}()
+	}
+}
+
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
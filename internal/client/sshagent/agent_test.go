@@ -0,0 +1,61 @@
+package sshagent
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"net"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+type testSession struct {
+	unlocked atomic.Bool
+}
+
+func (s *testSession) Unlocked() bool {
+	return s.unlocked.Load()
+}
+
+func TestAgent_SignsOnlyWhileUnlocked(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	assert.NoError(t, err)
+
+	session := &testSession{}
+	session.unlocked.Store(true)
+	a := New(session)
+	assert.NoError(t, a.AddKey(privateKey, "test"))
+
+	path := filepath.Join(t.TempDir(), "agent.sock")
+	server, err := Listen(path, a)
+	assert.NoError(t, err)
+	defer server.Close()
+
+	conn, err := net.Dial("unix", path)
+	assert.NoError(t, err)
+	defer conn.Close()
+	client := agent.NewClient(conn)
+
+	keys, err := client.List()
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+	assert.Equal(t, "test", keys[0].Comment)
+
+	signature, err := client.Sign(signer.PublicKey(), []byte("data"))
+	assert.NoError(t, err)
+	assert.NoError(t, signer.PublicKey().Verify([]byte("data"), signature))
+
+	assert.Error(t, client.RemoveAll())
+
+	session.unlocked.Store(false)
+	keys, err = client.List()
+	assert.NoError(t, err)
+	assert.Len(t, keys, 0)
+	_, err = client.Sign(signer.PublicKey(), []byte("data"))
+	assert.Error(t, err)
+}
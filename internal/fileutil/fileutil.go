@@ -2,31 +2,89 @@ package fileutil
 
 import (
 	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
 	"io"
 	"os"
+	"sync"
 )
 
+const (
+	MinChunkSize     = 4 * 1024
+	DefaultChunkSize = 64 * 1024
+	MaxChunkSize     = 1024 * 1024
+)
+
+var chunkPool = sync.Pool{}
+
+func getChunkBuffer(size int) *[]byte {
+	if buffer, ok := chunkPool.Get().(*[]byte); ok && cap(*buffer) >= size {
+		*buffer = (*buffer)[:size]
+		return buffer
+	}
+	buffer := make([]byte, size)
+	return &buffer
+}
+
+// ClampChunkSize fits the requested chunk size into [MinChunkSize, max], zero means DefaultChunkSize.
+func ClampChunkSize(requested int, max int) int {
+	if requested <= 0 {
+		requested = DefaultChunkSize
+	}
+	if requested > max {
+		requested = max
+	}
+	if requested < MinChunkSize {
+		requested = MinChunkSize
+	}
+	return requested
+}
+
 func Send(path string, chunkSender func([]byte) error) error {
+	return SendRange(path, false, 0, 0, DefaultChunkSize, chunkSender)
+}
+
+// SendRange sends length bytes of the file content starting at offset in chunks of chunkSize bytes.
+// Zero length means up to the end. Offset and length always address the uncompressed content.
+// The chunk buffer is reused, so chunkSender must not keep it after return.
+func SendRange(path string, compressed bool, offset int64, length int64, chunkSize int, chunkSender func([]byte) error) error {
 	file, err := os.Open(path)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
-	reader := bufio.NewReader(file)
-
-	buffer := make([]byte, 4096)
-	n := 0
 
-	for {
-		n, err = reader.Read(buffer)
-		if err == io.EOF || n == 0 {
-			return nil
-		}
+	var reader io.Reader = file
+	if compressed {
+		gzipReader, err := gzip.NewReader(bufio.NewReader(file))
 		if err != nil {
 			return err
 		}
+		defer gzipReader.Close()
+		if _, err := io.CopyN(io.Discard, gzipReader, offset); err != nil && err != io.EOF {
+			return err
+		}
+		reader = gzipReader
+	} else if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	if length > 0 {
+		reader = io.LimitReader(reader, length)
+	}
 
-		err = chunkSender(buffer[:n])
+	buffer := getChunkBuffer(chunkSize)
+	defer chunkPool.Put(buffer)
+
+	for {
+		n, err := io.ReadFull(reader, *buffer)
+		if n > 0 {
+			if err := chunkSender((*buffer)[:n]); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
 		if err != nil {
 			return err
 		}
@@ -34,7 +92,15 @@ func Send(path string, chunkSender func([]byte) error) error {
 }
 
 func Get(saveToPath string, chunkReceiver func() ([]byte, error)) error {
-	file, err := os.Create(saveToPath)
+	return receive(saveToPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, chunkReceiver)
+}
+
+func Append(saveToPath string, chunkReceiver func() ([]byte, error)) error {
+	return receive(saveToPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, chunkReceiver)
+}
+
+func receive(saveToPath string, flag int, chunkReceiver func() ([]byte, error)) error {
+	file, err := os.OpenFile(saveToPath, flag, 0666)
 	if err != nil {
 		return err
 	}
@@ -56,5 +122,64 @@ func Get(saveToPath string, chunkReceiver func() ([]byte, error)) error {
 			return err
 		}
 	}
+}
+
+func WriteAt(path string, offset int64, data []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0666)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteAt(data, offset); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
 
+// Compress writes gzip compressed content of src to dst.
+func Compress(src string, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	gzipWriter := gzip.NewWriter(out)
+	if _, err := io.Copy(gzipWriter, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func Checksum(path string, compressed bool) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var reader io.Reader = file
+	if compressed {
+		gzipReader, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, reader); err != nil {
+		return nil, err
+	}
+	return hash.Sum(nil), nil
 }
@@ -0,0 +1,91 @@
+package fileutil
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// benchContent looks like the typical stored file: certificates and config bundles.
+var benchContent = bytes.Repeat([]byte("-----BEGIN CERTIFICATE-----\nMIIFazCCA1OgAwIBAgIUb3Jn\nhost=db.internal port=5432\n"), 64*1024)
+
+func prepareBenchFile(b *testing.B, compressed bool) string {
+	dir := b.TempDir()
+	raw := filepath.Join(dir, "raw")
+	if err := os.WriteFile(raw, benchContent, 0644); err != nil {
+		b.Fatal(err)
+	}
+	if !compressed {
+		return raw
+	}
+	path := filepath.Join(dir, "compressed")
+	if err := Compress(raw, path); err != nil {
+		b.Fatal(err)
+	}
+	return path
+}
+
+func reportDiskRatio(b *testing.B, path string) {
+	info, err := os.Stat(path)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportMetric(float64(info.Size())/float64(len(benchContent)), "disk_ratio")
+}
+
+func benchmarkSend(b *testing.B, compressed bool) {
+	path := prepareBenchFile(b, compressed)
+	b.SetBytes(int64(len(benchContent)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := SendRange(path, compressed, 0, 0, DefaultChunkSize, func([]byte) error { return nil }); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+	reportDiskRatio(b, path)
+}
+
+func BenchmarkSend_Raw(b *testing.B) {
+	benchmarkSend(b, false)
+}
+
+func BenchmarkSend_Compressed(b *testing.B) {
+	benchmarkSend(b, true)
+}
+
+func BenchmarkCompress(b *testing.B) {
+	raw := prepareBenchFile(b, false)
+	dst := filepath.Join(b.TempDir(), "compressed")
+	b.SetBytes(int64(len(benchContent)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := Compress(raw, dst); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+	reportDiskRatio(b, dst)
+}
+
+func TestSendRange_RawAndCompressed(t *testing.T) {
+	dir := t.TempDir()
+	raw := filepath.Join(dir, "raw")
+	compressed := filepath.Join(dir, "compressed")
+	assert.NoError(t, os.WriteFile(raw, []byte("0123456789abcdef"), 0644))
+	assert.NoError(t, Compress(raw, compressed))
+
+	paths := map[bool]string{false: raw, true: compressed}
+	for isCompressed, path := range paths {
+		received := make([]byte, 0)
+		err := SendRange(path, isCompressed, 4, 6, MinChunkSize, func(chunk []byte) error {
+			received = append(received, chunk...)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "456789", string(received))
+	}
+}
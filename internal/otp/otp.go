@@ -0,0 +1,157 @@
+package otp
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultDigits    = 6
+	DefaultPeriod    = 30
+	DefaultAlgorithm = "SHA1"
+)
+
+var ErrInvalidKey = errors.New("invalid otp key")
+
+// Key is a TOTP key as described by an otpauth://totp/ URI.
+type Key struct {
+	Secret    string
+	Issuer    string
+	Account   string
+	Digits    int
+	Period    int
+	Algorithm string
+}
+
+func ParseURI(uri string) (*Key, error) {
+	u, err := url.Parse(strings.TrimSpace(uri))
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "otpauth" || u.Host != "totp" {
+		return nil, fmt.Errorf("%w: only otpauth://totp/ uris are supported", ErrInvalidKey)
+	}
+
+	key := &Key{Digits: DefaultDigits, Period: DefaultPeriod, Algorithm: DefaultAlgorithm}
+	label := strings.TrimPrefix(u.Path, "/")
+	if issuer, account, ok := strings.Cut(label, ":"); ok {
+		key.Issuer, key.Account = strings.TrimSpace(issuer), strings.TrimSpace(account)
+	} else {
+		key.Account = label
+	}
+
+	query := u.Query()
+	key.Secret = strings.ToUpper(strings.ReplaceAll(query.Get("secret"), " ", ""))
+	if issuer := query.Get("issuer"); issuer != "" {
+		key.Issuer = issuer
+	}
+	if algorithm := query.Get("algorithm"); algorithm != "" {
+		key.Algorithm = strings.ToUpper(algorithm)
+	}
+	if digits := query.Get("digits"); digits != "" {
+		if key.Digits, err = strconv.Atoi(digits); err != nil {
+			return nil, fmt.Errorf("%w: bad digits", ErrInvalidKey)
+		}
+	}
+	if period := query.Get("period"); period != "" {
+		if key.Period, err = strconv.Atoi(period); err != nil {
+			return nil, fmt.Errorf("%w: bad period", ErrInvalidKey)
+		}
+	}
+	return key, key.Validate()
+}
+
+func (k *Key) URI() string {
+	label := k.Account
+	if k.Issuer != "" {
+		label = k.Issuer + ":" + k.Account
+	}
+	query := url.Values{}
+	query.Set("secret", k.Secret)
+	if k.Issuer != "" {
+		query.Set("issuer", k.Issuer)
+	}
+	query.Set("algorithm", k.Algorithm)
+	query.Set("digits", strconv.Itoa(k.Digits))
+	query.Set("period", strconv.Itoa(k.Period))
+	u := url.URL{Scheme: "otpauth", Host: "totp", Path: "/" + label, RawQuery: query.Encode()}
+	return u.String()
+}
+
+func (k *Key) Validate() error {
+	if _, err := k.secretBytes(); err != nil {
+		return fmt.Errorf("%w: secret must be base32 encoded", ErrInvalidKey)
+	}
+	if k.Digits < 6 || k.Digits > 8 {
+		return fmt.Errorf("%w: digits must be 6-8", ErrInvalidKey)
+	}
+	if k.Period <= 0 {
+		return fmt.Errorf("%w: period must be positive", ErrInvalidKey)
+	}
+	if _, err := k.hash(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Code generates the TOTP code valid at t.
+func (k *Key) Code(t time.Time) (string, error) {
+	secret, err := k.secretBytes()
+	if err != nil {
+		return "", err
+	}
+	newHash, err := k.hash()
+	if err != nil {
+		return "", err
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(t.Unix()/int64(k.Period)))
+	mac := hmac.New(newHash, secret)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	modulo := uint32(1)
+	for i := 0; i < k.Digits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", k.Digits, value%modulo), nil
+}
+
+// Remaining returns how long the code generated at t stays valid.
+func (k *Key) Remaining(t time.Time) time.Duration {
+	period := int64(k.Period)
+	return time.Duration(period-t.Unix()%period) * time.Second
+}
+
+func (k *Key) secretBytes() ([]byte, error) {
+	if len(k.Secret) == 0 {
+		return nil, ErrInvalidKey
+	}
+	return base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(k.Secret, "="))
+}
+
+func (k *Key) hash() (func() hash.Hash, error) {
+	switch k.Algorithm {
+	case "SHA1":
+		return sha1.New, nil
+	case "SHA256":
+		return sha256.New, nil
+	case "SHA512":
+		return sha512.New, nil
+	}
+	return nil, fmt.Errorf("%w: unsupported algorithm %v", ErrInvalidKey, k.Algorithm)
+}
@@ -0,0 +1,58 @@
+package otp
+
+import (
+	"encoding/base32"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// test vectors from RFC 6238, appendix B
+func TestKey_Code_RFC6238(t *testing.T) {
+	secrets := map[string]string{
+		"SHA1":   "12345678901234567890",
+		"SHA256": "12345678901234567890123456789012",
+		"SHA512": "1234567890123456789012345678901234567890123456789012345678901234",
+	}
+	vectors := []struct {
+		unix      int64
+		algorithm string
+		code      string
+	}{
+		{59, "SHA1", "94287082"},
+		{59, "SHA256", "46119246"},
+		{59, "SHA512", "90693936"},
+		{1111111109, "SHA1", "07081804"},
+		{1234567890, "SHA256", "91819424"},
+		{20000000000, "SHA512", "47863826"},
+	}
+	for _, v := range vectors {
+		key := &Key{
+			Secret:    base32.StdEncoding.EncodeToString([]byte(secrets[v.algorithm])),
+			Digits:    8,
+			Period:    30,
+			Algorithm: v.algorithm,
+		}
+		code, err := key.Code(time.Unix(v.unix, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, v.code, code)
+	}
+}
+
+func TestParseURI(t *testing.T) {
+	key, err := ParseURI("otpauth://totp/ACME%20Co:john@example.com?secret=HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ&issuer=ACME%20Co&algorithm=SHA1&digits=6&period=30")
+	assert.NoError(t, err)
+	assert.Equal(t, "ACME Co", key.Issuer)
+	assert.Equal(t, "john@example.com", key.Account)
+	assert.Equal(t, "HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ", key.Secret)
+	assert.Equal(t, 6, key.Digits)
+
+	parsed, err := ParseURI(key.URI())
+	assert.NoError(t, err)
+	assert.Equal(t, key, parsed)
+
+	_, err = ParseURI("otpauth://hotp/label?secret=HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+	_, err = ParseURI("otpauth://totp/label?secret=not-base32!")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"google.golang.org/grpc/metadata"
+	"io"
+	pb "secstorage/internal/api/proto"
+	"secstorage/internal/fileutil"
+	"secstorage/internal/server/services"
+	"sync"
+	"testing"
+)
+
+const benchFileSize = 16 * 1024 * 1024
+
+var benchChunkSizes = []int{fileutil.MinChunkSize, fileutil.DefaultChunkSize, fileutil.MaxChunkSize}
+
+var benchOnce sync.Once
+var benchAuthClient pb.AuthClient
+var benchResourceClient pb.ResourcesClient
+
+// benchContext registers the user on a server without quota, the files are larger than the test quota allows.
+func benchContext(b *testing.B) context.Context {
+	benchOnce.Do(func() {
+		con := startServer(db, services.Quota{})
+		benchAuthClient = pb.NewAuthClient(con)
+		benchResourceClient = pb.NewResourcesClient(con)
+	})
+	prepare()
+	token, err := benchAuthClient.Register(context.Background(), &pb.AuthData{Login: "bench", Password: "bench"})
+	if err != nil {
+		b.Fatal(err)
+	}
+	return metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+}
+
+func benchContent(b *testing.B) []byte {
+	content := make([]byte, benchFileSize)
+	if _, err := rand.Read(content); err != nil {
+		b.Fatal(err)
+	}
+	return content
+}
+
+func BenchmarkSaveFile(b *testing.B) {
+	ctx := benchContext(b)
+	content := benchContent(b)
+
+	for _, chunkSize := range benchChunkSizes {
+		b.Run(fmt.Sprintf("chunk_%d", chunkSize), func(b *testing.B) {
+			b.SetBytes(benchFileSize)
+			for i := 0; i < b.N; i++ {
+				stream, err := benchResourceClient.SaveFile(ctx)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if err := stream.Send(&pb.FileChunk{Meta: []byte("bench")}); err != nil {
+					b.Fatal(err)
+				}
+				for offset := 0; offset < len(content); offset += chunkSize {
+					if err := stream.Send(&pb.FileChunk{Data: content[offset : offset+chunkSize]}); err != nil {
+						b.Fatal(err)
+					}
+				}
+				id, err := stream.CloseAndRecv()
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.StopTimer()
+				if _, err := benchResourceClient.Delete(ctx, id); err != nil {
+					b.Fatal(err)
+				}
+				b.StartTimer()
+			}
+		})
+	}
+}
+
+func BenchmarkGetFile(b *testing.B) {
+	ctx := benchContext(b)
+	content := benchContent(b)
+
+	session, err := benchResourceClient.InitUpload(ctx, &pb.UploadInit{Meta: []byte("bench"), ChunkSize: fileutil.MaxChunkSize})
+	if err != nil {
+		b.Fatal(err)
+	}
+	for offset := 0; offset < len(content); offset += int(session.ChunkSize) {
+		part := &pb.FilePart{SessionId: session.Id, Offset: int64(offset), Data: content[offset : offset+int(session.ChunkSize)]}
+		if _, err := benchResourceClient.UploadChunk(ctx, part); err != nil {
+			b.Fatal(err)
+		}
+	}
+	id, err := benchResourceClient.CompleteUpload(ctx, session.Id)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, chunkSize := range benchChunkSizes {
+		b.Run(fmt.Sprintf("chunk_%d", chunkSize), func(b *testing.B) {
+			b.SetBytes(benchFileSize)
+			for i := 0; i < b.N; i++ {
+				stream, err := benchResourceClient.GetFile(ctx, &pb.FileRequest{Id: id, ChunkSize: int32(chunkSize)})
+				if err != nil {
+					b.Fatal(err)
+				}
+				for {
+					_, err := stream.Recv()
+					if err == io.EOF {
+						break
+					}
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package events
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"secstorage/internal/api"
+	"sync"
+)
+
+// subscriptionBuffer is how many changes a watcher may fall behind before it is dropped
+const subscriptionBuffer = 64
+
+type Change struct {
+	UserId     api.UserId       `json:"userId"`
+	ResourceId api.ResourceId   `json:"resourceId"`
+	Type       api.ResourceType `json:"type"`
+	Kind       api.ChangeKind   `json:"kind"`
+}
+
+// Broker delivers changes to watchers of the same process.
+type Broker struct {
+	mutex       sync.Mutex
+	subscribers map[api.UserId]map[chan Change]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{subscribers: make(map[api.UserId]map[chan Change]struct{})}
+}
+
+// Subscribe returns changes of resources of the user and the function to unsubscribe.
+// The channel is closed when the subscriber doesn't keep up with changes.
+func (b *Broker) Subscribe(userId api.UserId) (<-chan Change, func()) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	ch := make(chan Change, subscriptionBuffer)
+	if b.subscribers[userId] == nil {
+		b.subscribers[userId] = make(map[chan Change]struct{})
+	}
+	b.subscribers[userId][ch] = struct{}{}
+
+	return ch, func() {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+		b.remove(userId, ch)
+	}
+}
+
+func (b *Broker) Publish(_ context.Context, change Change) error {
+	b.Dispatch(change)
+	return nil
+}
+
+// Dispatch delivers the change to subscribers of the user, a reset without user goes to all subscribers.
+func (b *Broker) Dispatch(change Change) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if change.Kind == api.ChangeReset && change.UserId == uuid.Nil {
+		for userId, subscribers := range b.subscribers {
+			for ch := range subscribers {
+				b.send(userId, ch, change)
+			}
+		}
+		return
+	}
+	for ch := range b.subscribers[change.UserId] {
+		b.send(change.UserId, ch, change)
+	}
+}
+
+func (b *Broker) send(userId api.UserId, ch chan Change, change Change) {
+	select {
+	case ch <- change:
+	default:
+		b.remove(userId, ch)
+	}
+}
+
+func (b *Broker) remove(userId api.UserId, ch chan Change) {
+	if _, ok := b.subscribers[userId][ch]; !ok {
+		return
+	}
+	delete(b.subscribers[userId], ch)
+	if len(b.subscribers[userId]) == 0 {
+		delete(b.subscribers, userId)
+	}
+	close(ch)
+}
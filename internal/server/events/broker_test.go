@@ -0,0 +1,46 @@
+package events
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"secstorage/internal/api"
+	"testing"
+)
+
+func TestBroker_Dispatch(t *testing.T) {
+	broker := NewBroker()
+	user, other := uuid.New(), uuid.New()
+	changes, unsubscribe := broker.Subscribe(user)
+	otherChanges, unsubscribeOther := broker.Subscribe(other)
+	defer unsubscribeOther()
+
+	change := Change{UserId: user, ResourceId: uuid.New(), Type: api.LoginPassword, Kind: api.ChangeCreated}
+	assert.NoError(t, broker.Publish(context.Background(), change))
+	assert.Equal(t, change, <-changes)
+	assert.Len(t, otherChanges, 0)
+
+	broker.Dispatch(Change{Kind: api.ChangeReset})
+	assert.Equal(t, api.ChangeReset, (<-changes).Kind)
+	assert.Equal(t, api.ChangeReset, (<-otherChanges).Kind)
+
+	unsubscribe()
+	_, ok := <-changes
+	assert.False(t, ok)
+	unsubscribe()
+}
+
+func TestBroker_DropsSlowSubscriber(t *testing.T) {
+	broker := NewBroker()
+	user := uuid.New()
+	changes, unsubscribe := broker.Subscribe(user)
+	for i := 0; i <= subscriptionBuffer; i++ {
+		broker.Dispatch(Change{UserId: user, ResourceId: uuid.New(), Kind: api.ChangeCreated})
+	}
+	received := 0
+	for range changes {
+		received++
+	}
+	assert.Equal(t, subscriptionBuffer, received)
+	unsubscribe()
+}
@@ -0,0 +1,73 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+	"secstorage/internal/api"
+	. "secstorage/internal/logger"
+	"time"
+)
+
+const notifyChannel = "resource_changes"
+
+// PgBroker delivers changes to watchers of all server instances through Postgres LISTEN/NOTIFY,
+// changes published by the instance itself come back through the listener too.
+type PgBroker struct {
+	*Broker
+	db  *sqlx.DB
+	url string
+}
+
+func NewPgBroker(broker *Broker, db *sqlx.DB, url string) *PgBroker {
+	return &PgBroker{Broker: broker, db: db, url: url}
+}
+
+func (b *PgBroker) Publish(ctx context.Context, change Change) error {
+	payload, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+	_, err = b.db.ExecContext(ctx, "select pg_notify($1, $2)", notifyChannel, string(payload))
+	return err
+}
+
+// Listen dispatches notifications to local subscribers until ctx is done.
+// Notifications sent while the connection was lost are missed, so watchers get a reset after reconnect.
+func (b *PgBroker) Listen(ctx context.Context) error {
+	listener := pq.NewListener(b.url, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			Log.Warn("resource changes listener", zap.Error(err))
+		}
+	})
+	defer listener.Close()
+	if err := listener.Listen(notifyChannel); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-listener.Notify:
+			if notification == nil {
+				b.Dispatch(Change{Kind: api.ChangeReset})
+				continue
+			}
+			var change Change
+			if err := json.Unmarshal([]byte(notification.Extra), &change); err != nil {
+				Log.Error("malformed resource change", zap.Error(err))
+				continue
+			}
+			b.Dispatch(change)
+		case <-time.After(time.Minute):
+			go func() {
+				if err := listener.Ping(); err != nil {
+					Log.Warn("resource changes listener ping failed", zap.Error(err))
+				}
+			}()
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"secstorage/internal/api"
+	pb "secstorage/internal/api/proto"
+	. "secstorage/internal/logger"
+	"secstorage/internal/server/services"
+	"secstorage/internal/server/storage/audit/model"
+	"time"
+)
+
+type Auditor interface {
+	ResolveOwner(context.Context, *model.Event, string) error
+	Record(context.Context, *model.Event) error
+}
+
+var auditedMethods = map[string]api.AuditAction{
+	"/secstorage.Auth/Register":            api.AuditRegister,
+	"/secstorage.Auth/Login":               api.AuditLogin,
+	"/secstorage.Auth/Recover":             api.AuditRecover,
+	"/secstorage.Auth/SetRecovery":         api.AuditSetRecovery,
+	"/secstorage.Resources/Get":            api.AuditGet,
+	"/secstorage.Resources/GetFile":        api.AuditGetFile,
+	"/secstorage.Resources/Save":           api.AuditSave,
+	"/secstorage.Resources/SaveFile":       api.AuditSave,
+	"/secstorage.Resources/CompleteUpload": api.AuditSave,
+	"/secstorage.Resources/Delete":         api.AuditDelete,
+	"/secstorage.Resources/Update":         api.AuditSave,
+	"/secstorage.Shares/Share":             api.AuditShare,
+	"/secstorage.Shares/Unshare":           api.AuditUnshare,
+	"/secstorage.Shares/UpdateShared":      api.AuditUpdateShared,
+	"/secstorage.Emergency/Request":        api.AuditEmergencyRequest,
+	"/secstorage.Emergency/Approve":        api.AuditEmergencyApprove,
+	"/secstorage.Emergency/Reject":         api.AuditEmergencyReject,
+	"/secstorage.Admin/SetDisabled":        api.AuditSetDisabled,
+	"/secstorage.Admin/Logout":             api.AuditForceLogout,
+}
+
+// failedActions are recorded when the call fails, other actions are recorded only on success
+var failedActions = map[string]api.AuditAction{
+	"/secstorage.Auth/Login":       api.AuditLoginFailed,
+	"/secstorage.Auth/Recover":     api.AuditRecoverFailed,
+	"/secstorage.Auth/SetRecovery": api.AuditSetRecoveryFailed,
+}
+
+type auditStream struct {
+	grpc.ServerStream
+	request  interface{}
+	response interface{}
+}
+
+func (s *auditStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.request == nil {
+		s.request = m
+	}
+	return err
+}
+
+func (s *auditStream) SendMsg(m interface{}) error {
+	if _, ok := m.(*pb.UUID); ok {
+		s.response = m
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+// AuditInterceptor records calls of security-relevant methods to the audit log, it must run after the token interceptor.
+func AuditInterceptor(auditor Auditor, tokenService *services.TokenService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		action, ok := auditedMethods[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+		event := newAuditEvent(ctx, auditor, info.FullMethod, action, req)
+		resp, err = handler(ctx, req)
+		recordAuditEvent(ctx, auditor, tokenService, info.FullMethod, event, resp, err)
+		return resp, err
+	}
+}
+
+func AuditStreamInterceptor(auditor Auditor, tokenService *services.TokenService) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		action, ok := auditedMethods[info.FullMethod]
+		if !ok {
+			return handler(srv, ss)
+		}
+		stream := &auditStream{ServerStream: ss}
+		err := handler(srv, stream)
+		event := newAuditEvent(ss.Context(), auditor, info.FullMethod, action, stream.request)
+		recordAuditEvent(ss.Context(), auditor, tokenService, info.FullMethod, event, stream.response, err)
+		return err
+	}
+}
+
+func newAuditEvent(ctx context.Context, auditor Auditor, method string, action api.AuditAction, req interface{}) *model.Event {
+	event := &model.Event{Action: action, TargetId: auditTargetId(req), Details: auditDetails(req)}
+	if userId, ok := ctx.Value("userId").(api.UserId); ok {
+		event.UserId = uuid.NullUUID{UUID: userId, Valid: true}
+	}
+	var login string
+	if r, ok := req.(interface{ GetLogin() string }); ok {
+		login = r.GetLogin()
+	}
+	if isPublicMethod(method) {
+		event.Login = login
+	}
+	if err := auditor.ResolveOwner(ctx, event, login); err != nil {
+		Log.Error("error on resolve audit event owner", zap.Error(err))
+	}
+	return event
+}
+
+func recordAuditEvent(
+	ctx context.Context,
+	auditor Auditor,
+	tokenService *services.TokenService,
+	method string,
+	event *model.Event,
+	resp interface{},
+	err error,
+) {
+	if err != nil {
+		failed, ok := failedActions[method]
+		if !ok {
+			return
+		}
+		event.Action = failed
+		record(ctx, auditor, event)
+		return
+	}
+	if !event.TargetId.Valid {
+		event.TargetId = auditTargetId(resp)
+	}
+
+	token := issuedToken(resp)
+	if token == nil {
+		record(ctx, auditor, event)
+		return
+	}
+	if userId, err := tokenService.Extract(token.Token); err == nil {
+		event.UserId = uuid.NullUUID{UUID: userId, Valid: true}
+	}
+	record(ctx, auditor, event)
+	record(ctx, auditor, &model.Event{
+		UserId:  event.UserId,
+		Login:   event.Login,
+		Action:  api.AuditTokenIssued,
+		Details: "expires at " + token.ExpireAt.AsTime().Format(time.RFC3339),
+	})
+}
+
+func record(ctx context.Context, auditor Auditor, event *model.Event) {
+	if err := auditor.Record(ctx, event); err != nil {
+		Log.Error("error on record audit event", zap.Int("action", int(event.Action)), zap.Error(err))
+	}
+}
+
+func auditTargetId(msg interface{}) uuid.NullUUID {
+	var id *pb.UUID
+	switch m := msg.(type) {
+	case *pb.UUID:
+		id = m
+	case interface{ GetId() *pb.UUID }:
+		id = m.GetId()
+	case interface{ GetResourceId() *pb.UUID }:
+		id = m.GetResourceId()
+	}
+	result, err := uuid.FromBytes(id.GetValue())
+	if err != nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: result, Valid: true}
+}
+
+func auditDetails(req interface{}) string {
+	switch r := req.(type) {
+	case *pb.ShareRequest:
+		return fmt.Sprintf("recipient: %v, permission: %v", r.Recipient, r.Permission)
+	case *pb.UnshareRequest:
+		return "recipient: " + r.Recipient
+	case *pb.AccountState:
+		return fmt.Sprintf("login: %v, disabled: %v", r.Login, r.Disabled)
+	case *pb.UserQuery:
+		return "login: " + r.Login
+	case *pb.FileRequest:
+		if r.Offset != 0 {
+			return fmt.Sprintf("offset: %v", r.Offset)
+		}
+	}
+	return ""
+}
+
+func issuedToken(resp interface{}) *pb.TokenData {
+	switch r := resp.(type) {
+	case *pb.TokenData:
+		return r
+	case *pb.RecoverResponse:
+		return r.Token
+	}
+	return nil
+}
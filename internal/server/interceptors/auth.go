@@ -2,8 +2,13 @@ package interceptors
 
 import (
 	"context"
+	"errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"secstorage/internal/server/reservederrors"
 	"secstorage/internal/server/services"
+	"strings"
 )
 
 type ServerStreamWithCtx struct {
@@ -15,18 +20,43 @@ func (w *ServerStreamWithCtx) Context() context.Context {
 	return w.ctx
 }
 
-func isAuthMethod(method string) bool {
-	return method == "/secstorage.Auth/Register" || method == "/secstorage.Auth/Login"
+// publicMethods are called without a token
+var publicMethods = map[string]bool{
+	"/secstorage.Auth/Register": true,
+	"/secstorage.Auth/Login":    true,
+	"/secstorage.Auth/Recover":  true,
+	"/secstorage.Sends/Open":    true,
+}
+
+func isPublicMethod(method string) bool {
+	return publicMethods[method]
+}
+
+func isAdminMethod(method string) bool {
+	return strings.HasPrefix(method, "/secstorage.Admin/")
+}
+
+func authorize(ctx context.Context, tokenService *services.TokenService, method string) (context.Context, error) {
+	userId, err := tokenService.Authorize(ctx, isAdminMethod(method))
+	if errors.Is(err, reservederrors.ErrPermissionDenied) || errors.Is(err, reservederrors.ErrUserDisabled) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if errors.Is(err, reservederrors.ErrTokenInvalid) {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return context.WithValue(ctx, "userId", userId), nil
 }
 
 func TokenInterceptor(tokenService *services.TokenService) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-		if !isAuthMethod(info.FullMethod) {
-			userId, err := tokenService.GetUserIdGRPC(ctx)
+		if !isPublicMethod(info.FullMethod) {
+			ctxWithUserId, err := authorize(ctx, tokenService, info.FullMethod)
 			if err != nil {
 				return nil, err
 			}
-			ctxWithUserId := context.WithValue(ctx, "userId", userId)
 			return handler(ctxWithUserId, req)
 		}
 		return handler(ctx, req)
@@ -35,12 +65,11 @@ func TokenInterceptor(tokenService *services.TokenService) grpc.UnaryServerInter
 
 func TokenStreamInterceptor(tokenService *services.TokenService) grpc.StreamServerInterceptor {
 	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		if !isAuthMethod(info.FullMethod) {
-			userId, err := tokenService.GetUserIdGRPC(ss.Context())
+		if !isPublicMethod(info.FullMethod) {
+			ctx, err := authorize(ss.Context(), tokenService, info.FullMethod)
 			if err != nil {
 				return err
 			}
-			ctx := context.WithValue(ss.Context(), "userId", userId)
 			ssCtx := &ServerStreamWithCtx{ServerStream: ss, ctx: ctx}
 
 			return handler(srv, ssCtx)
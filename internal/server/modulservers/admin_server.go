@@ -0,0 +1,121 @@
+package modulservers
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"secstorage/internal/api"
+	pb "secstorage/internal/api/proto"
+	"secstorage/internal/server/services"
+	auditModel "secstorage/internal/server/storage/audit/model"
+	"secstorage/internal/server/storage/auth/model"
+)
+
+type AdminService interface {
+	ListUsers(context.Context) ([]model.UserInfo, error)
+	GetUser(context.Context, string) (*model.UserInfo, error)
+	SetDisabled(context.Context, api.UserId, string, bool) error
+	Logout(context.Context, string) error
+	CollectGarbage(context.Context) (*services.GCResult, error)
+	VerifyFiles(context.Context) ([]api.ResourceId, error)
+	ListAudit(context.Context, string, int64, int) ([]auditModel.Event, error)
+	VerifyAudit(context.Context) (*services.AuditVerifyResult, error)
+}
+
+type AdminServer struct {
+	pb.UnimplementedAdminServer
+	service AdminService
+}
+
+func NewAdminServer(service AdminService) *AdminServer {
+	return &AdminServer{service: service}
+}
+
+func (s *AdminServer) ListUsers(_ *emptypb.Empty, stream pb.Admin_ListUsersServer) error {
+	users, err := s.service.ListUsers(stream.Context())
+	if err != nil {
+		return toStatusError(err)
+	}
+	for i := 0; i < len(users); i++ {
+		if err := stream.Send(toPbUserInfo(&users[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *AdminServer) GetUser(ctx context.Context, query *pb.UserQuery) (*pb.UserInfo, error) {
+	user, err := s.service.GetUser(ctx, query.Login)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toPbUserInfo(user), nil
+}
+
+func (s *AdminServer) SetDisabled(ctx context.Context, state *pb.AccountState) (*emptypb.Empty, error) {
+	if err := s.service.SetDisabled(ctx, extractUserId(ctx), state.Login, state.Disabled); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AdminServer) Logout(ctx context.Context, query *pb.UserQuery) (*emptypb.Empty, error) {
+	if err := s.service.Logout(ctx, query.Login); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AdminServer) RunMaintenance(ctx context.Context, request *pb.MaintenanceRequest) (*pb.MaintenanceResult, error) {
+	switch request.Task {
+	case pb.MAINTENANCE_TASK_BLOB_GC:
+		result, err := s.service.CollectGarbage(ctx)
+		if err != nil {
+			return nil, toStatusError(err)
+		}
+		return &pb.MaintenanceResult{
+			RemovedFiles:   result.RemovedFiles,
+			FreedBytes:     result.FreedBytes,
+			ExpiredUploads: result.ExpiredUploads,
+		}, nil
+	case pb.MAINTENANCE_TASK_VERIFY:
+		corrupted, err := s.service.VerifyFiles(ctx)
+		if err != nil {
+			return nil, toStatusError(err)
+		}
+		result := &pb.MaintenanceResult{}
+		for i := 0; i < len(corrupted); i++ {
+			result.Corrupted = append(result.Corrupted, &pb.UUID{Value: corrupted[i][:]})
+		}
+		return result, nil
+	}
+	return nil, status.Error(codes.InvalidArgument, "unknown maintenance task")
+}
+
+func (s *AdminServer) ListAudit(query *pb.AuditQuery, stream pb.Admin_ListAuditServer) error {
+	events, err := s.service.ListAudit(stream.Context(), query.Login, query.Before, int(query.Limit))
+	if err != nil {
+		return toStatusError(err)
+	}
+	return sendAuditEvents(events, stream.Send)
+}
+
+func (s *AdminServer) VerifyAudit(ctx context.Context, _ *emptypb.Empty) (*pb.AuditVerifyResult, error) {
+	result, err := s.service.VerifyAudit(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.AuditVerifyResult{Checked: result.Checked, FirstInvalidSeq: result.FirstInvalidSeq, Head: result.Head}, nil
+}
+
+func toPbUserInfo(user *model.UserInfo) *pb.UserInfo {
+	return &pb.UserInfo{
+		Id:       &pb.UUID{Value: user.Id[:]},
+		Login:    user.Login,
+		IsAdmin:  user.IsAdmin,
+		Disabled: user.Disabled,
+		Items:    user.Items,
+		Bytes:    user.Bytes,
+	}
+}
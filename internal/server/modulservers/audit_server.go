@@ -0,0 +1,57 @@
+package modulservers
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"secstorage/internal/api"
+	pb "secstorage/internal/api/proto"
+	"secstorage/internal/server/storage/audit/model"
+)
+
+type AuditService interface {
+	List(context.Context, api.UserId, int64, int) ([]model.Event, error)
+}
+
+type AuditServer struct {
+	pb.UnimplementedAuditServer
+	service AuditService
+}
+
+func NewAuditServer(service AuditService) *AuditServer {
+	return &AuditServer{service: service}
+}
+
+func (s *AuditServer) List(query *pb.AuditQuery, stream pb.Audit_ListServer) error {
+	events, err := s.service.List(stream.Context(), extractUserId(stream.Context()), query.Before, int(query.Limit))
+	if err != nil {
+		return toStatusError(err)
+	}
+	return sendAuditEvents(events, stream.Send)
+}
+
+func sendAuditEvents(events []model.Event, send func(*pb.AuditEvent) error) error {
+	for i := 0; i < len(events); i++ {
+		event := &pb.AuditEvent{
+			Seq:       events[i].Seq,
+			UserId:    toPbOptionalId(events[i].UserId),
+			Login:     events[i].Login,
+			Action:    pb.AUDIT_ACTION(events[i].Action),
+			TargetId:  toPbOptionalId(events[i].TargetId),
+			OwnerId:   toPbOptionalId(events[i].OwnerId),
+			Details:   events[i].Details,
+			CreatedAt: timestamppb.New(events[i].CreatedAt),
+		}
+		if err := send(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toPbOptionalId(id uuid.NullUUID) *pb.UUID {
+	if !id.Valid {
+		return nil
+	}
+	return &pb.UUID{Value: id.UUID[:]}
+}
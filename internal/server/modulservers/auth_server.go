@@ -7,6 +7,7 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	pb "secstorage/internal/api/proto"
 	. "secstorage/internal/logger"
@@ -19,6 +20,8 @@ import (
 type AuthService interface {
 	Register(ctx context.Context, info model.User) (uuid.UUID, error)
 	Login(ctx context.Context, info model.User) (uuid.UUID, error)
+	SetRecovery(ctx context.Context, userId uuid.UUID, password string, auth []byte, privateKey []byte) error
+	Recover(ctx context.Context, login string, auth []byte, newPassword string) (*model.RecoveredUser, error)
 }
 
 type AuthServer struct {
@@ -40,7 +43,7 @@ func (s *AuthServer) Register(ctx context.Context, authData *pb.AuthData) (*pb.T
 	id, err := s.authService.Register(ctx, User)
 
 	if err == nil {
-		return s.genToken(id)
+		return s.genToken(ctx, id)
 	}
 	if errors.Is(err, reservederrors.ErrUserAlreadyExist) {
 		return nil, status.Error(codes.AlreadyExists, err.Error())
@@ -55,14 +58,39 @@ func (s *AuthServer) Login(ctx context.Context, authData *pb.AuthData) (*pb.Toke
 	User := model.User{Login: authData.Login, Password: authData.Password}
 	id, err := s.authService.Login(ctx, User)
 	if err == nil {
-		return s.genToken(id)
+		return s.genToken(ctx, id)
 	}
 	if errors.Is(err, reservederrors.ErrUserNotFound) {
 		return nil, status.Error(codes.NotFound, err.Error())
 	}
+	if errors.Is(err, reservederrors.ErrUserDisabled) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
 	return nil, status.Error(codes.Internal, "internal error")
 }
 
+func (s *AuthServer) SetRecovery(ctx context.Context, setup *pb.RecoverySetup) (*emptypb.Empty, error) {
+	if err := s.authService.SetRecovery(ctx, extractUserId(ctx), setup.Password, setup.RecoveryAuth, setup.RecoveryPrivateKey); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthServer) Recover(ctx context.Context, request *pb.RecoverRequest) (*pb.RecoverResponse, error) {
+	if err := validateAuthData(&pb.AuthData{Login: request.Login, Password: request.NewPassword}); err != nil {
+		return nil, err
+	}
+	user, err := s.authService.Recover(ctx, request.Login, request.RecoveryAuth, request.NewPassword)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	token, err := s.genToken(ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RecoverResponse{Token: token, RecoveryPrivateKey: user.PrivateKey}, nil
+}
+
 func validateAuthData(authData *pb.AuthData) error {
 	if len(authData.Login) == 0 || len(authData.Password) == 0 {
 		return status.Error(codes.InvalidArgument, "invalid login/password format: must be nonempty")
@@ -70,9 +98,9 @@ func validateAuthData(authData *pb.AuthData) error {
 	return nil
 }
 
-func (s *AuthServer) genToken(id uuid.UUID) (*pb.TokenData, error) {
+func (s *AuthServer) genToken(ctx context.Context, id uuid.UUID) (*pb.TokenData, error) {
 	expireAt := time.Now().UTC().Add(time.Hour)
-	token, err := s.tokenService.Generate(id, expireAt)
+	token, err := s.tokenService.Generate(ctx, id, expireAt)
 	if err != nil {
 		Log.Error("error on register", zap.Error(err))
 		return nil, status.Error(codes.Internal, "internal error")
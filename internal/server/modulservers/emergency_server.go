@@ -0,0 +1,88 @@
+package modulservers
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"secstorage/internal/api"
+	pb "secstorage/internal/api/proto"
+	"secstorage/internal/server/storage/emergency/model"
+)
+
+type EmergencyService interface {
+	Add(context.Context, api.UserId, string, int) (uuid.UUID, error)
+	List(context.Context, api.UserId) ([]model.Access, error)
+	Request(context.Context, api.UserId, uuid.UUID) error
+	Approve(context.Context, api.UserId, uuid.UUID) error
+	Reject(context.Context, api.UserId, uuid.UUID) error
+	Remove(context.Context, api.UserId, uuid.UUID) error
+}
+
+type EmergencyServer struct {
+	pb.UnimplementedEmergencyServer
+	service EmergencyService
+}
+
+func NewEmergencyServer(service EmergencyService) *EmergencyServer {
+	return &EmergencyServer{service: service}
+}
+
+func (s *EmergencyServer) Add(ctx context.Context, contact *pb.TrustedContact) (*pb.UUID, error) {
+	id, err := s.service.Add(ctx, extractUserId(ctx), contact.Login, int(contact.WaitHours))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.UUID{Value: id[:]}, nil
+}
+
+func (s *EmergencyServer) List(_ *emptypb.Empty, stream pb.Emergency_ListServer) error {
+	accesses, err := s.service.List(stream.Context(), extractUserId(stream.Context()))
+	if err != nil {
+		return toStatusError(err)
+	}
+	for i := 0; i < len(accesses); i++ {
+		access := &pb.EmergencyAccess{
+			Id:        &pb.UUID{Value: accesses[i].Id[:]},
+			GrantorId: &pb.UUID{Value: accesses[i].GrantorId[:]},
+			Grantor:   accesses[i].GrantorLogin,
+			Trustee:   accesses[i].TrusteeLogin,
+			WaitHours: int32(accesses[i].WaitHours),
+			Status:    pb.EMERGENCY_STATUS(accesses[i].Status),
+		}
+		if accesses[i].RequestedAt.Valid {
+			access.RequestedAt = timestamppb.New(accesses[i].RequestedAt.Time)
+		}
+		if err := stream.Send(access); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *EmergencyServer) Request(ctx context.Context, id *pb.UUID) (*emptypb.Empty, error) {
+	return s.call(ctx, id, s.service.Request)
+}
+
+func (s *EmergencyServer) Approve(ctx context.Context, id *pb.UUID) (*emptypb.Empty, error) {
+	return s.call(ctx, id, s.service.Approve)
+}
+
+func (s *EmergencyServer) Reject(ctx context.Context, id *pb.UUID) (*emptypb.Empty, error) {
+	return s.call(ctx, id, s.service.Reject)
+}
+
+func (s *EmergencyServer) Remove(ctx context.Context, id *pb.UUID) (*emptypb.Empty, error) {
+	return s.call(ctx, id, s.service.Remove)
+}
+
+func (s *EmergencyServer) call(ctx context.Context, id *pb.UUID, f func(context.Context, api.UserId, uuid.UUID) error) (*emptypb.Empty, error) {
+	accessId, err := fromPbId(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := f(ctx, extractUserId(ctx), accessId); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,161 @@
+package modulservers
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"secstorage/internal/api"
+	pb "secstorage/internal/api/proto"
+	"secstorage/internal/server/storage/organization/model"
+)
+
+type OrgService interface {
+	Create(context.Context, api.UserId, string) (*model.Organization, error)
+	List(context.Context, api.UserId) ([]model.Membership, error)
+	Invite(context.Context, api.UserId, uuid.UUID, string, api.Role) error
+	Accept(context.Context, api.UserId, uuid.UUID) error
+	SetRole(context.Context, api.UserId, uuid.UUID, string, api.Role) error
+	RemoveMember(context.Context, api.UserId, uuid.UUID, string) error
+	ListMembers(context.Context, api.UserId, uuid.UUID) ([]model.Member, error)
+	CreateCollection(context.Context, api.UserId, uuid.UUID, string) (*model.Collection, error)
+	ListCollections(context.Context, api.UserId, uuid.UUID) ([]model.Collection, error)
+}
+
+type OrgServer struct {
+	pb.UnimplementedOrganizationsServer
+	service OrgService
+}
+
+func NewOrgServer(service OrgService) *OrgServer {
+	return &OrgServer{service: service}
+}
+
+func (s *OrgServer) Create(ctx context.Context, request *pb.OrganizationRequest) (*pb.Organization, error) {
+	org, err := s.service.Create(ctx, extractUserId(ctx), request.Name)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.Organization{Id: &pb.UUID{Value: org.Id[:]}, Name: org.Name, Role: pb.ROLE_OWNER, Accepted: true}, nil
+}
+
+func (s *OrgServer) List(_ *emptypb.Empty, stream pb.Organizations_ListServer) error {
+	orgs, err := s.service.List(stream.Context(), extractUserId(stream.Context()))
+	if err != nil {
+		return toStatusError(err)
+	}
+	for i := 0; i < len(orgs); i++ {
+		err := stream.Send(&pb.Organization{
+			Id:       &pb.UUID{Value: orgs[i].OrgId[:]},
+			Name:     orgs[i].Name,
+			Role:     pb.ROLE(orgs[i].Role),
+			Accepted: orgs[i].Accepted,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *OrgServer) Invite(ctx context.Context, request *pb.MemberRequest) (*emptypb.Empty, error) {
+	orgId, err := fromPbId(request.OrgId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.service.Invite(ctx, extractUserId(ctx), orgId, request.Login, api.Role(request.Role)); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *OrgServer) Accept(ctx context.Context, id *pb.UUID) (*emptypb.Empty, error) {
+	orgId, err := fromPbId(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.service.Accept(ctx, extractUserId(ctx), orgId); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *OrgServer) SetRole(ctx context.Context, request *pb.MemberRequest) (*emptypb.Empty, error) {
+	orgId, err := fromPbId(request.OrgId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.service.SetRole(ctx, extractUserId(ctx), orgId, request.Login, api.Role(request.Role)); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *OrgServer) RemoveMember(ctx context.Context, request *pb.MemberRequest) (*emptypb.Empty, error) {
+	orgId, err := fromPbId(request.OrgId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.service.RemoveMember(ctx, extractUserId(ctx), orgId, request.Login); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *OrgServer) ListMembers(id *pb.UUID, stream pb.Organizations_ListMembersServer) error {
+	orgId, err := fromPbId(id)
+	if err != nil {
+		return err
+	}
+	members, err := s.service.ListMembers(stream.Context(), extractUserId(stream.Context()), orgId)
+	if err != nil {
+		return toStatusError(err)
+	}
+	for i := 0; i < len(members); i++ {
+		err := stream.Send(&pb.OrgMember{
+			Login:    members[i].Login,
+			Role:     pb.ROLE(members[i].Role),
+			Accepted: members[i].Accepted,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *OrgServer) CreateCollection(ctx context.Context, request *pb.CollectionRequest) (*pb.Collection, error) {
+	orgId, err := fromPbId(request.OrgId)
+	if err != nil {
+		return nil, err
+	}
+	collection, err := s.service.CreateCollection(ctx, extractUserId(ctx), orgId, request.Name)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return toPbCollection(collection), nil
+}
+
+func (s *OrgServer) ListCollections(id *pb.UUID, stream pb.Organizations_ListCollectionsServer) error {
+	orgId, err := fromPbId(id)
+	if err != nil {
+		return err
+	}
+	collections, err := s.service.ListCollections(stream.Context(), extractUserId(stream.Context()), orgId)
+	if err != nil {
+		return toStatusError(err)
+	}
+	for i := 0; i < len(collections); i++ {
+		if err := stream.Send(toPbCollection(&collections[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toPbCollection(collection *model.Collection) *pb.Collection {
+	return &pb.Collection{
+		Id:    &pb.UUID{Value: collection.Id[:]},
+		OrgId: &pb.UUID{Value: collection.OrgId[:]},
+		Name:  collection.Name,
+	}
+}
@@ -0,0 +1,232 @@
+package modulservers
+
+import (
+	"encoding/json"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"secstorage/internal/api"
+	pb "secstorage/internal/api/proto"
+	"secstorage/internal/server/storage/resource/model"
+	"time"
+)
+
+// fromPbPayload encodes the typed payload into json kept in storage.
+// Clients that don't know typed payloads send the json in data, it is passed as is.
+func fromPbPayload(resource *pb.Resource) (api.ResourceType, []byte, error) {
+	var rType api.ResourceType
+	var payload any
+	fields := model.CustomFields{Fields: fromPbFields(resource.Fields)}
+	switch p := resource.Payload.(type) {
+	case *pb.Resource_LoginPassword:
+		rType = api.LoginPassword
+		changedAt := time.Now().UTC()
+		if p.LoginPassword.PasswordChangedAt != nil {
+			changedAt = p.LoginPassword.PasswordChangedAt.AsTime()
+		}
+		var totp *model.OTP
+		if p.LoginPassword.Totp != nil {
+			totp = fromPbOTP(p.LoginPassword.Totp)
+		}
+		payload = &model.LoginPassword{
+			CustomFields:      fields,
+			Login:             p.LoginPassword.Login,
+			Password:          p.LoginPassword.Password,
+			URLs:              p.LoginPassword.Urls,
+			Notes:             p.LoginPassword.Notes,
+			TOTP:              totp,
+			PasswordChangedAt: &changedAt,
+		}
+
+	case *pb.Resource_BankCard:
+		rType = api.BankCard
+		payload = &model.BankCard{
+			CustomFields: fields,
+			Number:       p.BankCard.Number,
+			Until:        p.BankCard.Until,
+			Name:         p.BankCard.Name,
+			Surname:      p.BankCard.Surname,
+		}
+
+	case *pb.Resource_SecureNote:
+		rType = api.SecureNote
+		payload = &model.SecureNote{CustomFields: fields, Title: p.SecureNote.Title, Body: p.SecureNote.Body}
+
+	case *pb.Resource_Otp:
+		rType = api.OTP
+		key := fromPbOTP(p.Otp)
+		key.CustomFields = fields
+		payload = key
+
+	case *pb.Resource_SshKey:
+		rType = api.SSHKey
+		payload = &model.SSHKey{
+			CustomFields: fields,
+			PrivateKey:   p.SshKey.PrivateKey,
+			PublicKey:    p.SshKey.PublicKey,
+			Comment:      p.SshKey.Comment,
+			Passphrase:   p.SshKey.Passphrase,
+		}
+
+	case *pb.Resource_Template:
+		rType = api.Template
+		payload = &model.Template{
+			CustomFields: fields,
+			Name:         p.Template.Name,
+			ResourceType: api.ResourceType(p.Template.ResourceType),
+		}
+
+	default:
+		return api.ResourceType(resource.Type), resource.Data, nil
+	}
+
+	if resource.Type != pb.TYPE_UNDEFINED && api.ResourceType(resource.Type) != rType {
+		return api.Undefined, nil, status.Error(codes.InvalidArgument, "payload doesn't match resource type")
+	}
+	data, err := json.Marshal(payload)
+	return rType, data, err
+}
+
+// toPb converts the stored resource filling both json data and the typed payload.
+func toPb(resource *model.Resource) *pb.Resource {
+	result := &pb.Resource{
+		Type:     pb.TYPE(resource.Type),
+		Data:     resource.Data,
+		Meta:     resource.Meta,
+		Revision: resource.Revision,
+	}
+	if resource.CollectionId.Valid {
+		result.CollectionId = &pb.UUID{Value: resource.CollectionId.UUID[:]}
+	}
+	switch resource.Type {
+	case api.LoginPassword:
+		var lp model.LoginPassword
+		if err := json.Unmarshal(resource.Data, &lp); err == nil {
+			data := &pb.LoginPasswordData{
+				Login:    lp.Login,
+				Password: lp.Password,
+				Urls:     lp.URLs,
+				Notes:    lp.Notes,
+			}
+			if lp.TOTP != nil {
+				data.Totp = toPbOTP(lp.TOTP)
+			}
+			if lp.PasswordChangedAt != nil {
+				data.PasswordChangedAt = timestamppb.New(*lp.PasswordChangedAt)
+			}
+			result.Payload = &pb.Resource_LoginPassword{LoginPassword: data}
+		}
+
+	case api.BankCard:
+		var bc model.BankCard
+		if err := json.Unmarshal(resource.Data, &bc); err == nil {
+			result.Payload = &pb.Resource_BankCard{BankCard: &pb.BankCardData{
+				Number:  bc.Number,
+				Until:   bc.Until,
+				Name:    bc.Name,
+				Surname: bc.Surname,
+			}}
+		}
+
+	case api.SecureNote:
+		var sn model.SecureNote
+		if err := json.Unmarshal(resource.Data, &sn); err == nil {
+			result.Payload = &pb.Resource_SecureNote{SecureNote: &pb.SecureNoteData{
+				Title: sn.Title,
+				Body:  sn.Body,
+			}}
+		}
+
+	case api.OTP:
+		var key model.OTP
+		if err := json.Unmarshal(resource.Data, &key); err == nil {
+			result.Payload = &pb.Resource_Otp{Otp: toPbOTP(&key)}
+		}
+
+	case api.SSHKey:
+		var key model.SSHKey
+		if err := json.Unmarshal(resource.Data, &key); err == nil {
+			result.Payload = &pb.Resource_SshKey{SshKey: &pb.SSHKeyData{
+				PrivateKey: key.PrivateKey,
+				PublicKey:  key.PublicKey,
+				Comment:    key.Comment,
+				Passphrase: key.Passphrase,
+			}}
+		}
+
+	case api.Template:
+		var template model.Template
+		if err := json.Unmarshal(resource.Data, &template); err == nil {
+			result.Payload = &pb.Resource_Template{Template: &pb.TemplateData{
+				Name:         template.Name,
+				ResourceType: pb.TYPE(template.ResourceType),
+			}}
+		}
+	}
+
+	var fields model.CustomFields
+	if err := json.Unmarshal(resource.Data, &fields); err == nil {
+		result.Fields = toPbFields(fields.Fields)
+	}
+	if resource.Shared != nil {
+		result.Shared = &pb.SharedData{
+			Owner:      resource.Shared.OwnerLogin,
+			Permission: pb.PERMISSION(resource.Shared.Permission),
+			WrappedKey: resource.Shared.WrappedKey,
+			Data:       resource.Shared.Data,
+		}
+	}
+	return result
+}
+
+func fromPbOTP(key *pb.OTPData) *model.OTP {
+	return &model.OTP{
+		Secret:    key.Secret,
+		Issuer:    key.Issuer,
+		Account:   key.Account,
+		Digits:    int(key.Digits),
+		Period:    int(key.Period),
+		Algorithm: key.Algorithm,
+	}
+}
+
+func toPbOTP(key *model.OTP) *pb.OTPData {
+	return &pb.OTPData{
+		Secret:    key.Secret,
+		Issuer:    key.Issuer,
+		Account:   key.Account,
+		Digits:    int32(key.Digits),
+		Period:    int32(key.Period),
+		Algorithm: key.Algorithm,
+	}
+}
+
+func fromPbFields(fields []*pb.CustomField) []model.CustomField {
+	if len(fields) == 0 {
+		return nil
+	}
+	result := make([]model.CustomField, len(fields))
+	for i := 0; i < len(fields); i++ {
+		result[i] = model.CustomField{
+			Name:  fields[i].Name,
+			Type:  api.FieldType(fields[i].Type),
+			Value: fields[i].Value,
+		}
+	}
+	return result
+}
+
+func toPbFields(fields []model.CustomField) []*pb.CustomField {
+	if len(fields) == 0 {
+		return nil
+	}
+	result := make([]*pb.CustomField, len(fields))
+	for i := 0; i < len(fields); i++ {
+		result[i] = &pb.CustomField{
+			Name:  fields[i].Name,
+			Type:  pb.FIELD_TYPE(fields[i].Type),
+			Value: fields[i].Value,
+		}
+	}
+	return result
+}
@@ -2,22 +2,43 @@ package modulservers
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"io"
 	"secstorage/internal/api"
 	pb "secstorage/internal/api/proto"
+	"secstorage/internal/server/events"
+	"secstorage/internal/server/reservederrors"
+	"secstorage/internal/server/services"
 	"secstorage/internal/server/storage/resource/model"
+	uploadModel "secstorage/internal/server/storage/upload/model"
 )
 
 type ResourceService interface {
 	Save(context.Context, *model.Resource) error
 	Delete(context.Context, api.ResourceId, api.UserId) error
-	ListByUserId(context.Context, api.UserId, api.ResourceType) ([]model.ShortResourceInfo, error)
+	ListByUserId(context.Context, api.UserId, api.ResourceType, uuid.NullUUID, uuid.NullUUID) ([]model.ShortResourceInfo, error)
 	Get(context.Context, api.ResourceId, api.UserId, api.ResourceType) (*model.Resource, error)
-	SaveFile(context.Context, api.UserId, []byte, func() ([]byte, error)) (api.ResourceId, error)
-	GetFile(resource *model.Resource, chunkSender func([]byte) error) error
+	ListAttachments(context.Context, api.ResourceId, api.UserId) ([]model.ShortResourceInfo, error)
+	SaveFile(context.Context, api.UserId, []byte, uuid.NullUUID, func() ([]byte, error)) (api.ResourceId, error)
+	GetFile(resource *model.Resource, offset int64, length int64, chunkSize int, chunkSender func([]byte) error) error
+	ChunkSize(requested int) int
+	Usage(context.Context, api.UserId) (model.Usage, error)
+	Quota() services.Quota
+	InitUpload(context.Context, api.UserId, []byte, uuid.NullUUID) (*uploadModel.Session, error)
+	UploadChunk(context.Context, api.UserId, uuid.UUID, int64, []byte) (int64, error)
+	UploadOffset(context.Context, api.UserId, uuid.UUID) (int64, error)
+	CompleteUpload(context.Context, api.UserId, uuid.UUID) (api.ResourceId, error)
+	Watch(api.UserId) (<-chan events.Change, func())
+	Sync(context.Context, api.UserId, int64, int) ([]model.Change, int64, bool, error)
+	Update(context.Context, api.UserId, *model.Resource, int64) (int64, uuid.NullUUID, error)
+	ListConflicts(context.Context, api.UserId) ([]model.Conflict, []*model.Resource, error)
+	ResolveConflict(context.Context, api.UserId, uuid.UUID, *model.Resource, int64) error
 }
 
 type ResourceServer struct {
@@ -32,15 +53,27 @@ func NewResourcesServer(service ResourceService) *ResourceServer {
 }
 
 func (s *ResourceServer) Save(ctx context.Context, resource *pb.Resource) (*pb.UUID, error) {
+	rType, data, err := fromPbPayload(resource)
+	if err != nil {
+		return nil, err
+	}
+	collectionId, err := fromPbOptionalId(resource.CollectionId)
+	if err != nil {
+		return nil, err
+	}
 	id := uuid.New()
-	err := s.service.Save(ctx, &model.Resource{
-		Id:     id,
-		UserId: extractUserId(ctx),
-		Type:   api.ResourceType(resource.Type),
-		Data:   resource.Data,
-		Meta:   resource.Meta,
+	err = s.service.Save(ctx, &model.Resource{
+		Id:           id,
+		UserId:       extractUserId(ctx),
+		Type:         rType,
+		Data:         data,
+		Meta:         resource.Meta,
+		CollectionId: collectionId,
 	})
-	return &pb.UUID{Value: id[:]}, err
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.UUID{Value: id[:]}, nil
 }
 
 func (s *ResourceServer) Delete(ctx context.Context, id *pb.UUID) (*emptypb.Empty, error) {
@@ -49,7 +82,7 @@ func (s *ResourceServer) Delete(ctx context.Context, id *pb.UUID) (*emptypb.Empt
 		return nil, err
 	}
 	if err := s.service.Delete(ctx, rId, extractUserId(ctx)); err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 	return &emptypb.Empty{}, nil
 }
@@ -57,15 +90,24 @@ func (s *ResourceServer) Delete(ctx context.Context, id *pb.UUID) (*emptypb.Empt
 func (s *ResourceServer) ListByUserId(query *pb.Query, stream pb.Resources_ListByUserIdServer) error {
 	t := api.ResourceType(query.ResourceType)
 	userId := extractUserId(stream.Context())
-	list, err := s.service.ListByUserId(stream.Context(), userId, t)
+	collectionId, err := fromPbOptionalId(query.CollectionId)
+	if err != nil {
+		return err
+	}
+	ownerId, err := fromPbOptionalId(query.OwnerId)
 	if err != nil {
 		return err
 	}
+	list, err := s.service.ListByUserId(stream.Context(), userId, t, collectionId, ownerId)
+	if err != nil {
+		return toStatusError(err)
+	}
 
 	for i := 0; i < len(list); i++ {
 		err := stream.Send(&pb.ShortResourceInfo{
-			Id:   &pb.UUID{Value: list[i].Id[:]},
-			Meta: list[i].Meta,
+			Id:     &pb.UUID{Value: list[i].Id[:]},
+			Meta:   list[i].Meta,
+			Shared: list[i].Shared,
 		})
 		if err != nil {
 			return err
@@ -80,15 +122,23 @@ func (s *ResourceServer) Get(ctx context.Context, id *pb.UUID) (*pb.Resource, er
 	if err != nil {
 		return nil, err
 	}
-	result, err := s.service.Get(ctx, rId, extractUserId(ctx), api.Undefined)
+	userId := extractUserId(ctx)
+	result, err := s.service.Get(ctx, rId, userId, api.Undefined)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
-	return &pb.Resource{
-		Type: pb.TYPE(result.Type),
-		Data: result.Data,
-		Meta: result.Meta,
-	}, nil
+	attachments, err := s.service.ListAttachments(ctx, rId, userId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resource := toPb(result)
+	for i := 0; i < len(attachments); i++ {
+		resource.Attachments = append(resource.Attachments, &pb.ShortResourceInfo{
+			Id:   &pb.UUID{Value: attachments[i].Id[:]},
+			Meta: attachments[i].Meta,
+		})
+	}
+	return resource, nil
 }
 
 func (s *ResourceServer) SaveFile(stream pb.Resources_SaveFileServer) error {
@@ -99,11 +149,16 @@ func (s *ResourceServer) SaveFile(stream pb.Resources_SaveFileServer) error {
 	if err != nil {
 		return err
 	}
+	parentId, err := fromPbOptionalId(chunk.ParentId)
+	if err != nil {
+		return err
+	}
 
 	rId, err := s.service.SaveFile(
 		stream.Context(),
 		extractUserId(stream.Context()),
 		chunk.Meta,
+		parentId,
 		func() ([]byte, error) {
 			chunk, err := stream.Recv()
 			if err != nil {
@@ -113,7 +168,7 @@ func (s *ResourceServer) SaveFile(stream pb.Resources_SaveFileServer) error {
 		},
 	)
 	if err != nil {
-		return err
+		return toStatusError(err)
 	}
 
 	id := &pb.UUID{Value: rId[:]}
@@ -121,25 +176,32 @@ func (s *ResourceServer) SaveFile(stream pb.Resources_SaveFileServer) error {
 	return stream.SendAndClose(id)
 }
 
-func (s *ResourceServer) GetFile(id *pb.UUID, stream pb.Resources_GetFileServer) error {
-	rId, err := uuid.FromBytes(id.Value)
+func (s *ResourceServer) GetFile(request *pb.FileRequest, stream pb.Resources_GetFileServer) error {
+	if request.Id == nil || request.Offset < 0 || request.Length < 0 {
+		return status.Error(codes.InvalidArgument, "invalid file request")
+	}
+	rId, err := uuid.FromBytes(request.Id.Value)
 	if err != nil {
-		return err
+		return status.Error(codes.InvalidArgument, err.Error())
 	}
 
 	resource, err := s.service.Get(stream.Context(), rId, extractUserId(stream.Context()), api.File)
 	if err != nil {
-		return err
+		return toStatusError(err)
 	}
 	err = stream.Send(&pb.FileChunk{
-		Meta: resource.Meta,
-		Data: nil,
+		Meta:     resource.Meta,
+		Data:     nil,
+		Checksum: resource.Checksum,
 	})
 	if err != nil {
 		return err
 	}
-	return s.service.GetFile(
+	err = s.service.GetFile(
 		resource,
+		request.Offset,
+		request.Length,
+		s.service.ChunkSize(int(request.ChunkSize)),
 		func(bytes []byte) error {
 			return stream.Send(&pb.FileChunk{
 				Meta: nil,
@@ -147,4 +209,247 @@ func (s *ResourceServer) GetFile(id *pb.UUID, stream pb.Resources_GetFileServer)
 			})
 		},
 	)
+	return toStatusError(err)
+}
+
+func (s *ResourceServer) Usage(ctx context.Context, _ *emptypb.Empty) (*pb.UsageInfo, error) {
+	usage, err := s.service.Usage(ctx, extractUserId(ctx))
+	if err != nil {
+		return nil, err
+	}
+	quota := s.service.Quota()
+	return &pb.UsageInfo{
+		Items:       usage.Items,
+		Bytes:       usage.Bytes,
+		MaxItems:    quota.MaxItems,
+		MaxBytes:    quota.MaxBytes,
+		MaxFileSize: quota.MaxFileSize,
+	}, nil
+}
+
+func (s *ResourceServer) InitUpload(ctx context.Context, init *pb.UploadInit) (*pb.UploadSession, error) {
+	parentId, err := fromPbOptionalId(init.ParentId)
+	if err != nil {
+		return nil, err
+	}
+	session, err := s.service.InitUpload(ctx, extractUserId(ctx), init.Meta, parentId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.UploadSession{
+		Id:        &pb.UUID{Value: session.Id[:]},
+		Offset:    session.Committed,
+		ChunkSize: int32(s.service.ChunkSize(int(init.ChunkSize))),
+	}, nil
+}
+
+func (s *ResourceServer) UploadChunk(ctx context.Context, part *pb.FilePart) (*pb.UploadSession, error) {
+	if part.SessionId == nil {
+		return nil, status.Error(codes.InvalidArgument, "session id is required")
+	}
+	sessionId, err := uuid.FromBytes(part.SessionId.Value)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	committed, err := s.service.UploadChunk(ctx, extractUserId(ctx), sessionId, part.Offset, part.Data)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.UploadSession{Id: part.SessionId, Offset: committed}, nil
+}
+
+func (s *ResourceServer) GetUploadOffset(ctx context.Context, id *pb.UUID) (*pb.UploadSession, error) {
+	sessionId, err := uuid.FromBytes(id.Value)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	committed, err := s.service.UploadOffset(ctx, extractUserId(ctx), sessionId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.UploadSession{Id: id, Offset: committed}, nil
+}
+
+func (s *ResourceServer) CompleteUpload(ctx context.Context, id *pb.UUID) (*pb.UUID, error) {
+	sessionId, err := uuid.FromBytes(id.Value)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	rId, err := s.service.CompleteUpload(ctx, extractUserId(ctx), sessionId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.UUID{Value: rId[:]}, nil
+}
+
+func (s *ResourceServer) Watch(_ *emptypb.Empty, stream pb.Resources_WatchServer) error {
+	changes, unsubscribe := s.service.Watch(extractUserId(stream.Context()))
+	defer unsubscribe()
+	// changes made before the subscription are not sent, so the client lists resources after the first reset
+	if err := stream.Send(&pb.ResourceChange{Kind: pb.CHANGE_KIND_RESET}); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case change, ok := <-changes:
+			if !ok {
+				return status.Error(codes.Aborted, "too many changes, list resources and watch again")
+			}
+			err := stream.Send(&pb.ResourceChange{
+				Kind: pb.CHANGE_KIND(change.Kind),
+				Id:   &pb.UUID{Value: change.ResourceId[:]},
+				Type: pb.TYPE(change.Type),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *ResourceServer) Sync(ctx context.Context, request *pb.SyncRequest) (*pb.SyncResponse, error) {
+	changes, highWaterMark, more, err := s.service.Sync(ctx, extractUserId(ctx), request.Since, int(request.Limit))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	result := &pb.SyncResponse{
+		Changes:       make([]*pb.ResourceChange, len(changes)),
+		HighWaterMark: highWaterMark,
+		More:          more,
+	}
+	for i := 0; i < len(changes); i++ {
+		change := &changes[i]
+		result.Changes[i] = &pb.ResourceChange{
+			Kind:         pb.CHANGE_KIND(change.Kind),
+			Id:           &pb.UUID{Value: change.ResourceId[:]},
+			Type:         pb.TYPE(change.Type),
+			Seq:          change.Seq,
+			Meta:         change.Meta,
+			ParentId:     toPbOptionalId(change.ParentId),
+			CollectionId: toPbOptionalId(change.CollectionId),
+		}
+	}
+	return result, nil
+}
+
+func (s *ResourceServer) Update(ctx context.Context, request *pb.UpdateRequest) (*pb.UpdateResult, error) {
+	rId, err := uuid.FromBytes(request.Id.GetValue())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if request.Resource == nil {
+		return nil, status.Error(codes.InvalidArgument, "resource is required")
+	}
+	rType, data, err := fromPbPayload(request.Resource)
+	if err != nil {
+		return nil, err
+	}
+	revision, conflictId, err := s.service.Update(ctx, extractUserId(ctx), &model.Resource{
+		Id:     rId,
+		Type:   rType,
+		Data:   data,
+		Meta:   request.Resource.Meta,
+		Shared: fromPbSharedData(request.SharedData),
+	}, request.BaseRevision)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.UpdateResult{Revision: revision, ConflictId: toPbOptionalId(conflictId)}, nil
+}
+
+func (s *ResourceServer) ListConflicts(_ *emptypb.Empty, stream pb.Resources_ListConflictsServer) error {
+	conflicts, current, err := s.service.ListConflicts(stream.Context(), extractUserId(stream.Context()))
+	if err != nil {
+		return toStatusError(err)
+	}
+	for i := 0; i < len(conflicts); i++ {
+		conflict := &conflicts[i]
+		err := stream.Send(&pb.Conflict{
+			Id:           &pb.UUID{Value: conflict.Id[:]},
+			ResourceId:   &pb.UUID{Value: conflict.ResourceId[:]},
+			Current:      toPb(current[i]),
+			Conflicting:  toPb(&model.Resource{Type: current[i].Type, Data: conflict.Data, Meta: conflict.Meta}),
+			BaseRevision: conflict.BaseRevision,
+			CreatedAt:    timestamppb.New(conflict.CreatedAt),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ResourceServer) ResolveConflict(ctx context.Context, resolution *pb.ConflictResolution) (*emptypb.Empty, error) {
+	id, err := uuid.FromBytes(resolution.Id.GetValue())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var resolved *model.Resource
+	if resolution.Resource != nil {
+		rType, data, err := fromPbPayload(resolution.Resource)
+		if err != nil {
+			return nil, err
+		}
+		resolved = &model.Resource{Type: rType, Data: data, Meta: resolution.Resource.Meta, Shared: fromPbSharedData(resolution.SharedData)}
+	}
+	if err := s.service.ResolveConflict(ctx, extractUserId(ctx), id, resolved, resolution.BaseRevision); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func fromPbOptionalId(id *pb.UUID) (uuid.NullUUID, error) {
+	if id == nil {
+		return uuid.NullUUID{}, nil
+	}
+	result, err := uuid.FromBytes(id.Value)
+	if err != nil {
+		return uuid.NullUUID{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return uuid.NullUUID{UUID: result, Valid: true}, nil
+}
+
+func toStatusError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return status.Error(codes.NotFound, "resource not found")
+	}
+	if errors.Is(err, reservederrors.ErrQuotaExceeded) || errors.Is(err, reservederrors.ErrFileTooLarge) || errors.Is(err, reservederrors.ErrTooManySends) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if errors.Is(err, reservederrors.ErrChecksumMismatch) {
+		return status.Error(codes.DataLoss, err.Error())
+	}
+	if errors.Is(err, reservederrors.ErrUploadNotFound) || errors.Is(err, reservederrors.ErrSendNotFound) || errors.Is(err, reservederrors.ErrEmergencyNotFound) ||
+		errors.Is(err, reservederrors.ErrConflictNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, reservederrors.ErrOffsetMismatch) || errors.Is(err, reservederrors.ErrRevisionConflict) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, reservederrors.ErrChunkTooLarge) || errors.Is(err, reservederrors.ErrInvalidResource) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, reservederrors.ErrPermissionDenied) || errors.Is(err, reservederrors.ErrNotMember) || errors.Is(err, reservederrors.ErrRecoveryFailed) || errors.Is(err, reservederrors.ErrWrongPassword) || errors.Is(err, reservederrors.ErrUserDisabled) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	if errors.Is(err, reservederrors.ErrNotShared) || errors.Is(err, reservederrors.ErrKeysNotFound) || errors.Is(err, reservederrors.ErrUserNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, reservederrors.ErrLastOwner) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, reservederrors.ErrKeysAlreadySet) || errors.Is(err, reservederrors.ErrMemberExists) || errors.Is(err, reservederrors.ErrEmergencyExists) {
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	return err
+}
+
+// fromPbSharedData keeps the shared copy sent with an update, nil means the update has no shared copy.
+func fromPbSharedData(data []byte) *model.Shared {
+	if len(data) == 0 {
+		return nil
+	}
+	return &model.Shared{Data: data}
 }
@@ -0,0 +1,83 @@
+package modulservers
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"secstorage/internal/api"
+	pb "secstorage/internal/api/proto"
+	"secstorage/internal/server/storage/send/model"
+	"time"
+)
+
+type SendService interface {
+	Create(context.Context, api.UserId, []byte, int, time.Time) (uuid.UUID, error)
+	Open(context.Context, uuid.UUID) (*model.Send, error)
+	List(context.Context, api.UserId) ([]model.Send, error)
+	Delete(context.Context, api.UserId, uuid.UUID) error
+}
+
+type SendServer struct {
+	pb.UnimplementedSendsServer
+	service SendService
+}
+
+func NewSendServer(service SendService) *SendServer {
+	return &SendServer{service: service}
+}
+
+func (s *SendServer) Create(ctx context.Context, request *pb.SendRequest) (*pb.UUID, error) {
+	id, err := s.service.Create(ctx, extractUserId(ctx), request.Data, int(request.MaxViews), request.ExpiresAt.AsTime())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.UUID{Value: id[:]}, nil
+}
+
+// Open is called without a token by people who got the link.
+func (s *SendServer) Open(ctx context.Context, id *pb.UUID) (*pb.SendData, error) {
+	sendId, err := fromPbId(id)
+	if err != nil {
+		return nil, err
+	}
+	send, err := s.service.Open(ctx, sendId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.SendData{
+		Data:      send.Data,
+		ViewsLeft: int32(send.MaxViews - send.Views),
+		ExpiresAt: timestamppb.New(send.ExpiresAt),
+	}, nil
+}
+
+func (s *SendServer) List(_ *emptypb.Empty, stream pb.Sends_ListServer) error {
+	sends, err := s.service.List(stream.Context(), extractUserId(stream.Context()))
+	if err != nil {
+		return toStatusError(err)
+	}
+	for i := 0; i < len(sends); i++ {
+		err := stream.Send(&pb.SendInfo{
+			Id:        &pb.UUID{Value: sends[i].Id[:]},
+			MaxViews:  int32(sends[i].MaxViews),
+			Views:     int32(sends[i].Views),
+			ExpiresAt: timestamppb.New(sends[i].ExpiresAt),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SendServer) Delete(ctx context.Context, id *pb.UUID) (*emptypb.Empty, error) {
+	sendId, err := fromPbId(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.service.Delete(ctx, extractUserId(ctx), sendId); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,142 @@
+package modulservers
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"secstorage/internal/api"
+	pb "secstorage/internal/api/proto"
+	authModel "secstorage/internal/server/storage/auth/model"
+	"secstorage/internal/server/storage/resource/model"
+)
+
+type ShareService interface {
+	SetKeys(context.Context, api.UserId, authModel.KeyPair) error
+	GetKeys(context.Context, api.UserId) (*authModel.KeyPair, error)
+	UpdateKeys(context.Context, api.UserId, authModel.KeyPair) error
+	GetPublicKey(context.Context, string) (*authModel.PublicKey, error)
+	Share(context.Context, api.UserId, *model.SharedItem, string, []byte, api.Permission) error
+	Unshare(context.Context, api.UserId, api.ResourceId, string) error
+	UpdateShared(context.Context, api.UserId, api.ResourceId, []byte) error
+	ListGrants(context.Context, api.UserId, api.ResourceId) ([]model.Grant, error)
+}
+
+type ShareServer struct {
+	pb.UnimplementedSharesServer
+	service ShareService
+}
+
+func NewShareServer(service ShareService) *ShareServer {
+	return &ShareServer{service: service}
+}
+
+func (s *ShareServer) SetKeys(ctx context.Context, keys *pb.KeyPair) (*emptypb.Empty, error) {
+	err := s.service.SetKeys(ctx, extractUserId(ctx), authModel.KeyPair{
+		PublicKey:           keys.PublicKey,
+		EncryptedPrivateKey: keys.EncryptedPrivateKey,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ShareServer) GetKeys(ctx context.Context, _ *emptypb.Empty) (*pb.KeyPair, error) {
+	keys, err := s.service.GetKeys(ctx, extractUserId(ctx))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.KeyPair{PublicKey: keys.PublicKey, EncryptedPrivateKey: keys.EncryptedPrivateKey}, nil
+}
+
+func (s *ShareServer) UpdateKeys(ctx context.Context, keys *pb.KeyPair) (*emptypb.Empty, error) {
+	err := s.service.UpdateKeys(ctx, extractUserId(ctx), authModel.KeyPair{
+		PublicKey:           keys.PublicKey,
+		EncryptedPrivateKey: keys.EncryptedPrivateKey,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ShareServer) GetPublicKey(ctx context.Context, query *pb.UserQuery) (*pb.PublicKey, error) {
+	key, err := s.service.GetPublicKey(ctx, query.Login)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pb.PublicKey{UserId: &pb.UUID{Value: key.UserId[:]}, PublicKey: key.PublicKey}, nil
+}
+
+func (s *ShareServer) Share(ctx context.Context, request *pb.ShareRequest) (*emptypb.Empty, error) {
+	resourceId, err := fromPbId(request.ResourceId)
+	if err != nil {
+		return nil, err
+	}
+	item := &model.SharedItem{
+		ResourceId: resourceId,
+		OwnerKey:   request.OwnerKey,
+		Data:       request.Data,
+	}
+	err = s.service.Share(ctx, extractUserId(ctx), item, request.Recipient, request.WrappedKey, api.Permission(request.Permission))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ShareServer) Unshare(ctx context.Context, request *pb.UnshareRequest) (*emptypb.Empty, error) {
+	resourceId, err := fromPbId(request.ResourceId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.service.Unshare(ctx, extractUserId(ctx), resourceId, request.Recipient); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ShareServer) UpdateShared(ctx context.Context, update *pb.SharedUpdate) (*emptypb.Empty, error) {
+	resourceId, err := fromPbId(update.ResourceId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.service.UpdateShared(ctx, extractUserId(ctx), resourceId, update.Data); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ShareServer) ListGrants(id *pb.UUID, stream pb.Shares_ListGrantsServer) error {
+	resourceId, err := fromPbId(id)
+	if err != nil {
+		return err
+	}
+	grants, err := s.service.ListGrants(stream.Context(), extractUserId(stream.Context()), resourceId)
+	if err != nil {
+		return toStatusError(err)
+	}
+	for i := 0; i < len(grants); i++ {
+		err := stream.Send(&pb.Grant{
+			Recipient:  grants[i].RecipientLogin,
+			Permission: pb.PERMISSION(grants[i].Permission),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func fromPbId(id *pb.UUID) (uuid.UUID, error) {
+	if id == nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	result, err := uuid.FromBytes(id.Value)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return result, nil
+}
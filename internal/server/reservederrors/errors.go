@@ -4,6 +4,39 @@ import "errors"
 
 var ErrUserAlreadyExist = errors.New("user already exist")
 var ErrUserNotFound = errors.New("user not found")
+var ErrUserDisabled = errors.New("user is disabled")
 
 var ErrTokenNotFound = errors.New("token not found")
 var ErrTokenInvalid = errors.New("invalid token")
+
+var ErrInvalidResource = errors.New("invalid resource")
+
+var ErrQuotaExceeded = errors.New("storage quota exceeded")
+var ErrFileTooLarge = errors.New("file too large")
+
+var ErrChecksumMismatch = errors.New("file checksum mismatch")
+
+var ErrRecoveryFailed = errors.New("recovery key doesn't match")
+var ErrWrongPassword = errors.New("password doesn't match")
+
+var ErrKeysAlreadySet = errors.New("user keys are already set")
+var ErrKeysNotFound = errors.New("user keys not found")
+var ErrPermissionDenied = errors.New("permission denied")
+var ErrNotShared = errors.New("resource is not shared")
+
+var ErrNotMember = errors.New("user is not a member of the organization")
+var ErrMemberExists = errors.New("user is already a member of the organization")
+var ErrLastOwner = errors.New("organization must keep at least one owner")
+
+var ErrEmergencyNotFound = errors.New("emergency access not found")
+var ErrEmergencyExists = errors.New("user is already a trusted contact")
+
+var ErrSendNotFound = errors.New("send not found or expired")
+var ErrTooManySends = errors.New("too many active sends")
+
+var ErrUploadNotFound = errors.New("upload session not found")
+var ErrOffsetMismatch = errors.New("chunk offset doesn't match committed offset")
+var ErrChunkTooLarge = errors.New("chunk is larger than negotiated chunk size")
+
+var ErrRevisionConflict = errors.New("resource was changed since the base revision")
+var ErrConflictNotFound = errors.New("conflict not found")
@@ -5,29 +5,52 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	_ "google.golang.org/grpc/encoding/gzip"
 	"net"
 	pb "secstorage/internal/api/proto"
 	. "secstorage/internal/logger"
 	"secstorage/internal/server/interceptors"
 	"secstorage/internal/server/modulservers"
 	"secstorage/internal/server/services"
+	"time"
 )
 
+const shutdownTimeout = 10 * time.Second
+
 func Run(
 	ctx context.Context,
 	authServer *modulservers.AuthServer,
 	resourceServer *modulservers.ResourceServer,
+	shareServer *modulservers.ShareServer,
+	orgServer *modulservers.OrgServer,
+	sendServer *modulservers.SendServer,
+	emergencyServer *modulservers.EmergencyServer,
+	adminServer *modulservers.AdminServer,
+	auditServer *modulservers.AuditServer,
 	tokenService *services.TokenService,
+	auditor interceptors.Auditor,
 	creds credentials.TransportCredentials,
 	listen net.Listener,
 ) {
 	server := grpc.NewServer(
 		grpc.Creds(creds),
-		grpc.UnaryInterceptor(interceptors.TokenInterceptor(tokenService)),
-		grpc.StreamInterceptor(interceptors.TokenStreamInterceptor(tokenService)),
+		grpc.ChainUnaryInterceptor(
+			interceptors.TokenInterceptor(tokenService),
+			interceptors.AuditInterceptor(auditor, tokenService),
+		),
+		grpc.ChainStreamInterceptor(
+			interceptors.TokenStreamInterceptor(tokenService),
+			interceptors.AuditStreamInterceptor(auditor, tokenService),
+		),
 	)
 	pb.RegisterAuthServer(server, authServer)
 	pb.RegisterResourcesServer(server, resourceServer)
+	pb.RegisterSharesServer(server, shareServer)
+	pb.RegisterOrganizationsServer(server, orgServer)
+	pb.RegisterSendsServer(server, sendServer)
+	pb.RegisterEmergencyServer(server, emergencyServer)
+	pb.RegisterAdminServer(server, adminServer)
+	pb.RegisterAuditServer(server, auditServer)
 	Log.Info("server is up")
 
 	go func() {
@@ -38,6 +61,16 @@ func Run(
 
 	<-ctx.Done()
 
-	server.GracefulStop()
+	// watch streams don't end by themselves, so they are cut after the timeout
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		server.Stop()
+	}
 	Log.Info("server stopped")
 }
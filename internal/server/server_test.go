@@ -1,55 +1,121 @@
 package server
 
 import (
+	"compress/gzip"
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"io"
 	"log"
 	"net"
 	"os"
 	"secstorage/internal/api"
 	pb "secstorage/internal/api/proto"
+	"secstorage/internal/server/events"
 	"secstorage/internal/server/modulservers"
 	"secstorage/internal/server/services"
 	"secstorage/internal/server/storage"
+	auditStorage "secstorage/internal/server/storage/audit"
 	authStorage "secstorage/internal/server/storage/auth"
+	emergencyStorage "secstorage/internal/server/storage/emergency"
+	orgStorage "secstorage/internal/server/storage/organization"
 	resourceStorage "secstorage/internal/server/storage/resource"
+	sendStorage "secstorage/internal/server/storage/send"
+	shareStorage "secstorage/internal/server/storage/share"
+	uploadStorage "secstorage/internal/server/storage/upload"
 	"secstorage/internal/server/testutils"
+	"strings"
 	"testing"
+	"time"
 )
 
 var authClient pb.AuthClient
 var resourceClient pb.ResourcesClient
+var shareClient pb.SharesClient
+var orgClient pb.OrganizationsClient
+var sendClient pb.SendsClient
+var emergencyClient pb.EmergencyClient
+var adminClient pb.AdminClient
+var auditClient pb.AuditClient
 var db *sqlx.DB
 
 var TokenService = services.NewTokenService("7+P+BBqjUvY6NF0jGU9JVWurFULGLbDWPWBRVK6MCpvCHkU1aPAA/gm4t0xKTNGxbQdJvUXMa89rGQCur1z5rw==")
+var testQuota = services.Quota{MaxItems: 10, MaxBytes: 1024 * 1024, MaxFileSize: 64 * 1024}
 var testResource = &pb.Resource{
 	Type: 1,
-	Data: []byte("data"),
+	Data: []byte(`{"login":"login","password":"password"}`),
 	Meta: []byte("meta"),
 }
 
 func initServerAndClient(db *sqlx.DB) {
+	con := startServer(db, testQuota)
+	authClient = pb.NewAuthClient(con)
+	resourceClient = pb.NewResourcesClient(con)
+	shareClient = pb.NewSharesClient(con)
+	orgClient = pb.NewOrganizationsClient(con)
+	sendClient = pb.NewSendsClient(con)
+	emergencyClient = pb.NewEmergencyClient(con)
+	adminClient = pb.NewAdminClient(con)
+	auditClient = pb.NewAuditClient(con)
+}
+
+// startServer runs the server with the quota on an in-memory listener and returns the connection to it.
+func startServer(db *sqlx.DB, quota services.Quota) *grpc.ClientConn {
 	buffer := 101024 * 1024
 	lis := bufconn.Listen(buffer)
 
 	authStore := authStorage.NewStorage(context.Background(), db)
+	TokenService.SetSessionStore(authStore)
 	authService := services.NewAuthService(authStore)
 	authServer := modulservers.NewAuthServer(authService, TokenService)
 
 	resourceStore := resourceStorage.NewStore(context.Background(), db)
-	resourceService := services.NewResourceStoreService(resourceStore, "./")
+	uploadStore := uploadStorage.NewStorage(context.Background(), db)
+	shareStore := shareStorage.NewStorage(context.Background(), db)
+	orgStore := orgStorage.NewStorage(context.Background(), db)
+	emergencyStore := emergencyStorage.NewStorage(context.Background(), db)
+	changes := events.NewBroker()
+	resourceService := services.NewResourceStoreService(resourceStore, uploadStore, shareStore, orgStore, emergencyStore, changes, services.FileStoreConfig{Path: "./", Compress: true}, quota)
 	resourceServer := modulservers.NewResourcesServer(resourceService)
+	shareServer := modulservers.NewShareServer(services.NewShareService(authStore, shareStore, resourceStore, changes))
+	orgServer := modulservers.NewOrgServer(services.NewOrgService(orgStore))
+	sendServer := modulservers.NewSendServer(services.NewSendService(sendStorage.NewStorage(context.Background(), db)))
+	emergencyServer := modulservers.NewEmergencyServer(services.NewEmergencyService(emergencyStore))
+	auditService := services.NewAuditService(auditStorage.NewStorage(context.Background(), db))
+	adminServer := modulservers.NewAdminServer(services.NewAdminService(authStore, resourceService, auditService))
+	auditServer := modulservers.NewAuditServer(auditService)
 
-	go Run(context.Background(), authServer, resourceServer, TokenService, insecure.NewCredentials(), lis)
+	go Run(
+		context.Background(),
+		authServer,
+		resourceServer,
+		shareServer,
+		orgServer,
+		sendServer,
+		emergencyServer,
+		adminServer,
+		auditServer,
+		TokenService,
+		auditService,
+		insecure.NewCredentials(),
+		lis,
+	)
 
 	con, err := grpc.DialContext(context.Background(), "",
 		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
@@ -67,8 +133,7 @@ func initServerAndClient(db *sqlx.DB) {
 		}
 	}()
 
-	authClient = pb.NewAuthClient(con)
-	resourceClient = pb.NewResourcesClient(con)
+	return con
 }
 
 func TestMain(m *testing.M) {
@@ -124,6 +189,53 @@ func TestAuthServer_Login_UserNotFound(t *testing.T) {
 	assert.ErrorIs(t, err, status.Error(codes.NotFound, "user not found"))
 }
 
+func TestAuthServer_Recover(t *testing.T) {
+	prepare()
+
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	keyPair := &pb.KeyPair{PublicKey: make([]byte, 32), EncryptedPrivateKey: []byte("sealed")}
+	_, err = shareClient.SetKeys(ctx, keyPair)
+	assert.NoError(t, err)
+
+	recoveryAuth := bytes32(1)
+	_, err = authClient.SetRecovery(ctx, &pb.RecoverySetup{RecoveryAuth: recoveryAuth[:8], RecoveryPrivateKey: []byte("recovery"), Password: "password"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = authClient.SetRecovery(ctx, &pb.RecoverySetup{RecoveryAuth: recoveryAuth, RecoveryPrivateKey: []byte("recovery")})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = authClient.SetRecovery(ctx, &pb.RecoverySetup{RecoveryAuth: recoveryAuth, RecoveryPrivateKey: []byte("recovery"), Password: "password"})
+	assert.NoError(t, err)
+
+	_, err = authClient.Recover(context.Background(), &pb.RecoverRequest{Login: "login", RecoveryAuth: make([]byte, 32), NewPassword: "new"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	recovered, err := authClient.Recover(context.Background(), &pb.RecoverRequest{Login: "login", RecoveryAuth: recoveryAuth, NewPassword: "new"})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("recovery"), recovered.RecoveryPrivateKey)
+	_, err = shareClient.GetKeys(ctx, &emptypb.Empty{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = authClient.Login(context.Background(), testAuthData)
+	assert.Error(t, err)
+	_, err = authClient.Login(context.Background(), &pb.AuthData{Login: "login", Password: "new"})
+	assert.NoError(t, err)
+
+	recoveredCtx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": recovered.Token.Token}))
+	_, err = shareClient.UpdateKeys(recoveredCtx, &pb.KeyPair{PublicKey: bytes32(1), EncryptedPrivateKey: []byte("resealed")})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = shareClient.UpdateKeys(recoveredCtx, &pb.KeyPair{PublicKey: keyPair.PublicKey, EncryptedPrivateKey: []byte("resealed")})
+	assert.NoError(t, err)
+	keys, err := shareClient.GetKeys(recoveredCtx, &emptypb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("resealed"), keys.EncryptedPrivateKey)
+}
+
+func bytes32(first byte) []byte {
+	result := make([]byte, 32)
+	result[0] = first
+	return result
+}
+
 func TestResourceServer_Save_and_Get_Success(t *testing.T) {
 	prepare()
 
@@ -133,7 +245,7 @@ func TestResourceServer_Save_and_Get_Success(t *testing.T) {
 	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
 	resource := &pb.Resource{
 		Type: 1,
-		Data: []byte("data"),
+		Data: []byte(`{"login":"login","password":"password"}`),
 		Meta: []byte("meta"),
 	}
 	id, err := resourceClient.Save(ctx, resource)
@@ -145,6 +257,206 @@ func TestResourceServer_Save_and_Get_Success(t *testing.T) {
 	assert.Equal(t, resource.Type, result.Type)
 	assert.Equal(t, resource.Data, result.Data)
 	assert.Equal(t, resource.Meta, result.Meta)
+	assert.Equal(t, "password", result.GetLoginPassword().Password)
+}
+
+func TestResourceServer_Save_TypedPayload(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	card := &pb.BankCardData{Number: "4111 1111 1111 1111", Until: "12/30", Name: "name", Surname: "surname"}
+	id, err := resourceClient.Save(ctx, &pb.Resource{
+		Meta:    []byte("meta"),
+		Payload: &pb.Resource_BankCard{BankCard: card},
+	})
+	assert.NoError(t, err)
+
+	result, err := resourceClient.Get(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.TYPE_BANK_CARD, result.Type)
+	assert.Equal(t, card.Number, result.GetBankCard().Number)
+	assert.Equal(t, card.Until, result.GetBankCard().Until)
+	assert.JSONEq(t, `{"number":"4111 1111 1111 1111","until":"12/30","name":"name","surname":"surname"}`, string(result.Data))
+}
+
+func TestResourceServer_Save_SecureNote(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	note := &pb.SecureNoteData{Title: "title", Body: "line 1\nline 2"}
+	id, err := resourceClient.Save(ctx, &pb.Resource{Payload: &pb.Resource_SecureNote{SecureNote: note}})
+	assert.NoError(t, err)
+
+	result, err := resourceClient.Get(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.TYPE_SECURE_NOTE, result.Type)
+	assert.Equal(t, note.Title, result.GetSecureNote().Title)
+	assert.Equal(t, note.Body, result.GetSecureNote().Body)
+}
+
+func TestResourceServer_Save_OTP(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	key := &pb.OTPData{Secret: "HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ", Issuer: "ACME", Account: "john", Digits: 6, Period: 30, Algorithm: "SHA1"}
+	id, err := resourceClient.Save(ctx, &pb.Resource{Payload: &pb.Resource_Otp{Otp: key}})
+	assert.NoError(t, err)
+
+	result, err := resourceClient.Get(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.TYPE_OTP, result.Type)
+	assert.Equal(t, key.Secret, result.GetOtp().Secret)
+	assert.Equal(t, key.Issuer, result.GetOtp().Issuer)
+}
+
+func TestResourceServer_Save_SSHKey(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	assert.NoError(t, err)
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	assert.NoError(t, err)
+	key := &pb.SSHKeyData{
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		PublicKey:  string(ssh.MarshalAuthorizedKey(sshPublicKey)),
+		Comment:    "john@host",
+	}
+	id, err := resourceClient.Save(ctx, &pb.Resource{Payload: &pb.Resource_SshKey{SshKey: key}})
+	assert.NoError(t, err)
+
+	result, err := resourceClient.Get(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.TYPE_SSH_KEY, result.Type)
+	assert.Equal(t, key.PrivateKey, result.GetSshKey().PrivateKey)
+	assert.Equal(t, key.Comment, result.GetSshKey().Comment)
+
+	otherPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	sshOtherPublicKey, err := ssh.NewPublicKey(otherPublicKey)
+	assert.NoError(t, err)
+	key.PublicKey = string(ssh.MarshalAuthorizedKey(sshOtherPublicKey))
+	_, err = resourceClient.Save(ctx, &pb.Resource{Payload: &pb.Resource_SshKey{SshKey: key}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestResourceServer_Save_LoginPasswordExtras(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	lp := &pb.LoginPasswordData{
+		Login:    "login",
+		Password: "password",
+		Urls:     []string{"https://example.com/login", "https://m.example.com"},
+		Notes:    "security question: pet name",
+		Totp:     &pb.OTPData{Secret: "HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ", Digits: 6, Period: 30, Algorithm: "SHA1"},
+	}
+	id, err := resourceClient.Save(ctx, &pb.Resource{Payload: &pb.Resource_LoginPassword{LoginPassword: lp}})
+	assert.NoError(t, err)
+
+	result, err := resourceClient.Get(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, lp.Urls, result.GetLoginPassword().Urls)
+	assert.Equal(t, lp.Notes, result.GetLoginPassword().Notes)
+	assert.Equal(t, lp.Totp.Secret, result.GetLoginPassword().Totp.Secret)
+	assert.NotNil(t, result.GetLoginPassword().PasswordChangedAt)
+
+	// clients reading json data know only login and password
+	var legacy struct {
+		Login    string `json:"login"`
+		Password string `json:"password"`
+	}
+	assert.NoError(t, json.Unmarshal(result.Data, &legacy))
+	assert.Equal(t, "login", legacy.Login)
+	assert.Equal(t, "password", legacy.Password)
+
+	lp.Urls = []string{"example.com"}
+	_, err = resourceClient.Save(ctx, &pb.Resource{Payload: &pb.Resource_LoginPassword{LoginPassword: lp}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestResourceServer_Save_CustomFields(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	fields := []*pb.CustomField{
+		{Name: "host", Type: pb.FIELD_TYPE_TEXT, Value: "db.example.com"},
+		{Name: "port", Type: pb.FIELD_TYPE_TEXT, Value: "5432"},
+		{Name: "db", Type: pb.FIELD_TYPE_TEXT},
+	}
+	templateId, err := resourceClient.Save(ctx, &pb.Resource{
+		Payload: &pb.Resource_Template{Template: &pb.TemplateData{Name: "Database credential", ResourceType: pb.TYPE_LOGIN_PASSWORD}},
+		Fields:  fields,
+	})
+	assert.NoError(t, err)
+
+	template, err := resourceClient.Get(ctx, templateId)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.TYPE_TEMPLATE, template.Type)
+	assert.Equal(t, "Database credential", template.GetTemplate().Name)
+	assert.Equal(t, pb.TYPE_LOGIN_PASSWORD, template.GetTemplate().ResourceType)
+	assert.Len(t, template.Fields, 3)
+
+	id, err := resourceClient.Save(ctx, &pb.Resource{
+		Payload: &pb.Resource_LoginPassword{LoginPassword: &pb.LoginPasswordData{Login: "login", Password: "password"}},
+		Fields: []*pb.CustomField{
+			{Name: "host", Type: pb.FIELD_TYPE_TEXT, Value: "db.example.com"},
+			{Name: "admin", Type: pb.FIELD_TYPE_URL, Value: "https://db.example.com/admin"},
+			{Name: "pin", Type: pb.FIELD_TYPE_HIDDEN, Value: "1234"},
+			{Name: "rotated", Type: pb.FIELD_TYPE_DATE, Value: "2022-10-01"},
+		},
+	})
+	assert.NoError(t, err)
+
+	result, err := resourceClient.Get(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, "login", result.GetLoginPassword().Login)
+	assert.Len(t, result.Fields, 4)
+	assert.Equal(t, pb.FIELD_TYPE_HIDDEN, result.Fields[2].Type)
+	assert.Equal(t, "1234", result.Fields[2].Value)
+}
+
+func TestResourceServer_Save_InvalidPayload(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	lp := &pb.Resource_LoginPassword{LoginPassword: &pb.LoginPasswordData{Login: "login", Password: "password"}}
+	invalidResources := []*pb.Resource{
+		{Payload: &pb.Resource_BankCard{BankCard: &pb.BankCardData{Number: "4111 1111 1111 1112", Until: "12/30"}}},
+		{Payload: &pb.Resource_BankCard{BankCard: &pb.BankCardData{Number: "4111 1111 1111 1111", Until: "13/30"}}},
+		{Type: pb.TYPE_LOGIN_PASSWORD, Payload: &pb.Resource_BankCard{BankCard: &pb.BankCardData{Number: "4111 1111 1111 1111", Until: "12/30"}}},
+		{Type: pb.TYPE_LOGIN_PASSWORD, Data: []byte("not json")},
+		{Type: pb.TYPE_FILE, Data: []byte("/etc/passwd")},
+		{Payload: &pb.Resource_SecureNote{SecureNote: &pb.SecureNoteData{Title: " ", Body: "body"}}},
+		{Payload: &pb.Resource_Otp{Otp: &pb.OTPData{Secret: "not base32!", Digits: 6, Period: 30, Algorithm: "SHA1"}}},
+		{Payload: &pb.Resource_Otp{Otp: &pb.OTPData{Secret: "HXDMVJECJJWSRB3HWIZR4IFUGFTMXBOZ", Digits: 6, Period: 30, Algorithm: "MD5"}}},
+		{Payload: &pb.Resource_SshKey{SshKey: &pb.SSHKeyData{PrivateKey: "not a key"}}},
+		{Payload: lp, Fields: []*pb.CustomField{{Name: "site", Type: pb.FIELD_TYPE_URL, Value: "not a url"}}},
+		{Payload: lp, Fields: []*pb.CustomField{{Name: "expires", Type: pb.FIELD_TYPE_DATE, Value: "31.12.2030"}}},
+		{Payload: lp, Fields: []*pb.CustomField{{Name: "host", Value: "a"}, {Name: "host", Value: "b"}}},
+		{Payload: lp, Fields: []*pb.CustomField{{Name: " ", Value: "a"}}},
+		{Payload: &pb.Resource_Template{Template: &pb.TemplateData{Name: "files", ResourceType: pb.TYPE_FILE}}},
+	}
+	for _, resource := range invalidResources {
+		_, err := resourceClient.Save(ctx, resource)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
 }
 
 func TestResourceServer_List_And_Delete_Success(t *testing.T) {
@@ -203,6 +515,9 @@ func TestResourceServer_List_And_Delete_Success(t *testing.T) {
 	err = db.GetContext(ctx, &c, "select count(*) from resources where id = $1", rId1)
 	assert.NoError(t, err)
 	assert.Equal(t, 0, c)
+
+	_, err = resourceClient.Get(ctx, &pb.UUID{Value: rId1[:]})
+	assert.Equal(t, codes.NotFound, status.Code(err))
 }
 
 func TestResourceServer_SaveAndGetAndDeleteFile(t *testing.T) {
@@ -236,7 +551,7 @@ func TestResourceServer_SaveAndGetAndDeleteFile(t *testing.T) {
 	id, err := sendStream.CloseAndRecv()
 	assert.NoError(t, err)
 
-	getStream, err := resourceClient.GetFile(ctx, id)
+	getStream, err := resourceClient.GetFile(ctx, &pb.FileRequest{Id: id})
 	assert.NoError(t, err)
 	received := make([]byte, 0)
 	for {
@@ -252,8 +567,1085 @@ func TestResourceServer_SaveAndGetAndDeleteFile(t *testing.T) {
 	_, err = resourceClient.Delete(ctx, id)
 	assert.NoError(t, err)
 
-	getStream, err = resourceClient.GetFile(ctx, id)
+	getStream, err = resourceClient.GetFile(ctx, &pb.FileRequest{Id: id})
 	assert.NoError(t, err)
 	_, err = getStream.Recv()
-	assert.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestResourceServer_SaveFile_TooLarge(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	sendStream, err := resourceClient.SaveFile(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, sendStream.Send(&pb.FileChunk{Meta: []byte("meta")}))
+
+	chunk := make([]byte, 4096)
+	for i := 0; i <= int(testQuota.MaxFileSize)/len(chunk); i++ {
+		if err := sendStream.Send(&pb.FileChunk{Data: chunk}); err != nil {
+			break
+		}
+	}
+	_, err = sendStream.CloseAndRecv()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	var c int
+	assert.NoError(t, db.GetContext(ctx, &c, "select count(*) from resources"))
+	assert.Equal(t, 0, c)
+}
+
+func TestResourceServer_Save_ItemsQuotaExceeded(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	for i := 0; i < int(testQuota.MaxItems); i++ {
+		_, err := resourceClient.Save(ctx, testResource)
+		assert.NoError(t, err)
+	}
+	_, err = resourceClient.Save(ctx, testResource)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	usage, err := resourceClient.Usage(ctx, &emptypb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, testQuota.MaxItems, usage.Items)
+	assert.Equal(t, testQuota.MaxItems*int64(len(testResource.Data)+len(testResource.Meta)), usage.Bytes)
+	assert.Equal(t, testQuota.MaxItems, usage.MaxItems)
+}
+
+func TestResourceServer_Save_ConcurrentQuota(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	results := make(chan error)
+	for i := 0; i < 2*int(testQuota.MaxItems); i++ {
+		go func() {
+			_, err := resourceClient.Save(ctx, testResource)
+			results <- err
+		}()
+	}
+	var saved int64
+	for i := 0; i < 2*int(testQuota.MaxItems); i++ {
+		if err := <-results; err == nil {
+			saved++
+		} else {
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		}
+	}
+	assert.Equal(t, testQuota.MaxItems, saved)
+}
+
+func TestResourceServer_GetFile_ChecksumMismatch(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	sendStream, err := resourceClient.SaveFile(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, sendStream.Send(&pb.FileChunk{Meta: []byte("meta")}))
+	assert.NoError(t, sendStream.Send(&pb.FileChunk{Data: []byte("data")}))
+	id, err := sendStream.CloseAndRecv()
+	assert.NoError(t, err)
+
+	rId, err := uuid.FromBytes(id.Value)
+	assert.NoError(t, err)
+	var path string
+	assert.NoError(t, db.GetContext(ctx, &path, "select data from resources where id = $1", rId))
+	file, err := os.Create(path)
+	assert.NoError(t, err)
+	gzipWriter := gzip.NewWriter(file)
+	_, err = gzipWriter.Write([]byte("corrupted"))
+	assert.NoError(t, err)
+	assert.NoError(t, gzipWriter.Close())
+	assert.NoError(t, file.Close())
+
+	getStream, err := resourceClient.GetFile(ctx, &pb.FileRequest{Id: id})
+	assert.NoError(t, err)
+	first, err := getStream.Recv()
+	assert.NoError(t, err)
+	expected := sha256.Sum256([]byte("data"))
+	assert.Equal(t, expected[:], first.Checksum)
+	for err == nil {
+		_, err = getStream.Recv()
+	}
+	assert.Equal(t, codes.DataLoss, status.Code(err))
+
+	_, err = resourceClient.Delete(ctx, id)
+	assert.NoError(t, err)
+}
+
+func TestResourceServer_ResumableUploadAndRangeDownload(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	session, err := resourceClient.InitUpload(ctx, &pb.UploadInit{Meta: []byte("meta")})
+	assert.NoError(t, err)
+
+	part, err := resourceClient.UploadChunk(ctx, &pb.FilePart{SessionId: session.Id, Offset: 0, Data: []byte("data_p1")})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), part.Offset)
+
+	_, err = resourceClient.UploadChunk(ctx, &pb.FilePart{SessionId: session.Id, Offset: 0, Data: []byte("data_p1")})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	committed, err := resourceClient.GetUploadOffset(ctx, session.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), committed.Offset)
+
+	_, err = resourceClient.UploadChunk(ctx, &pb.FilePart{SessionId: session.Id, Offset: committed.Offset, Data: []byte("data_p2")})
+	assert.NoError(t, err)
+
+	id, err := resourceClient.CompleteUpload(ctx, session.Id)
+	assert.NoError(t, err)
+
+	getStream, err := resourceClient.GetFile(ctx, &pb.FileRequest{Id: id, Offset: 5, Length: 6})
+	assert.NoError(t, err)
+	received := make([]byte, 0)
+	for {
+		chunk, err := getStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		received = append(received, chunk.Data...)
+	}
+	assert.Equal(t, "p1data", string(received))
+
+	_, err = resourceClient.GetUploadOffset(ctx, session.Id)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = resourceClient.Delete(ctx, id)
+	assert.NoError(t, err)
+}
+
+func TestResourceServer_CompleteUpload_QuotaOfOpenSessions(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	sessions := make([]*pb.UUID, 0, testQuota.MaxItems+1)
+	for i := 0; i <= int(testQuota.MaxItems); i++ {
+		session, err := resourceClient.InitUpload(ctx, &pb.UploadInit{Meta: []byte("meta")})
+		assert.NoError(t, err)
+		_, err = resourceClient.UploadChunk(ctx, &pb.FilePart{SessionId: session.Id, Offset: 0, Data: []byte{byte(i)}})
+		assert.NoError(t, err)
+		sessions = append(sessions, session.Id)
+	}
+	for i := 0; i < int(testQuota.MaxItems); i++ {
+		_, err := resourceClient.CompleteUpload(ctx, sessions[i])
+		assert.NoError(t, err)
+	}
+	_, err = resourceClient.CompleteUpload(ctx, sessions[testQuota.MaxItems])
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestResourceServer_UploadChunk_QuotaOfOpenSessions(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	chunk := make([]byte, testQuota.MaxFileSize)
+	for i := int64(0); i < testQuota.MaxBytes/testQuota.MaxFileSize; i++ {
+		session, err := resourceClient.InitUpload(ctx, &pb.UploadInit{})
+		assert.NoError(t, err)
+		_, err = resourceClient.UploadChunk(ctx, &pb.FilePart{SessionId: session.Id, Offset: 0, Data: chunk})
+		assert.NoError(t, err)
+	}
+	session, err := resourceClient.InitUpload(ctx, &pb.UploadInit{})
+	assert.NoError(t, err)
+	_, err = resourceClient.UploadChunk(ctx, &pb.FilePart{SessionId: session.Id, Offset: 0, Data: []byte("over")})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	sendStream, err := resourceClient.SaveFile(ctx)
+	assert.NoError(t, err)
+	_ = sendStream.Send(&pb.FileChunk{Meta: []byte("meta")})
+	_ = sendStream.Send(&pb.FileChunk{Data: []byte("over")})
+	_, err = sendStream.CloseAndRecv()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func saveTestFile(t *testing.T, ctx context.Context, data []byte) *pb.UUID {
+	sendStream, err := resourceClient.SaveFile(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, sendStream.Send(&pb.FileChunk{Meta: []byte("meta")}))
+	assert.NoError(t, sendStream.Send(&pb.FileChunk{Data: data}))
+	id, err := sendStream.CloseAndRecv()
+	assert.NoError(t, err)
+	return id
+}
+
+func TestResourceServer_SaveFile_DeduplicatesBlobs(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	id1 := saveTestFile(t, ctx, []byte("same data"))
+	id2 := saveTestFile(t, ctx, []byte("same data"))
+
+	var blob struct {
+		Path     string `db:"path"`
+		RefCount int    `db:"ref_count"`
+	}
+	assert.NoError(t, db.GetContext(ctx, &blob, "select path, ref_count from blobs"))
+	assert.Equal(t, 2, blob.RefCount)
+
+	_, err = resourceClient.Delete(ctx, id1)
+	assert.NoError(t, err)
+	assert.NoError(t, db.GetContext(ctx, &blob, "select path, ref_count from blobs"))
+	assert.Equal(t, 1, blob.RefCount)
+	assert.FileExists(t, blob.Path)
+
+	_, err = resourceClient.Delete(ctx, id2)
+	assert.NoError(t, err)
+	var c int
+	assert.NoError(t, db.GetContext(ctx, &c, "select count(*) from blobs"))
+	assert.Equal(t, 0, c)
+	assert.NoFileExists(t, blob.Path)
+}
+
+func TestResourceServer_DeleteFileStoredBeforeDeduplication(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+	userId, err := TokenService.Extract(token.Token)
+	assert.NoError(t, err)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	data := []byte("same data")
+	legacyId := uuid.New()
+	legacyPath := "./" + legacyId.String()
+	assert.NoError(t, os.WriteFile(legacyPath, data, 0600))
+	checksum := sha256.Sum256(data)
+	_, err = db.ExecContext(
+		ctx,
+		"insert into resources(id, user_id, type, data, meta, size, checksum) values ($1, $2, $3, $4, $5, $6, $7)",
+		legacyId,
+		userId,
+		api.File,
+		[]byte(legacyPath),
+		[]byte("meta"),
+		len(data)+len("meta"),
+		checksum[:],
+	)
+	assert.NoError(t, err)
+	id := saveTestFile(t, ctx, data)
+
+	getStream, err := resourceClient.GetFile(ctx, &pb.FileRequest{Id: &pb.UUID{Value: legacyId[:]}})
+	assert.NoError(t, err)
+	received := make([]byte, 0)
+	for {
+		chunk, err := getStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		received = append(received, chunk.Data...)
+	}
+	assert.Equal(t, data, received)
+
+	_, err = resourceClient.Delete(ctx, &pb.UUID{Value: legacyId[:]})
+	assert.NoError(t, err)
+	assert.NoFileExists(t, legacyPath)
+	var blob struct {
+		Path     string `db:"path"`
+		RefCount int    `db:"ref_count"`
+	}
+	assert.NoError(t, db.GetContext(ctx, &blob, "select path, ref_count from blobs"))
+	assert.Equal(t, 1, blob.RefCount)
+	assert.FileExists(t, blob.Path)
+
+	_, err = resourceClient.Delete(ctx, id)
+	assert.NoError(t, err)
+}
+
+func TestResourceServer_DeleteFileWithMissingBlob(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	id := saveTestFile(t, ctx, []byte("missing data"))
+	var path string
+	assert.NoError(t, db.GetContext(ctx, &path, "select path from blobs"))
+	assert.NoError(t, os.Remove(path))
+
+	_, err = resourceClient.Delete(ctx, id)
+	assert.NoError(t, err)
+	_, err = resourceClient.Get(ctx, id)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestResourceServer_Attachments(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	parentId, err := resourceClient.Save(ctx, &pb.Resource{
+		Payload: &pb.Resource_LoginPassword{LoginPassword: &pb.LoginPasswordData{Login: "login", Password: "password"}},
+	})
+	assert.NoError(t, err)
+
+	session, err := resourceClient.InitUpload(ctx, &pb.UploadInit{Meta: []byte("recovery codes"), ParentId: parentId})
+	assert.NoError(t, err)
+	_, err = resourceClient.UploadChunk(ctx, &pb.FilePart{SessionId: session.Id, Offset: 0, Data: []byte("codes")})
+	assert.NoError(t, err)
+	attachmentId, err := resourceClient.CompleteUpload(ctx, session.Id)
+	assert.NoError(t, err)
+
+	sendStream, err := resourceClient.SaveFile(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, sendStream.Send(&pb.FileChunk{Meta: []byte("scan"), ParentId: parentId}))
+	assert.NoError(t, sendStream.Send(&pb.FileChunk{Data: []byte("scan data")}))
+	_, err = sendStream.CloseAndRecv()
+	assert.NoError(t, err)
+
+	parent, err := resourceClient.Get(ctx, parentId)
+	assert.NoError(t, err)
+	assert.Len(t, parent.Attachments, 2)
+
+	listStream, err := resourceClient.ListByUserId(ctx, &pb.Query{ResourceType: pb.TYPE_FILE})
+	assert.NoError(t, err)
+	_, err = listStream.Recv()
+	assert.Equal(t, io.EOF, err)
+
+	_, err = resourceClient.InitUpload(ctx, &pb.UploadInit{Meta: []byte("nested"), ParentId: attachmentId})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	var paths []string
+	aId, err := uuid.FromBytes(attachmentId.Value)
+	assert.NoError(t, err)
+	var kept string
+	assert.NoError(t, db.GetContext(ctx, &kept, "select data from resources where id = $1", aId))
+	assert.NoError(t, db.SelectContext(ctx, &paths, "select path from blobs where path <> $1", kept))
+	assert.Len(t, paths, 1)
+	// the same content saved as a standalone file keeps the blob of the attachment
+	standaloneId := saveTestFile(t, ctx, []byte("codes"))
+
+	_, err = resourceClient.Delete(ctx, parentId)
+	assert.NoError(t, err)
+	_, err = resourceClient.Get(ctx, attachmentId)
+	assert.Error(t, err)
+	var c int
+	assert.NoError(t, db.GetContext(ctx, &c, "select count(*) from resources"))
+	assert.Equal(t, 1, c)
+	for _, path := range paths {
+		assert.NoFileExists(t, path)
+	}
+	assert.FileExists(t, kept)
+
+	_, err = resourceClient.Delete(ctx, standaloneId)
+	assert.NoError(t, err)
+	assert.NoFileExists(t, kept)
+}
+
+func TestShareServer_ShareResource(t *testing.T) {
+	prepare()
+	ownerToken, err := authClient.Register(context.Background(), &pb.AuthData{Login: "owner", Password: "password"})
+	assert.NoError(t, err)
+	recipientToken, err := authClient.Register(context.Background(), &pb.AuthData{Login: "recipient", Password: "password"})
+	assert.NoError(t, err)
+	ownerCtx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": ownerToken.Token}))
+	recipientCtx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": recipientToken.Token}))
+
+	keyPair := &pb.KeyPair{PublicKey: make([]byte, 32), EncryptedPrivateKey: []byte("sealed")}
+	_, err = shareClient.SetKeys(ownerCtx, keyPair)
+	assert.NoError(t, err)
+	_, err = shareClient.SetKeys(ownerCtx, keyPair)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, err = shareClient.SetKeys(recipientCtx, keyPair)
+	assert.NoError(t, err)
+
+	id, err := resourceClient.Save(ownerCtx, testResource)
+	assert.NoError(t, err)
+	share := &pb.ShareRequest{
+		ResourceId: id,
+		Recipient:  "recipient",
+		Permission: pb.PERMISSION_READ,
+		WrappedKey: []byte("wrapped"),
+		OwnerKey:   []byte("owner key"),
+		Data:       []byte("encrypted"),
+	}
+	_, err = shareClient.Share(recipientCtx, share)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = shareClient.Share(ownerCtx, share)
+	assert.NoError(t, err)
+
+	listStream, err := resourceClient.ListByUserId(recipientCtx, &pb.Query{ResourceType: pb.TYPE_LOGIN_PASSWORD})
+	assert.NoError(t, err)
+	info, err := listStream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, id.Value, info.Id.Value)
+	assert.True(t, info.Shared)
+
+	shared, err := resourceClient.Get(recipientCtx, id)
+	assert.NoError(t, err)
+	assert.Nil(t, shared.Payload)
+	assert.Empty(t, shared.Data)
+	assert.Equal(t, "owner", shared.Shared.Owner)
+	assert.Equal(t, []byte("wrapped"), shared.Shared.WrappedKey)
+	assert.Equal(t, []byte("encrypted"), shared.Shared.Data)
+
+	_, err = shareClient.UpdateShared(recipientCtx, &pb.SharedUpdate{ResourceId: id, Data: []byte("changed")})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	share.Permission = pb.PERMISSION_READ_WRITE
+	_, err = shareClient.Share(ownerCtx, share)
+	assert.NoError(t, err)
+	_, err = shareClient.UpdateShared(recipientCtx, &pb.SharedUpdate{ResourceId: id, Data: []byte("changed")})
+	assert.NoError(t, err)
+
+	own, err := resourceClient.Get(ownerCtx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("owner key"), own.Shared.WrappedKey)
+	assert.Equal(t, []byte("changed"), own.Shared.Data)
+
+	update := &pb.UpdateRequest{Id: id, Resource: testResource, BaseRevision: own.Revision}
+	_, err = resourceClient.Update(ownerCtx, update)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	update.SharedData = []byte("updated")
+	_, err = resourceClient.Update(ownerCtx, update)
+	assert.NoError(t, err)
+	shared, err = resourceClient.Get(recipientCtx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("updated"), shared.Shared.Data)
+
+	grantStream, err := shareClient.ListGrants(ownerCtx, id)
+	assert.NoError(t, err)
+	grant, err := grantStream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, "recipient", grant.Recipient)
+	assert.Equal(t, pb.PERMISSION_READ_WRITE, grant.Permission)
+
+	_, err = resourceClient.Delete(recipientCtx, id)
+	assert.NoError(t, err)
+	_, err = resourceClient.Get(recipientCtx, id)
+	assert.Error(t, err)
+	_, err = resourceClient.Get(ownerCtx, id)
+	assert.NoError(t, err)
+}
+
+func TestOrgServer_CollectionAccess(t *testing.T) {
+	prepare()
+	ctxs := map[string]context.Context{}
+	for _, login := range []string{"owner", "member", "reader", "stranger"} {
+		token, err := authClient.Register(context.Background(), &pb.AuthData{Login: login, Password: "password"})
+		assert.NoError(t, err)
+		ctxs[login] = metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	}
+
+	org, err := orgClient.Create(ctxs["owner"], &pb.OrganizationRequest{Name: "team"})
+	assert.NoError(t, err)
+	collection, err := orgClient.CreateCollection(ctxs["owner"], &pb.CollectionRequest{OrgId: org.Id, Name: "prod"})
+	assert.NoError(t, err)
+	_, err = orgClient.Invite(ctxs["owner"], &pb.MemberRequest{OrgId: org.Id, Login: "member", Role: pb.ROLE_MEMBER})
+	assert.NoError(t, err)
+	_, err = orgClient.Invite(ctxs["owner"], &pb.MemberRequest{OrgId: org.Id, Login: "member", Role: pb.ROLE_MEMBER})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, err = orgClient.Invite(ctxs["owner"], &pb.MemberRequest{OrgId: org.Id, Login: "reader", Role: pb.ROLE_READ_ONLY})
+	assert.NoError(t, err)
+
+	query := &pb.Query{ResourceType: pb.TYPE_LOGIN_PASSWORD, CollectionId: collection.Id}
+	_, err = orgClient.Invite(ctxs["member"], &pb.MemberRequest{OrgId: org.Id, Login: "stranger"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = orgClient.Accept(ctxs["member"], org.Id)
+	assert.NoError(t, err)
+	_, err = orgClient.Accept(ctxs["reader"], org.Id)
+	assert.NoError(t, err)
+	_, err = orgClient.Invite(ctxs["member"], &pb.MemberRequest{OrgId: org.Id, Login: "stranger"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	resource := &pb.Resource{Type: testResource.Type, Data: testResource.Data, Meta: testResource.Meta, CollectionId: collection.Id}
+	_, err = resourceClient.Save(ctxs["reader"], resource)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = resourceClient.Save(ctxs["stranger"], resource)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	id, err := resourceClient.Save(ctxs["member"], resource)
+	assert.NoError(t, err)
+
+	listStream, err := resourceClient.ListByUserId(ctxs["reader"], query)
+	assert.NoError(t, err)
+	info, err := listStream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, id.Value, info.Id.Value)
+	own, err := resourceClient.ListByUserId(ctxs["member"], &pb.Query{ResourceType: pb.TYPE_LOGIN_PASSWORD})
+	assert.NoError(t, err)
+	_, err = own.Recv()
+	assert.ErrorIs(t, err, io.EOF)
+	listStream, err = resourceClient.ListByUserId(ctxs["stranger"], query)
+	assert.NoError(t, err)
+	_, err = listStream.Recv()
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	result, err := resourceClient.Get(ctxs["reader"], id)
+	assert.NoError(t, err)
+	assert.Equal(t, "login", result.GetLoginPassword().Login)
+	assert.Equal(t, collection.Id.Value, result.CollectionId.Value)
+	_, err = resourceClient.Get(ctxs["stranger"], id)
+	assert.Error(t, err)
+	_, err = resourceClient.Delete(ctxs["reader"], id)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = orgClient.RemoveMember(ctxs["owner"], &pb.MemberRequest{OrgId: org.Id, Login: "owner"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = orgClient.RemoveMember(ctxs["owner"], &pb.MemberRequest{OrgId: org.Id, Login: "reader"})
+	assert.NoError(t, err)
+	_, err = resourceClient.Get(ctxs["reader"], id)
+	assert.Error(t, err)
+
+	_, err = resourceClient.Delete(ctxs["owner"], id)
+	assert.NoError(t, err)
+	_, err = resourceClient.Get(ctxs["member"], id)
+	assert.Error(t, err)
+}
+
+func TestSendServer_OpenWithoutToken(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+
+	request := &pb.SendRequest{Data: []byte("encrypted"), MaxViews: 2, ExpiresAt: timestamppb.New(time.Now().Add(time.Hour))}
+	_, err = sendClient.Create(context.Background(), request)
+	assert.Error(t, err)
+	_, err = sendClient.Create(ctx, &pb.SendRequest{Data: request.Data, MaxViews: 0, ExpiresAt: request.ExpiresAt})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = sendClient.Create(ctx, &pb.SendRequest{Data: request.Data, MaxViews: 1, ExpiresAt: timestamppb.New(time.Now().Add(-time.Minute))})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	id, err := sendClient.Create(ctx, request)
+	assert.NoError(t, err)
+
+	data, err := sendClient.Open(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("encrypted"), data.Data)
+	assert.Equal(t, int32(1), data.ViewsLeft)
+
+	listStream, err := sendClient.List(ctx, &emptypb.Empty{})
+	assert.NoError(t, err)
+	info, err := listStream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), info.Views)
+
+	data, err = sendClient.Open(context.Background(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), data.ViewsLeft)
+	_, err = sendClient.Open(context.Background(), id)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestSendServer_ActiveSendsLimit(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+
+	request := &pb.SendRequest{Data: []byte("encrypted"), MaxViews: 1, ExpiresAt: timestamppb.New(time.Now().Add(time.Hour))}
+	var id *pb.UUID
+	for i := 0; i < 20; i++ {
+		id, err = sendClient.Create(ctx, request)
+		assert.NoError(t, err)
+	}
+	_, err = sendClient.Create(ctx, request)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = sendClient.Open(context.Background(), id)
+	assert.NoError(t, err)
+	_, err = sendClient.Create(ctx, request)
+	assert.NoError(t, err)
+}
+
+func TestEmergencyServer_RequestAndWaitingPeriod(t *testing.T) {
+	prepare()
+	grantorToken, err := authClient.Register(context.Background(), &pb.AuthData{Login: "grantor", Password: "password"})
+	assert.NoError(t, err)
+	trusteeToken, err := authClient.Register(context.Background(), &pb.AuthData{Login: "trustee", Password: "password"})
+	assert.NoError(t, err)
+	grantorCtx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": grantorToken.Token}))
+	trusteeCtx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": trusteeToken.Token}))
+
+	resourceId, err := resourceClient.Save(grantorCtx, testResource)
+	assert.NoError(t, err)
+	_, err = emergencyClient.Add(grantorCtx, &pb.TrustedContact{Login: "trustee", WaitHours: 0})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	id, err := emergencyClient.Add(grantorCtx, &pb.TrustedContact{Login: "trustee", WaitHours: 24})
+	assert.NoError(t, err)
+	_, err = emergencyClient.Add(grantorCtx, &pb.TrustedContact{Login: "trustee", WaitHours: 24})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	listStream, err := emergencyClient.List(trusteeCtx, &emptypb.Empty{})
+	assert.NoError(t, err)
+	access, err := listStream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, "grantor", access.Grantor)
+	assert.Equal(t, pb.EMERGENCY_STATUS_IDLE, access.Status)
+
+	query := &pb.Query{ResourceType: pb.TYPE_LOGIN_PASSWORD, OwnerId: access.GrantorId}
+	vault, err := resourceClient.ListByUserId(trusteeCtx, query)
+	assert.NoError(t, err)
+	_, err = vault.Recv()
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = resourceClient.Get(trusteeCtx, resourceId)
+	assert.Error(t, err)
+
+	_, err = emergencyClient.Approve(grantorCtx, id)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = emergencyClient.Request(trusteeCtx, id)
+	assert.NoError(t, err)
+	_, err = resourceClient.Get(trusteeCtx, resourceId)
+	assert.Error(t, err)
+
+	// the waiting period is over
+	db.MustExec("update emergency_access set requested_at = now() - interval '25 hours'")
+	vault, err = resourceClient.ListByUserId(trusteeCtx, query)
+	assert.NoError(t, err)
+	info, err := vault.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, resourceId.Value, info.Id.Value)
+	result, err := resourceClient.Get(trusteeCtx, resourceId)
+	assert.NoError(t, err)
+	assert.Equal(t, "password", result.GetLoginPassword().Password)
+	_, err = resourceClient.Delete(trusteeCtx, resourceId)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = emergencyClient.Reject(trusteeCtx, id)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = emergencyClient.Reject(grantorCtx, id)
+	assert.NoError(t, err)
+	_, err = resourceClient.Get(trusteeCtx, resourceId)
+	assert.Error(t, err)
+}
+
+func TestAdminServer_DisableAndLogout(t *testing.T) {
+	prepare()
+	adminToken, err := authClient.Register(context.Background(), &pb.AuthData{Login: "admin", Password: "password"})
+	assert.NoError(t, err)
+	userToken, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+	adminCtx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": adminToken.Token}))
+	userCtx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": userToken.Token}))
+	_, err = resourceClient.Save(userCtx, testResource)
+	assert.NoError(t, err)
+
+	_, err = adminClient.GetUser(adminCtx, &pb.UserQuery{Login: "login"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	db.MustExec("update users set is_admin = true where login = 'admin'")
+
+	user, err := adminClient.GetUser(adminCtx, &pb.UserQuery{Login: "login"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), user.Items)
+	assert.False(t, user.IsAdmin)
+	_, err = adminClient.GetUser(userCtx, &pb.UserQuery{Login: "login"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = adminClient.SetDisabled(adminCtx, &pb.AccountState{Login: "admin", Disabled: true})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = adminClient.SetDisabled(adminCtx, &pb.AccountState{Login: "login", Disabled: true})
+	assert.NoError(t, err)
+	_, err = resourceClient.Usage(userCtx, &emptypb.Empty{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = authClient.Login(context.Background(), testAuthData)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = adminClient.SetDisabled(adminCtx, &pb.AccountState{Login: "login", Disabled: false})
+	assert.NoError(t, err)
+	userToken, err = authClient.Login(context.Background(), testAuthData)
+	assert.NoError(t, err)
+	userCtx = metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": userToken.Token}))
+	_, err = resourceClient.Usage(userCtx, &emptypb.Empty{})
+	assert.NoError(t, err)
+
+	_, err = adminClient.Logout(adminCtx, &pb.UserQuery{Login: "login"})
+	assert.NoError(t, err)
+	_, err = resourceClient.Usage(userCtx, &emptypb.Empty{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	userToken, err = authClient.Login(context.Background(), testAuthData)
+	assert.NoError(t, err)
+	userCtx = metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": userToken.Token}))
+	_, err = resourceClient.Usage(userCtx, &emptypb.Empty{})
+	assert.NoError(t, err)
+}
+
+func TestAdminServer_BlobGC(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+	db.MustExec("update users set is_admin = true")
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+
+	saveTestFile(t, ctx, []byte("referenced"))
+	var referenced string
+	assert.NoError(t, db.GetContext(ctx, &referenced, "select path from blobs"))
+	orphan := "./" + uuid.NewString() + "_" + strings.Repeat("ab", 32)
+	assert.NoError(t, os.WriteFile(orphan, []byte("orphan"), 0600))
+	old := time.Now().Add(-2 * time.Hour)
+	assert.NoError(t, os.Chtimes(orphan, old, old))
+	assert.NoError(t, os.Chtimes(referenced, old, old))
+
+	result, err := adminClient.RunMaintenance(ctx, &pb.MaintenanceRequest{Task: pb.MAINTENANCE_TASK_BLOB_GC})
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, result.RemovedFiles, int64(1))
+	assert.NoFileExists(t, orphan)
+	assert.FileExists(t, referenced)
+	assert.FileExists(t, "server_test.go")
+}
+
+func TestAuditServer_ListAndVerify(t *testing.T) {
+	prepare()
+	_, err := authClient.Login(context.Background(), testAuthData)
+	assert.Error(t, err)
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+	_, err = authClient.Login(context.Background(), &pb.AuthData{Login: testAuthData.Login, Password: "wrong"})
+	assert.Error(t, err)
+	otherToken, err := authClient.Register(context.Background(), &pb.AuthData{Login: "other", Password: "password"})
+	assert.NoError(t, err)
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	otherCtx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": otherToken.Token}))
+
+	id, err := resourceClient.Save(ctx, testResource)
+	assert.NoError(t, err)
+	_, err = resourceClient.Get(ctx, id)
+	assert.NoError(t, err)
+	_, err = resourceClient.Delete(ctx, id)
+	assert.NoError(t, err)
+
+	stream, err := auditClient.List(ctx, &pb.AuditQuery{})
+	assert.NoError(t, err)
+	actions := make([]pb.AUDIT_ACTION, 0)
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		actions = append(actions, event.Action)
+		if event.Action == pb.AUDIT_ACTION_GET {
+			assert.Equal(t, id.Value, event.TargetId.Value)
+			assert.Equal(t, testAuthData.Login, event.Login)
+		}
+	}
+	assert.Equal(t, []pb.AUDIT_ACTION{
+		pb.AUDIT_ACTION_DELETE,
+		pb.AUDIT_ACTION_GET,
+		pb.AUDIT_ACTION_SAVE,
+		pb.AUDIT_ACTION_LOGIN_FAILED,
+		pb.AUDIT_ACTION_TOKEN_ISSUED,
+		pb.AUDIT_ACTION_REGISTER,
+	}, actions)
+
+	stream, err = auditClient.List(otherCtx, &pb.AuditQuery{Limit: 1})
+	assert.NoError(t, err)
+	event, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, pb.AUDIT_ACTION_TOKEN_ISSUED, event.Action)
+	assert.Equal(t, "other", event.Login)
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+
+	_, err = db.Exec("update audit_events set details = 'changed'")
+	assert.Error(t, err)
+	_, err = adminClient.VerifyAudit(ctx, &emptypb.Empty{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	db.MustExec("update users set is_admin = true where login = $1", testAuthData.Login)
+	result, err := adminClient.VerifyAudit(ctx, &emptypb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), result.FirstInvalidSeq)
+
+	var seq int64
+	assert.NoError(t, db.Get(&seq, "select seq from audit_events where action = $1 and target_id = $2", api.AuditGet, uuid.Must(uuid.FromBytes(id.Value))))
+	db.MustExec("alter table audit_events disable trigger audit_events_append_only")
+	db.MustExec("update audit_events set user_id = null where seq = $1", seq)
+	db.MustExec("alter table audit_events enable trigger audit_events_append_only")
+	result, err = adminClient.VerifyAudit(ctx, &emptypb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, seq, result.FirstInvalidSeq)
+}
+
+func TestResourceServer_Watch(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token})))
+	defer cancel()
+
+	stream, err := resourceClient.Watch(ctx, &emptypb.Empty{})
+	assert.NoError(t, err)
+	change, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, pb.CHANGE_KIND_RESET, change.Kind)
+
+	id, err := resourceClient.Save(ctx, testResource)
+	assert.NoError(t, err)
+	change, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, pb.CHANGE_KIND_CREATED, change.Kind)
+	assert.Equal(t, id.Value, change.Id.Value)
+	assert.Equal(t, pb.TYPE_LOGIN_PASSWORD, change.Type)
+
+	_, err = resourceClient.Delete(ctx, id)
+	assert.NoError(t, err)
+	change, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, pb.CHANGE_KIND_DELETED, change.Kind)
+	assert.Equal(t, id.Value, change.Id.Value)
+}
+
+func TestResourceServer_WatchSharedResource(t *testing.T) {
+	prepare()
+	ownerToken, err := authClient.Register(context.Background(), &pb.AuthData{Login: "owner", Password: "password"})
+	assert.NoError(t, err)
+	recipientToken, err := authClient.Register(context.Background(), &pb.AuthData{Login: "recipient", Password: "password"})
+	assert.NoError(t, err)
+	ownerCtx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": ownerToken.Token}))
+	recipientCtx, cancel := context.WithCancel(metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": recipientToken.Token})))
+	defer cancel()
+	_, err = shareClient.SetKeys(recipientCtx, &pb.KeyPair{PublicKey: make([]byte, 32), EncryptedPrivateKey: []byte("sealed")})
+	assert.NoError(t, err)
+
+	id, err := resourceClient.Save(ownerCtx, testResource)
+	assert.NoError(t, err)
+	stream, err := resourceClient.Watch(recipientCtx, &emptypb.Empty{})
+	assert.NoError(t, err)
+	change, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, pb.CHANGE_KIND_RESET, change.Kind)
+
+	_, err = shareClient.Share(ownerCtx, &pb.ShareRequest{
+		ResourceId: id,
+		Recipient:  "recipient",
+		Permission: pb.PERMISSION_READ,
+		WrappedKey: []byte("wrapped"),
+		OwnerKey:   []byte("owner key"),
+		Data:       []byte("encrypted"),
+	})
+	assert.NoError(t, err)
+	change, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, pb.CHANGE_KIND_CREATED, change.Kind)
+	assert.Equal(t, id.Value, change.Id.Value)
+
+	_, err = shareClient.UpdateShared(ownerCtx, &pb.SharedUpdate{ResourceId: id, Data: []byte("changed")})
+	assert.NoError(t, err)
+	change, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, pb.CHANGE_KIND_UPDATED, change.Kind)
+
+	_, err = resourceClient.Delete(ownerCtx, id)
+	assert.NoError(t, err)
+	change, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, pb.CHANGE_KIND_DELETED, change.Kind)
+	assert.Equal(t, id.Value, change.Id.Value)
+}
+
+func TestResourceServer_Sync(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+
+	kept, err := resourceClient.Save(ctx, testResource)
+	assert.NoError(t, err)
+	deleted, err := resourceClient.Save(ctx, testResource)
+	assert.NoError(t, err)
+	_, err = resourceClient.Delete(ctx, deleted)
+	assert.NoError(t, err)
+
+	result, err := resourceClient.Sync(ctx, &pb.SyncRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), result.HighWaterMark)
+	assert.False(t, result.More)
+	assert.Equal(t, 2, len(result.Changes))
+	assert.Equal(t, kept.Value, result.Changes[0].Id.Value)
+	assert.Equal(t, pb.CHANGE_KIND_CREATED, result.Changes[0].Kind)
+	assert.Equal(t, int64(1), result.Changes[0].Seq)
+	assert.Equal(t, testResource.Meta, result.Changes[0].Meta)
+	assert.Equal(t, deleted.Value, result.Changes[1].Id.Value)
+	assert.Equal(t, pb.CHANGE_KIND_DELETED, result.Changes[1].Kind)
+	assert.Equal(t, int64(3), result.Changes[1].Seq)
+	assert.Nil(t, result.Changes[1].Meta)
+
+	result, err = resourceClient.Sync(ctx, &pb.SyncRequest{Since: 1, Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(result.Changes))
+	assert.Equal(t, int64(3), result.HighWaterMark)
+	assert.True(t, result.More)
+
+	result, err = resourceClient.Sync(ctx, &pb.SyncRequest{Since: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(result.Changes))
+	assert.Equal(t, int64(3), result.HighWaterMark)
+}
+
+func TestResourceServer_ListConflictsAfterLeaving(t *testing.T) {
+	prepare()
+	ctxs := map[string]context.Context{}
+	for _, login := range []string{"owner", "member"} {
+		token, err := authClient.Register(context.Background(), &pb.AuthData{Login: login, Password: "password"})
+		assert.NoError(t, err)
+		ctxs[login] = metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	}
+	org, err := orgClient.Create(ctxs["owner"], &pb.OrganizationRequest{Name: "team"})
+	assert.NoError(t, err)
+	collection, err := orgClient.CreateCollection(ctxs["owner"], &pb.CollectionRequest{OrgId: org.Id, Name: "prod"})
+	assert.NoError(t, err)
+	_, err = orgClient.Invite(ctxs["owner"], &pb.MemberRequest{OrgId: org.Id, Login: "member", Role: pb.ROLE_MEMBER})
+	assert.NoError(t, err)
+	_, err = orgClient.Accept(ctxs["member"], org.Id)
+	assert.NoError(t, err)
+
+	resource := &pb.Resource{Type: testResource.Type, Data: testResource.Data, Meta: testResource.Meta, CollectionId: collection.Id}
+	id, err := resourceClient.Save(ctxs["owner"], resource)
+	assert.NoError(t, err)
+	_, err = resourceClient.Update(ctxs["owner"], &pb.UpdateRequest{Id: id, Resource: resource, BaseRevision: 1})
+	assert.NoError(t, err)
+	conflicting, err := resourceClient.Update(ctxs["member"], &pb.UpdateRequest{Id: id, Resource: resource, BaseRevision: 1})
+	assert.NoError(t, err)
+	assert.NotNil(t, conflicting.ConflictId)
+
+	_, err = orgClient.RemoveMember(ctxs["owner"], &pb.MemberRequest{OrgId: org.Id, Login: "member"})
+	assert.NoError(t, err)
+	stream, err := resourceClient.ListConflicts(ctxs["member"], &emptypb.Empty{})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestResourceServer_SyncOfMembersAndRecipients(t *testing.T) {
+	prepare()
+	ctxs := map[string]context.Context{}
+	for _, login := range []string{"owner", "member", "recipient"} {
+		token, err := authClient.Register(context.Background(), &pb.AuthData{Login: login, Password: "password"})
+		assert.NoError(t, err)
+		ctxs[login] = metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	}
+	keyPair := &pb.KeyPair{PublicKey: make([]byte, 32), EncryptedPrivateKey: []byte("sealed")}
+	_, err := shareClient.SetKeys(ctxs["recipient"], keyPair)
+	assert.NoError(t, err)
+
+	org, err := orgClient.Create(ctxs["owner"], &pb.OrganizationRequest{Name: "team"})
+	assert.NoError(t, err)
+	collection, err := orgClient.CreateCollection(ctxs["owner"], &pb.CollectionRequest{OrgId: org.Id, Name: "prod"})
+	assert.NoError(t, err)
+	_, err = orgClient.Invite(ctxs["owner"], &pb.MemberRequest{OrgId: org.Id, Login: "member", Role: pb.ROLE_MEMBER})
+	assert.NoError(t, err)
+	_, err = orgClient.Accept(ctxs["member"], org.Id)
+	assert.NoError(t, err)
+
+	resource := &pb.Resource{Type: testResource.Type, Data: testResource.Data, Meta: testResource.Meta, CollectionId: collection.Id}
+	collectionId, err := resourceClient.Save(ctxs["member"], resource)
+	assert.NoError(t, err)
+	share := &pb.ShareRequest{
+		ResourceId: collectionId,
+		Recipient:  "recipient",
+		Permission: pb.PERMISSION_READ,
+		WrappedKey: []byte("wrapped"),
+		OwnerKey:   []byte("owner key"),
+		Data:       []byte("encrypted"),
+	}
+	_, err = shareClient.Share(ctxs["member"], share)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	sharedId, err := resourceClient.Save(ctxs["owner"], testResource)
+	assert.NoError(t, err)
+	share.ResourceId = sharedId
+	_, err = shareClient.Share(ctxs["owner"], share)
+	assert.NoError(t, err)
+
+	syncIds := func(login string, kind pb.CHANGE_KIND) []string {
+		result, err := resourceClient.Sync(ctxs[login], &pb.SyncRequest{})
+		assert.NoError(t, err)
+		ids := make([]string, 0, len(result.Changes))
+		for _, change := range result.Changes {
+			assert.Equal(t, kind, change.Kind)
+			ids = append(ids, string(change.Id.Value))
+		}
+		return ids
+	}
+	toString := func(id *pb.UUID) string {
+		return string(id.Value)
+	}
+	assert.Equal(t, []string{toString(collectionId), toString(sharedId)}, syncIds("owner", pb.CHANGE_KIND_CREATED))
+	assert.Equal(t, []string{toString(collectionId)}, syncIds("member", pb.CHANGE_KIND_CREATED))
+	assert.Equal(t, []string{toString(sharedId)}, syncIds("recipient", pb.CHANGE_KIND_CREATED))
+
+	_, err = resourceClient.Delete(ctxs["owner"], collectionId)
+	assert.NoError(t, err)
+	_, err = resourceClient.Delete(ctxs["owner"], sharedId)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{toString(collectionId), toString(sharedId)}, syncIds("owner", pb.CHANGE_KIND_DELETED))
+	assert.Equal(t, []string{toString(collectionId)}, syncIds("member", pb.CHANGE_KIND_DELETED))
+	assert.Equal(t, []string{toString(sharedId)}, syncIds("recipient", pb.CHANGE_KIND_DELETED))
+}
+
+func TestResourceServer_UpdateConflict(t *testing.T) {
+	prepare()
+	token, err := authClient.Register(context.Background(), testAuthData)
+	assert.NoError(t, err)
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{"token": token.Token}))
+	loginPassword := func(password string) *pb.Resource {
+		return &pb.Resource{
+			Meta:    []byte("meta"),
+			Payload: &pb.Resource_LoginPassword{LoginPassword: &pb.LoginPasswordData{Login: "login", Password: password}},
+		}
+	}
+
+	id, err := resourceClient.Save(ctx, loginPassword("first"))
+	assert.NoError(t, err)
+	result, err := resourceClient.Get(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.Revision)
+
+	updated, err := resourceClient.Update(ctx, &pb.UpdateRequest{Id: id, Resource: loginPassword("laptop"), BaseRevision: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), updated.Revision)
+	assert.Nil(t, updated.ConflictId)
+
+	conflicting, err := resourceClient.Update(ctx, &pb.UpdateRequest{Id: id, Resource: loginPassword("desktop"), BaseRevision: 1})
+	assert.NoError(t, err)
+	assert.NotNil(t, conflicting.ConflictId)
+	result, err = resourceClient.Get(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, "laptop", result.GetLoginPassword().Password)
+
+	stream, err := resourceClient.ListConflicts(ctx, &emptypb.Empty{})
+	assert.NoError(t, err)
+	conflict, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, conflicting.ConflictId.Value, conflict.Id.Value)
+	assert.Equal(t, id.Value, conflict.ResourceId.Value)
+	assert.Equal(t, "laptop", conflict.Current.GetLoginPassword().Password)
+	assert.Equal(t, "desktop", conflict.Conflicting.GetLoginPassword().Password)
+	assert.Equal(t, int64(2), conflict.Current.Revision)
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+
+	_, err = resourceClient.ResolveConflict(ctx, &pb.ConflictResolution{Id: conflict.Id, Resource: conflict.Conflicting, BaseRevision: 1})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = resourceClient.ResolveConflict(ctx, &pb.ConflictResolution{Id: conflict.Id, Resource: conflict.Conflicting, BaseRevision: 2})
+	assert.NoError(t, err)
+	result, err = resourceClient.Get(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, "desktop", result.GetLoginPassword().Password)
+	assert.Equal(t, int64(3), result.Revision)
+
+	stream, err = resourceClient.ListConflicts(ctx, &emptypb.Empty{})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+
+	changes, err := resourceClient.Sync(ctx, &pb.SyncRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(changes.Changes))
+	assert.Equal(t, pb.CHANGE_KIND_UPDATED, changes.Changes[0].Kind)
 }
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"secstorage/internal/api"
+	auditModel "secstorage/internal/server/storage/audit/model"
+	"secstorage/internal/server/storage/auth/model"
+)
+
+type AdminStore interface {
+	ListUsers(context.Context) ([]model.UserInfo, error)
+	GetUserInfo(context.Context, string) (*model.UserInfo, error)
+	SetDisabled(context.Context, string, bool) error
+	Logout(context.Context, string) error
+}
+
+type Maintenance interface {
+	CollectGarbage(context.Context) (*GCResult, error)
+	VerifyFiles(context.Context) ([]api.ResourceId, error)
+}
+
+type AuditLog interface {
+	ListAll(context.Context, string, int64, int) ([]auditModel.Event, error)
+	Verify(context.Context) (*AuditVerifyResult, error)
+}
+
+// AdminService operates users and the store, callers are checked to be admins by the token interceptors.
+type AdminService struct {
+	store       AdminStore
+	maintenance Maintenance
+	audit       AuditLog
+}
+
+func NewAdminService(store AdminStore, maintenance Maintenance, audit AuditLog) *AdminService {
+	return &AdminService{store: store, maintenance: maintenance, audit: audit}
+}
+
+func (s *AdminService) ListUsers(ctx context.Context) ([]model.UserInfo, error) {
+	return s.store.ListUsers(ctx)
+}
+
+func (s *AdminService) GetUser(ctx context.Context, login string) (*model.UserInfo, error) {
+	return s.store.GetUserInfo(ctx, login)
+}
+
+func (s *AdminService) SetDisabled(ctx context.Context, adminId api.UserId, login string, disabled bool) error {
+	user, err := s.store.GetUserInfo(ctx, login)
+	if err != nil {
+		return err
+	}
+	if user.Id == adminId {
+		return invalid("admins can't disable themselves")
+	}
+	return s.store.SetDisabled(ctx, login, disabled)
+}
+
+func (s *AdminService) Logout(ctx context.Context, login string) error {
+	return s.store.Logout(ctx, login)
+}
+
+func (s *AdminService) CollectGarbage(ctx context.Context) (*GCResult, error) {
+	return s.maintenance.CollectGarbage(ctx)
+}
+
+func (s *AdminService) VerifyFiles(ctx context.Context) ([]api.ResourceId, error) {
+	return s.maintenance.VerifyFiles(ctx)
+}
+
+func (s *AdminService) ListAudit(ctx context.Context, login string, before int64, limit int) ([]auditModel.Event, error) {
+	return s.audit.ListAll(ctx, login, before, limit)
+}
+
+func (s *AdminService) VerifyAudit(ctx context.Context) (*AuditVerifyResult, error) {
+	return s.audit.Verify(ctx)
+}
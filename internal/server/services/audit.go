@@ -0,0 +1,145 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"github.com/google/uuid"
+	"hash"
+	"secstorage/internal/api"
+	"secstorage/internal/server/storage/audit/model"
+	"time"
+)
+
+const defaultAuditLimit = 50
+const maxAuditLimit = 1000
+const auditVerifyBatch = 1000
+
+type AuditStore interface {
+	Append(context.Context, *model.Event, func([]byte) []byte) error
+	Owner(context.Context, uuid.UUID) (uuid.NullUUID, error)
+	UserIdByLogin(context.Context, string) (uuid.NullUUID, error)
+	ListByUserId(context.Context, api.UserId, int64, int) ([]model.Event, error)
+	List(context.Context, int64, int) ([]model.Event, error)
+	ListAfter(context.Context, int64, int) ([]model.Event, error)
+}
+
+type AuditVerifyResult struct {
+	Checked         int64
+	FirstInvalidSeq int64
+	Head            []byte
+}
+
+// AuditService keeps the append-only log of security-relevant events,
+// the events are chained by hashes so a changed or removed event is detected by Verify.
+type AuditService struct {
+	store AuditStore
+}
+
+func NewAuditService(store AuditStore) *AuditService {
+	return &AuditService{store: store}
+}
+
+// ResolveOwner sets the owner of the event to the owner of its target or to the user with the login the event is about.
+// It is called before the action, as the target may be gone after it.
+func (s *AuditService) ResolveOwner(ctx context.Context, event *model.Event, login string) error {
+	var err error
+	if event.TargetId.Valid {
+		event.OwnerId, err = s.store.Owner(ctx, event.TargetId.UUID)
+	} else if login != "" {
+		event.OwnerId, err = s.store.UserIdByLogin(ctx, login)
+	}
+	return err
+}
+
+func (s *AuditService) Record(ctx context.Context, event *model.Event) error {
+	if !event.OwnerId.Valid {
+		event.OwnerId = event.UserId
+	}
+	event.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	return s.store.Append(ctx, event, func(prev []byte) []byte {
+		return eventHash(prev, event)
+	})
+}
+
+func (s *AuditService) List(ctx context.Context, userId api.UserId, before int64, limit int) ([]model.Event, error) {
+	return s.store.ListByUserId(ctx, userId, before, auditLimit(limit))
+}
+
+// ListAll lists events of all users or of the user with the login.
+func (s *AuditService) ListAll(ctx context.Context, login string, before int64, limit int) ([]model.Event, error) {
+	if login == "" {
+		return s.store.List(ctx, before, auditLimit(limit))
+	}
+	userId, err := s.store.UserIdByLogin(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+	if !userId.Valid {
+		return nil, nil
+	}
+	return s.store.ListByUserId(ctx, userId.UUID, before, auditLimit(limit))
+}
+
+// Verify walks the whole chain and reports the first event whose hash doesn't match.
+func (s *AuditService) Verify(ctx context.Context) (*AuditVerifyResult, error) {
+	result := &AuditVerifyResult{}
+	var prev []byte
+	var after int64
+	for {
+		events, err := s.store.ListAfter(ctx, after, auditVerifyBatch)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < len(events); i++ {
+			result.Checked++
+			if !bytes.Equal(events[i].PrevHash, prev) || !bytes.Equal(events[i].Hash, eventHash(prev, &events[i])) {
+				result.FirstInvalidSeq = events[i].Seq
+				return result, nil
+			}
+			prev = events[i].Hash
+			after = events[i].Seq
+		}
+		if len(events) < auditVerifyBatch {
+			result.Head = prev
+			return result, nil
+		}
+	}
+}
+
+func auditLimit(limit int) int {
+	if limit <= 0 {
+		return defaultAuditLimit
+	}
+	if limit > maxAuditLimit {
+		return maxAuditLimit
+	}
+	return limit
+}
+
+func eventHash(prev []byte, event *model.Event) []byte {
+	h := sha256.New()
+	writeField(h, prev)
+	writeField(h, nullUUIDBytes(event.UserId))
+	writeField(h, []byte(event.Login))
+	writeField(h, binary.BigEndian.AppendUint64(nil, uint64(event.Action)))
+	writeField(h, nullUUIDBytes(event.TargetId))
+	writeField(h, nullUUIDBytes(event.OwnerId))
+	writeField(h, []byte(event.Details))
+	writeField(h, binary.BigEndian.AppendUint64(nil, uint64(event.CreatedAt.UnixMicro())))
+	return h.Sum(nil)
+}
+
+// writeField writes the length before the value, so different events can't have the same encoding
+func writeField(h hash.Hash, value []byte) {
+	h.Write(binary.BigEndian.AppendUint32(nil, uint32(len(value))))
+	h.Write(value)
+}
+
+func nullUUIDBytes(id uuid.NullUUID) []byte {
+	if !id.Valid {
+		return nil
+	}
+	return id.UUID[:]
+}
@@ -2,13 +2,18 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
 	"github.com/google/uuid"
 	"secstorage/internal/server/storage/auth/model"
 )
 
+const recoveryAuthLength = 32
+
 type AuthStorage interface {
 	Register(context.Context, model.User) (uuid.UUID, error)
 	Login(context.Context, model.User) (uuid.UUID, error)
+	SetRecovery(context.Context, uuid.UUID, string, model.Recovery) error
+	Recover(context.Context, string, []byte, string) (*model.RecoveredUser, error)
 }
 
 type AuthService struct {
@@ -27,3 +32,23 @@ func (s *AuthService) Register(ctx context.Context, info model.User) (uuid.UUID,
 func (s *AuthService) Login(ctx context.Context, info model.User) (uuid.UUID, error) {
 	return s.storage.Login(ctx, info)
 }
+
+// SetRecovery stores the private key encrypted under the recovery key, only the hash of the proof
+// derived from the recovery key is stored, so the server can't decrypt the private key.
+// The current password is required, otherwise a stolen token would be enough to take the account over.
+func (s *AuthService) SetRecovery(ctx context.Context, userId uuid.UUID, password string, auth []byte, privateKey []byte) error {
+	if len(auth) != recoveryAuthLength || len(privateKey) == 0 {
+		return invalid("malformed recovery data")
+	}
+	authHash := sha256.Sum256(auth)
+	return s.storage.SetRecovery(ctx, userId, password, model.Recovery{AuthHash: authHash[:], PrivateKey: privateKey})
+}
+
+// Recover sets the new password and returns the private key encrypted under the recovery key.
+func (s *AuthService) Recover(ctx context.Context, login string, auth []byte, newPassword string) (*model.RecoveredUser, error) {
+	if len(auth) != recoveryAuthLength {
+		return nil, invalid("malformed recovery data")
+	}
+	authHash := sha256.Sum256(auth)
+	return s.storage.Recover(ctx, login, authHash[:], newPassword)
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"secstorage/internal/api"
+	"secstorage/internal/server/reservederrors"
+	"secstorage/internal/server/storage/emergency/model"
+)
+
+const maxEmergencyWaitHours = 90 * 24
+
+type EmergencyStore interface {
+	Create(context.Context, *model.Access) error
+	Get(context.Context, uuid.UUID) (*model.Access, error)
+	ListByUserId(context.Context, api.UserId) ([]model.Access, error)
+	SetStatus(context.Context, uuid.UUID, api.EmergencyStatus) error
+	Delete(context.Context, uuid.UUID) error
+	IsGranted(context.Context, api.UserId, api.UserId) (bool, error)
+}
+
+// EmergencyService lets trusted contacts get read access to the vault of a user who can't be reached.
+// Requested access is granted after the waiting period chosen by the grantor unless the grantor rejects it.
+type EmergencyService struct {
+	store EmergencyStore
+}
+
+func NewEmergencyService(store EmergencyStore) *EmergencyService {
+	return &EmergencyService{store: store}
+}
+
+func (s *EmergencyService) Add(ctx context.Context, grantorId api.UserId, login string, waitHours int) (uuid.UUID, error) {
+	if waitHours < 1 || waitHours > maxEmergencyWaitHours {
+		return uuid.Nil, invalid("waiting period must be from 1 hour to 90 days")
+	}
+	access := &model.Access{
+		Id:           uuid.New(),
+		GrantorId:    grantorId,
+		TrusteeLogin: login,
+		WaitHours:    waitHours,
+	}
+	return access.Id, s.store.Create(ctx, access)
+}
+
+func (s *EmergencyService) List(ctx context.Context, userId api.UserId) ([]model.Access, error) {
+	return s.store.ListByUserId(ctx, userId)
+}
+
+// Request starts the waiting period, repeated requests don't restart it.
+func (s *EmergencyService) Request(ctx context.Context, trusteeId api.UserId, id uuid.UUID) error {
+	access, err := s.store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if access.TrusteeId != trusteeId {
+		return reservederrors.ErrEmergencyNotFound
+	}
+	if access.Status != api.EmergencyIdle {
+		return nil
+	}
+	return s.store.SetStatus(ctx, id, api.EmergencyRequested)
+}
+
+// Approve grants the requested access without waiting.
+func (s *EmergencyService) Approve(ctx context.Context, grantorId api.UserId, id uuid.UUID) error {
+	access, err := s.getAsGrantor(ctx, grantorId, id)
+	if err != nil {
+		return err
+	}
+	if access.Status == api.EmergencyIdle {
+		return invalid("access is not requested")
+	}
+	return s.store.SetStatus(ctx, id, api.EmergencyGranted)
+}
+
+// Reject rejects the request or takes back the granted access, the contact stays trusted.
+func (s *EmergencyService) Reject(ctx context.Context, grantorId api.UserId, id uuid.UUID) error {
+	if _, err := s.getAsGrantor(ctx, grantorId, id); err != nil {
+		return err
+	}
+	return s.store.SetStatus(ctx, id, api.EmergencyIdle)
+}
+
+// Remove removes the trusted contact, both the grantor and the trustee can do it.
+func (s *EmergencyService) Remove(ctx context.Context, userId api.UserId, id uuid.UUID) error {
+	access, err := s.store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if access.GrantorId != userId && access.TrusteeId != userId {
+		return reservederrors.ErrEmergencyNotFound
+	}
+	return s.store.Delete(ctx, id)
+}
+
+func (s *EmergencyService) getAsGrantor(ctx context.Context, grantorId api.UserId, id uuid.UUID) (*model.Access, error) {
+	access, err := s.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if access.GrantorId != grantorId {
+		return nil, reservederrors.ErrEmergencyNotFound
+	}
+	return access, nil
+}
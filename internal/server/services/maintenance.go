@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	. "secstorage/internal/logger"
+	"strings"
+	"time"
+)
+
+// files written less than gcGracePeriod ago may belong to uploads in progress
+const gcGracePeriod = time.Hour
+const uploadSessionTTL = 7 * 24 * time.Hour
+
+type GCResult struct {
+	RemovedFiles   int64
+	FreedBytes     int64
+	ExpiredUploads int64
+}
+
+// CollectGarbage drops expired upload sessions and removes files of the store no resource or upload refers to,
+// e.g. blobs left behind by deleted users.
+func (s *ResourceService) CollectGarbage(ctx context.Context) (*GCResult, error) {
+	result := &GCResult{}
+	expired, err := s.uploads.DeleteExpired(ctx, uploadSessionTTL)
+	if err != nil {
+		return nil, err
+	}
+	result.ExpiredUploads = int64(len(expired))
+
+	referenced, err := s.referencedFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(s.fileStore.Path)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !isStoreFile(entry.Name()) || referenced[entry.Name()] {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) < gcGracePeriod {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.fileStore.Path, entry.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			Log.Error("error on remove unreferenced file", zap.String("name", entry.Name()), zap.Error(err))
+			continue
+		}
+		result.RemovedFiles++
+		result.FreedBytes += info.Size()
+	}
+	return result, nil
+}
+
+func (s *ResourceService) referencedFiles(ctx context.Context) (map[string]bool, error) {
+	files, err := s.store.ListFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	uploads, err := s.uploads.ListPaths(ctx)
+	if err != nil {
+		return nil, err
+	}
+	referenced := make(map[string]bool, len(files)+len(uploads))
+	for i := 0; i < len(files); i++ {
+		referenced[filepath.Base(string(files[i].Data))] = true
+	}
+	for i := 0; i < len(uploads); i++ {
+		referenced[filepath.Base(uploads[i])] = true
+	}
+	return referenced, nil
+}
+
+// isStoreFile reports whether name is one of the names the service gives to files:
+// <id>.part for uploads, <user id>_<checksum> for blobs and <id> for files stored before deduplication.
+func isStoreFile(name string) bool {
+	if strings.HasSuffix(name, ".part") {
+		return isUUID(strings.TrimSuffix(name, ".part"))
+	}
+	if userId, checksum, ok := strings.Cut(name, "_"); ok {
+		_, err := hex.DecodeString(checksum)
+		return isUUID(userId) && err == nil && len(checksum) == 2*32
+	}
+	return isUUID(name)
+}
+
+func isUUID(s string) bool {
+	_, err := uuid.Parse(s)
+	return err == nil && len(s) == 36
+}
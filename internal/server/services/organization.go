@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"secstorage/internal/api"
+	"secstorage/internal/server/reservederrors"
+	"secstorage/internal/server/storage/organization/model"
+)
+
+type OrgStore interface {
+	Create(context.Context, *model.Organization, api.UserId) error
+	ListByUserId(context.Context, api.UserId) ([]model.Membership, error)
+	GetMember(context.Context, uuid.UUID, api.UserId) (*model.Member, error)
+	GetMemberByLogin(context.Context, uuid.UUID, string) (*model.Member, error)
+	ListMembers(context.Context, uuid.UUID) ([]model.Member, error)
+	Invite(context.Context, uuid.UUID, string, api.Role) error
+	Accept(context.Context, uuid.UUID, api.UserId) error
+	SetRole(context.Context, uuid.UUID, api.UserId, api.Role) error
+	RemoveMember(context.Context, uuid.UUID, api.UserId) error
+	CountOwners(context.Context, uuid.UUID) (int, error)
+	CreateCollection(context.Context, *model.Collection) error
+	ListCollections(context.Context, uuid.UUID) ([]model.Collection, error)
+	CollectionRole(context.Context, uuid.UUID, api.UserId) (api.Role, error)
+}
+
+// OrgService manages organizations, their members and collections.
+// Admins manage members and collections, but nobody can grant a role higher than their own.
+type OrgService struct {
+	store OrgStore
+}
+
+func NewOrgService(store OrgStore) *OrgService {
+	return &OrgService{store: store}
+}
+
+func (s *OrgService) Create(ctx context.Context, userId api.UserId, name string) (*model.Organization, error) {
+	if name == "" {
+		return nil, invalid("organization name is required")
+	}
+	org := &model.Organization{Id: uuid.New(), Name: name}
+	return org, s.store.Create(ctx, org, userId)
+}
+
+func (s *OrgService) List(ctx context.Context, userId api.UserId) ([]model.Membership, error) {
+	return s.store.ListByUserId(ctx, userId)
+}
+
+func (s *OrgService) Invite(ctx context.Context, userId api.UserId, orgId uuid.UUID, login string, role api.Role) error {
+	member, err := s.requireRole(ctx, orgId, userId, api.Admin)
+	if err != nil {
+		return err
+	}
+	if role > api.Owner {
+		return invalid("unknown role")
+	}
+	if role > member.Role {
+		return reservederrors.ErrPermissionDenied
+	}
+	return s.store.Invite(ctx, orgId, login, role)
+}
+
+func (s *OrgService) Accept(ctx context.Context, userId api.UserId, orgId uuid.UUID) error {
+	return s.store.Accept(ctx, orgId, userId)
+}
+
+func (s *OrgService) SetRole(ctx context.Context, userId api.UserId, orgId uuid.UUID, login string, role api.Role) error {
+	member, err := s.requireRole(ctx, orgId, userId, api.Admin)
+	if err != nil {
+		return err
+	}
+	if role > api.Owner {
+		return invalid("unknown role")
+	}
+	target, err := s.store.GetMemberByLogin(ctx, orgId, login)
+	if err != nil {
+		return err
+	}
+	if role > member.Role || target.Role > member.Role {
+		return reservederrors.ErrPermissionDenied
+	}
+	if target.Role == api.Owner && role != api.Owner {
+		if err := s.checkOwnerLeft(ctx, orgId); err != nil {
+			return err
+		}
+	}
+	return s.store.SetRole(ctx, orgId, target.UserId, role)
+}
+
+// RemoveMember removes the member or cancels the invitation, any member can leave the organization.
+func (s *OrgService) RemoveMember(ctx context.Context, userId api.UserId, orgId uuid.UUID, login string) error {
+	target, err := s.store.GetMemberByLogin(ctx, orgId, login)
+	if err != nil {
+		return err
+	}
+	if target.UserId != userId {
+		member, err := s.requireRole(ctx, orgId, userId, api.Admin)
+		if err != nil {
+			return err
+		}
+		if target.Role > member.Role {
+			return reservederrors.ErrPermissionDenied
+		}
+	}
+	if target.Role == api.Owner {
+		if err := s.checkOwnerLeft(ctx, orgId); err != nil {
+			return err
+		}
+	}
+	return s.store.RemoveMember(ctx, orgId, target.UserId)
+}
+
+func (s *OrgService) ListMembers(ctx context.Context, userId api.UserId, orgId uuid.UUID) ([]model.Member, error) {
+	if _, err := s.requireRole(ctx, orgId, userId, api.ReadOnly); err != nil {
+		return nil, err
+	}
+	return s.store.ListMembers(ctx, orgId)
+}
+
+func (s *OrgService) CreateCollection(ctx context.Context, userId api.UserId, orgId uuid.UUID, name string) (*model.Collection, error) {
+	if name == "" {
+		return nil, invalid("collection name is required")
+	}
+	if _, err := s.requireRole(ctx, orgId, userId, api.Admin); err != nil {
+		return nil, err
+	}
+	collection := &model.Collection{Id: uuid.New(), OrgId: orgId, Name: name}
+	return collection, s.store.CreateCollection(ctx, collection)
+}
+
+func (s *OrgService) ListCollections(ctx context.Context, userId api.UserId, orgId uuid.UUID) ([]model.Collection, error) {
+	if _, err := s.requireRole(ctx, orgId, userId, api.ReadOnly); err != nil {
+		return nil, err
+	}
+	return s.store.ListCollections(ctx, orgId)
+}
+
+// requireRole returns the member if the user accepted the invitation and has at least the role.
+func (s *OrgService) requireRole(ctx context.Context, orgId uuid.UUID, userId api.UserId, role api.Role) (*model.Member, error) {
+	member, err := s.store.GetMember(ctx, orgId, userId)
+	if err != nil {
+		return nil, err
+	}
+	if !member.Accepted {
+		return nil, reservederrors.ErrNotMember
+	}
+	if member.Role < role {
+		return nil, reservederrors.ErrPermissionDenied
+	}
+	return member, nil
+}
+
+func (s *OrgService) checkOwnerLeft(ctx context.Context, orgId uuid.UUID) error {
+	owners, err := s.store.CountOwners(ctx, orgId)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return reservederrors.ErrLastOwner
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"secstorage/internal/server/reservederrors"
+	"secstorage/internal/server/storage/resource/model"
+)
+
+// Quota limits what a single user may keep in the store. Zero means unlimited.
+type Quota struct {
+	MaxItems    int64
+	MaxBytes    int64
+	MaxFileSize int64
+}
+
+func (q Quota) checkSave(usage model.Usage, size int64) error {
+	if q.MaxItems > 0 && usage.Items+1 > q.MaxItems {
+		return reservederrors.ErrQuotaExceeded
+	}
+	if q.MaxBytes > 0 && usage.Bytes+size > q.MaxBytes {
+		return reservederrors.ErrQuotaExceeded
+	}
+	return nil
+}
+
+// checkUpdate checks that the resource may grow by delta bytes.
+func (q Quota) checkUpdate(usage model.Usage, delta int64) error {
+	if q.MaxBytes > 0 && delta > 0 && usage.Bytes+delta > q.MaxBytes {
+		return reservederrors.ErrQuotaExceeded
+	}
+	return nil
+}
+
+// fileLimit returns the number of bytes a new file may take and the error to report when it is crossed.
+func (q Quota) fileLimit(usage model.Usage) (int64, error) {
+	limit, limitErr := int64(-1), error(nil)
+	if q.MaxFileSize > 0 {
+		limit, limitErr = q.MaxFileSize, reservederrors.ErrFileTooLarge
+	}
+	if q.MaxBytes > 0 && (limit < 0 || q.MaxBytes-usage.Bytes < limit) {
+		limit, limitErr = q.MaxBytes-usage.Bytes, reservederrors.ErrQuotaExceeded
+	}
+	return limit, limitErr
+}
@@ -1,83 +1,651 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 	"os"
 	"secstorage/internal/api"
 	"secstorage/internal/fileutil"
+	. "secstorage/internal/logger"
+	"secstorage/internal/server/events"
+	"secstorage/internal/server/reservederrors"
 	"secstorage/internal/server/storage/resource/model"
 )
 
 type ResourceStore interface {
-	Save(context.Context, *model.Resource) error
-	Delete(context.Context, api.ResourceId, api.UserId) error
-	DeleteTx(context.Context, api.ResourceId, api.UserId, func() error) error
+	Save(context.Context, *model.Resource, func(model.Usage) error) error
+	SaveFile(context.Context, *model.Resource, *model.Blob, func(model.Usage) error, func(bool) error) error
+	DeleteTx(context.Context, api.ResourceId) ([]string, error)
 	ListByUserId(context.Context, api.UserId, api.ResourceType) ([]model.ShortResourceInfo, error)
+	ListByCollection(context.Context, uuid.UUID, api.ResourceType) ([]model.ShortResourceInfo, error)
+	ListAttachments(context.Context, api.ResourceId) ([]model.ShortResourceInfo, error)
 	Get(context.Context, api.ResourceId, api.ResourceType, api.UserId) (*model.Resource, error)
+	GetById(context.Context, api.ResourceId, api.ResourceType) (*model.Resource, error)
+	Usage(context.Context, api.UserId) (model.Usage, error)
+	ListFiles(context.Context) ([]model.Resource, error)
+	Sync(context.Context, api.UserId, int64, int) ([]model.Change, int64, error)
+	Update(context.Context, *model.Resource, int64) error
+	SaveConflict(context.Context, *model.Conflict) error
+	ListConflicts(context.Context, api.UserId) ([]model.Conflict, error)
+	GetConflict(context.Context, uuid.UUID) (*model.Conflict, error)
+	DeleteConflict(context.Context, uuid.UUID) error
+	Audience(context.Context, *model.Resource) ([]api.UserId, error)
 }
 
+type ChangeBroker interface {
+	Publish(context.Context, events.Change) error
+	Subscribe(api.UserId) (<-chan events.Change, func())
+}
+
+type FileStoreConfig struct {
+	Path         string
+	Compress     bool
+	MaxChunkSize int
+}
+
+const defaultSyncLimit = 500
+const maxSyncLimit = 1000
+
 type ResourceService struct {
-	store         ResourceStore
-	fileStorePath string
+	store     ResourceStore
+	uploads   UploadStore
+	shares    ShareStore
+	orgs      OrgStore
+	emergency EmergencyStore
+	changes   ChangeBroker
+	fileStore FileStoreConfig
+	quota     Quota
 }
 
-func NewResourceStoreService(store ResourceStore, fileStorePath string) *ResourceService {
-	return &ResourceService{store: store, fileStorePath: fileStorePath}
+func NewResourceStoreService(
+	store ResourceStore,
+	uploads UploadStore,
+	shares ShareStore,
+	orgs OrgStore,
+	emergency EmergencyStore,
+	changes ChangeBroker,
+	fileStore FileStoreConfig,
+	quota Quota,
+) *ResourceService {
+	return &ResourceService{
+		store:     store,
+		uploads:   uploads,
+		shares:    shares,
+		orgs:      orgs,
+		emergency: emergency,
+		changes:   changes,
+		fileStore: fileStore,
+		quota:     quota,
+	}
 }
 
 func (s *ResourceService) Save(ctx context.Context, data *model.Resource) error {
-	return s.store.Save(ctx, data)
+	if err := validate(data); err != nil {
+		return err
+	}
+	if err := s.checkCollection(ctx, data.UserId, data.CollectionId); err != nil {
+		return err
+	}
+	data.Size = int64(len(data.Data) + len(data.Meta))
+	err := s.store.Save(ctx, data, func(usage model.Usage) error {
+		return s.quota.checkSave(usage, data.Size)
+	})
+	if err != nil {
+		return err
+	}
+	s.notify(ctx, api.ChangeCreated, data)
+	return nil
 }
 
+// Delete deletes the resource together with its attachments, a recipient of a shared resource only gives up the access.
 func (s *ResourceService) Delete(ctx context.Context, id api.ResourceId, userId api.UserId) error {
-	resource, err := s.store.Get(ctx, id, api.Undefined, userId)
+	resource, err := s.store.GetById(ctx, id, api.Undefined)
 	if err != nil {
 		return err
 	}
-	if resource.Type == api.File {
-		return s.store.DeleteTx(ctx, id, userId, func() error {
-			return os.Remove(string(resource.Data))
-		})
+	role, err := s.access(ctx, resource, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, grantErr := s.shares.GetGrant(ctx, id, userId); grantErr != nil {
+			return err
+		}
+		if err := s.shares.Unshare(ctx, id, userId); err != nil {
+			return err
+		}
+		publish(ctx, s.changes, api.ChangeDeleted, resource, userId)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if role < api.Member {
+		return reservederrors.ErrPermissionDenied
 	}
-	return s.store.Delete(ctx, id, userId)
+	// recipients lose the shares with the resource, so they are found before the delete
+	audience, err := s.store.Audience(ctx, resource)
+	if err != nil {
+		return err
+	}
+	released, err := s.store.DeleteTx(ctx, id)
+	if err != nil {
+		return err
+	}
+	publish(ctx, s.changes, api.ChangeDeleted, resource, audience...)
+	// blobs are removed only after the commit, a rolled back delete still references them,
+	// files left behind on errors are collected by the maintenance
+	for i := 0; i < len(released); i++ {
+		if err := os.Remove(released[i]); err != nil && !errors.Is(err, os.ErrNotExist) {
+			Log.Error("error on remove released blob", zap.String("path", released[i]), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// Update replaces the resource if nobody updated it since the base revision and returns the new revision.
+// Otherwise the update is kept as a conflict for the user to resolve and the id of the conflict is returned.
+func (s *ResourceService) Update(ctx context.Context, userId api.UserId, data *model.Resource, baseRevision int64) (int64, uuid.NullUUID, error) {
+	resource, err := s.writable(ctx, data.Id, userId)
+	if err != nil {
+		return 0, uuid.NullUUID{}, err
+	}
+	err = s.update(ctx, userId, resource, data, baseRevision)
+	if !errors.Is(err, reservederrors.ErrRevisionConflict) {
+		return data.Revision, uuid.NullUUID{}, err
+	}
+	conflict := &model.Conflict{
+		Id:           uuid.New(),
+		ResourceId:   resource.Id,
+		UserId:       userId,
+		Data:         data.Data,
+		Meta:         data.Meta,
+		BaseRevision: baseRevision,
+	}
+	if err := s.store.SaveConflict(ctx, conflict); err != nil {
+		return 0, uuid.NullUUID{}, err
+	}
+	return resource.Revision, uuid.NullUUID{UUID: conflict.Id, Valid: true}, nil
+}
+
+// ListConflicts lists conflicts made by the user or on resources of the user with the current versions of the resources.
+// Conflicts on resources deleted meanwhile or the user can't change anymore are left out.
+func (s *ResourceService) ListConflicts(ctx context.Context, userId api.UserId) ([]model.Conflict, []*model.Resource, error) {
+	conflicts, err := s.store.ListConflicts(ctx, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+	results := make([]model.Conflict, 0, len(conflicts))
+	current := make([]*model.Resource, 0, len(conflicts))
+	for i := 0; i < len(conflicts); i++ {
+		resource, err := s.writable(ctx, conflicts[i].ResourceId, userId)
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, reservederrors.ErrPermissionDenied) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		results = append(results, conflicts[i])
+		current = append(current, resource)
+	}
+	return results, current, nil
 }
 
-func (s *ResourceService) ListByUserId(ctx context.Context, userId api.UserId, resourceType api.ResourceType) ([]model.ShortResourceInfo, error) {
-	return s.store.ListByUserId(ctx, userId, resourceType)
+// ResolveConflict drops the conflict, the resolved version replaces the resource unless it is nil, i.e. the current version is kept.
+// ErrRevisionConflict is returned when the resource was updated again since the base revision.
+func (s *ResourceService) ResolveConflict(ctx context.Context, userId api.UserId, conflictId uuid.UUID, resolved *model.Resource, baseRevision int64) error {
+	conflict, err := s.store.GetConflict(ctx, conflictId)
+	if err != nil {
+		return err
+	}
+	resource, err := s.writable(ctx, conflict.ResourceId, userId)
+	if err != nil {
+		return err
+	}
+	if resolved != nil {
+		resolved.Id = resource.Id
+		if err := s.update(ctx, userId, resource, resolved, baseRevision); err != nil {
+			return err
+		}
+	}
+	return s.store.DeleteConflict(ctx, conflictId)
 }
 
+// writable gets the resource the user may change: own resource or resource of a collection the user is a member of.
+func (s *ResourceService) writable(ctx context.Context, id api.ResourceId, userId api.UserId) (*model.Resource, error) {
+	resource, err := s.store.GetById(ctx, id, api.Undefined)
+	if err != nil {
+		return nil, err
+	}
+	role, err := s.access(ctx, resource, userId)
+	if err != nil {
+		return nil, err
+	}
+	if role < api.Member {
+		return nil, reservederrors.ErrPermissionDenied
+	}
+	return resource, nil
+}
+
+func (s *ResourceService) update(ctx context.Context, userId api.UserId, resource *model.Resource, data *model.Resource, baseRevision int64) error {
+	if resource.Type == api.File || data.Type != resource.Type {
+		return invalid("only the payload of the same type can be updated")
+	}
+	if err := validate(data); err != nil {
+		return err
+	}
+	// the shared copy follows the resource, so recipients and the owner see the same version
+	_, err := s.shares.GetItem(ctx, resource.Id)
+	if errors.Is(err, reservederrors.ErrNotShared) {
+		data.Shared = nil
+	} else if err != nil {
+		return err
+	} else if data.Shared == nil {
+		return invalid("the shared copy has to be updated with the shared resource")
+	}
+	data.UserId = resource.UserId
+	data.CollectionId = resource.CollectionId
+	data.Size = int64(len(data.Data) + len(data.Meta))
+	usage, err := s.store.Usage(ctx, resource.UserId)
+	if err != nil {
+		return err
+	}
+	if err := s.quota.checkUpdate(usage, data.Size-resource.Size); err != nil {
+		return err
+	}
+	if err := s.store.Update(ctx, data, baseRevision); err != nil {
+		return err
+	}
+	s.notify(ctx, api.ChangeUpdated, data)
+	return nil
+}
+
+// Watch returns changes of resources the user can see and the function to stop watching.
+func (s *ResourceService) Watch(userId api.UserId) (<-chan events.Change, func()) {
+	return s.changes.Subscribe(userId)
+}
+
+// Sync returns changes of resources the user can see after the sequence since and the sequence to sync from next time,
+// more is set when changes were left out because of the limit.
+func (s *ResourceService) Sync(ctx context.Context, userId api.UserId, since int64, limit int) ([]model.Change, int64, bool, error) {
+	if since < 0 {
+		return nil, 0, false, invalid("since must not be negative")
+	}
+	limit = syncLimit(limit)
+	changes, highWaterMark, err := s.store.Sync(ctx, userId, since, limit)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	return changes, highWaterMark, len(changes) == limit, nil
+}
+
+func syncLimit(limit int) int {
+	if limit <= 0 {
+		return defaultSyncLimit
+	}
+	if limit > maxSyncLimit {
+		return maxSyncLimit
+	}
+	return limit
+}
+
+// notify tells watchers of users who can see the resource about the change.
+func (s *ResourceService) notify(ctx context.Context, kind api.ChangeKind, resource *model.Resource) {
+	audience, err := s.store.Audience(ctx, resource)
+	if err != nil {
+		Log.Error("error on get users of resource change", zap.String("id", resource.Id.String()), zap.Error(err))
+		return
+	}
+	publish(ctx, s.changes, kind, resource, audience...)
+}
+
+// publish tells watchers of the users about the change, the change is already stored, so errors are only logged.
+func publish(ctx context.Context, changes ChangeBroker, kind api.ChangeKind, resource *model.Resource, userIds ...api.UserId) {
+	for i := 0; i < len(userIds); i++ {
+		change := events.Change{UserId: userIds[i], ResourceId: resource.Id, Type: resource.Type, Kind: kind}
+		if err := changes.Publish(ctx, change); err != nil {
+			Log.Error("error on publish resource change", zap.String("id", resource.Id.String()), zap.Error(err))
+		}
+	}
+}
+
+// ListByUserId lists own resources of the type followed by the ones shared with the user,
+// resources of the collection are listed instead when collectionId is valid
+// and resources of the owner who gave the user emergency access when ownerId is valid.
+func (s *ResourceService) ListByUserId(ctx context.Context, userId api.UserId, resourceType api.ResourceType, collectionId uuid.NullUUID, ownerId uuid.NullUUID) ([]model.ShortResourceInfo, error) {
+	if ownerId.Valid && ownerId.UUID != userId {
+		granted, err := s.emergency.IsGranted(ctx, ownerId.UUID, userId)
+		if err != nil {
+			return nil, err
+		}
+		if !granted {
+			return nil, reservederrors.ErrPermissionDenied
+		}
+		return s.store.ListByUserId(ctx, ownerId.UUID, resourceType)
+	}
+	if collectionId.Valid {
+		if _, err := s.orgs.CollectionRole(ctx, collectionId.UUID, userId); err != nil {
+			return nil, err
+		}
+		return s.store.ListByCollection(ctx, collectionId.UUID, resourceType)
+	}
+	results, err := s.store.ListByUserId(ctx, userId, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := s.shares.ListShared(ctx, userId, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	return append(results, shared...), nil
+}
+
+// Get gets own resource, resource of the user's collection or the one shared with the user.
+// Recipients get only the encrypted shared copy.
 func (s *ResourceService) Get(ctx context.Context, resourceId api.ResourceId, userId api.UserId, rType api.ResourceType) (*model.Resource, error) {
-	return s.store.Get(ctx, resourceId, rType, userId)
+	resource, err := s.store.GetById(ctx, resourceId, rType)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.access(ctx, resource, userId)
+	if err == nil {
+		if resource.UserId != userId {
+			return resource, nil
+		}
+		return resource, s.addOwnShare(ctx, resource)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	grant, grantErr := s.shares.GetGrant(ctx, resourceId, userId)
+	if errors.Is(grantErr, reservederrors.ErrNotShared) {
+		return nil, err
+	}
+	if grantErr != nil {
+		return nil, grantErr
+	}
+	item, err := s.shares.GetItem(ctx, resourceId)
+	if err != nil {
+		return nil, err
+	}
+	resource.Data = nil
+	resource.Shared = &model.Shared{
+		OwnerLogin: grant.OwnerLogin,
+		Permission: grant.Permission,
+		WrappedKey: grant.WrappedKey,
+		Data:       item.Data,
+	}
+	return resource, nil
+}
+
+// access returns the role of the user for the resource: owners have full access to personal resources,
+// trusted contacts with granted emergency access can read them and members of the organization
+// get their role for resources of its collections.
+// sql.ErrNoRows is returned when the user has no access, so the resource stays hidden.
+func (s *ResourceService) access(ctx context.Context, resource *model.Resource, userId api.UserId) (api.Role, error) {
+	if !resource.CollectionId.Valid {
+		if resource.UserId == userId {
+			return api.Owner, nil
+		}
+		granted, err := s.emergency.IsGranted(ctx, resource.UserId, userId)
+		if err != nil {
+			return api.ReadOnly, err
+		}
+		if !granted {
+			return api.ReadOnly, sql.ErrNoRows
+		}
+		return api.ReadOnly, nil
+	}
+	role, err := s.orgs.CollectionRole(ctx, resource.CollectionId.UUID, userId)
+	if errors.Is(err, reservederrors.ErrNotMember) {
+		return role, sql.ErrNoRows
+	}
+	return role, err
+}
+
+// checkCollection checks that the user can add resources to the collection.
+func (s *ResourceService) checkCollection(ctx context.Context, userId api.UserId, collectionId uuid.NullUUID) error {
+	if !collectionId.Valid {
+		return nil
+	}
+	role, err := s.orgs.CollectionRole(ctx, collectionId.UUID, userId)
+	if errors.Is(err, reservederrors.ErrNotMember) {
+		return reservederrors.ErrPermissionDenied
+	}
+	if err != nil {
+		return err
+	}
+	if role < api.Member {
+		return reservederrors.ErrPermissionDenied
+	}
+	return nil
+}
+
+func (s *ResourceService) addOwnShare(ctx context.Context, resource *model.Resource) error {
+	if resource.Type == api.File {
+		return nil
+	}
+	item, err := s.shares.GetItem(ctx, resource.Id)
+	if errors.Is(err, reservederrors.ErrNotShared) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	resource.Shared = &model.Shared{
+		Permission: api.ReadWrite,
+		WrappedKey: item.OwnerKey,
+		Data:       item.Data,
+		IsOwner:    true,
+	}
+	return nil
+}
+
+// ListAttachments lists files attached to the resource, recipients of a shared resource don't see them.
+func (s *ResourceService) ListAttachments(ctx context.Context, parentId api.ResourceId, userId api.UserId) ([]model.ShortResourceInfo, error) {
+	parent, err := s.store.GetById(ctx, parentId, api.Undefined)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.access(ctx, parent, userId); errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return s.store.ListAttachments(ctx, parentId)
+}
+
+// checkParent checks that the user can attach files to the parent resource
+// and returns the collection the attachments belong to.
+func (s *ResourceService) checkParent(ctx context.Context, userId api.UserId, parentId uuid.NullUUID) (uuid.NullUUID, error) {
+	if !parentId.Valid {
+		return uuid.NullUUID{}, nil
+	}
+	parent, err := s.store.GetById(ctx, parentId.UUID, api.Undefined)
+	if err == nil {
+		var role api.Role
+		role, err = s.access(ctx, parent, userId)
+		if err == nil && role < api.Member {
+			return uuid.NullUUID{}, reservederrors.ErrPermissionDenied
+		}
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.NullUUID{}, invalid("parent resource not found")
+	}
+	if err != nil {
+		return uuid.NullUUID{}, err
+	}
+	if parent.Type == api.File {
+		return uuid.NullUUID{}, invalid("files can't have attachments")
+	}
+	return parent.CollectionId, nil
 }
 
 type Close func()
 
-func (s *ResourceService) createFilePath(id api.ResourceId) string {
-	return s.fileStorePath + "/" + id.String()
+func (s *ResourceService) createTempFilePath(id uuid.UUID) string {
+	return s.fileStore.Path + "/" + id.String() + ".part"
 }
 
-func (s *ResourceService) SaveFile(ctx context.Context, userId api.UserId, meta []byte, chunkReceiver func() ([]byte, error)) (api.ResourceId, error) {
-	id := uuid.New()
-	path := s.createFilePath(id)
+func (s *ResourceService) createBlobPath(userId api.UserId, checksum []byte) string {
+	return s.fileStore.Path + "/" + userId.String() + "_" + hex.EncodeToString(checksum)
+}
 
-	resource := &model.Resource{
-		Id:     id,
-		UserId: userId,
-		Type:   api.File,
-		Data:   []byte(path),
-		Meta:   meta,
+// saveFileResource saves the file resource with content written to tmpPath. Blobs are shared between
+// files of the same user with the same content, so tmpPath is either moved to the store or dropped.
+// The quota is checked again on insert, other files of the user may have been saved since the upload started.
+func (s *ResourceService) saveFileResource(ctx context.Context, resource *model.Resource, tmpPath string) error {
+	blob := &model.Blob{
+		UserId:     resource.UserId,
+		Checksum:   resource.Checksum,
+		Path:       s.createBlobPath(resource.UserId, resource.Checksum),
+		Size:       resource.Size - int64(len(resource.Meta)),
+		Compressed: s.fileStore.Compress,
+	}
+	resource.Data = []byte(blob.Path)
+
+	isNewBlob := false
+	check := func(usage model.Usage) error {
+		return s.quota.checkSave(usage, resource.Size)
+	}
+	err := s.store.SaveFile(ctx, resource, blob, check, func(isNew bool) error {
+		isNewBlob = isNew
+		if !isNew {
+			return nil
+		}
+		if blob.Compressed {
+			return fileutil.Compress(tmpPath, blob.Path)
+		}
+		return os.Rename(tmpPath, blob.Path)
+	})
+	if err != nil {
+		if isNewBlob && !blob.Compressed {
+			_ = os.Rename(blob.Path, tmpPath)
+		}
+		if isNewBlob && blob.Compressed {
+			_ = os.Remove(blob.Path)
+		}
+		return err
+	}
+	s.notify(ctx, api.ChangeCreated, resource)
+	if !isNewBlob || blob.Compressed {
+		return os.Remove(tmpPath)
+	}
+	return nil
+}
+
+func (s *ResourceService) Usage(ctx context.Context, userId api.UserId) (model.Usage, error) {
+	return s.store.Usage(ctx, userId)
+}
+
+func (s *ResourceService) Quota() Quota {
+	return s.quota
+}
+
+// SaveFile saves the file received in chunks, the file is attached to parentId when it is valid.
+func (s *ResourceService) SaveFile(ctx context.Context, userId api.UserId, meta []byte, parentId uuid.NullUUID, chunkReceiver func() ([]byte, error)) (api.ResourceId, error) {
+	collectionId, err := s.checkParent(ctx, userId, parentId)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	usage, err := s.uploadUsage(ctx, userId, uuid.Nil)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if err := s.quota.checkSave(usage, int64(len(meta))); err != nil {
+		return uuid.Nil, err
 	}
+	limit, limitErr := s.quota.fileLimit(usage)
 
-	err := s.store.Save(ctx, resource)
+	id := uuid.New()
+	path := s.createTempFilePath(id)
 
+	var size int64
+	hash := sha256.New()
+	err = fileutil.Get(path, func() ([]byte, error) {
+		chunk, err := chunkReceiver()
+		if err != nil {
+			return nil, err
+		}
+		size += int64(len(chunk))
+		if limit >= 0 && size > limit {
+			return nil, limitErr
+		}
+		hash.Write(chunk)
+		return chunk, nil
+	})
 	if err != nil {
+		_ = os.Remove(path)
 		return uuid.Nil, err
 	}
 
-	return id, fileutil.Get(path, chunkReceiver)
+	resource := &model.Resource{
+		Id:           id,
+		UserId:       userId,
+		Type:         api.File,
+		Meta:         meta,
+		Size:         size + int64(len(meta)),
+		Checksum:     hash.Sum(nil),
+		ParentId:     parentId,
+		CollectionId: collectionId,
+	}
+
+	if err := s.saveFileResource(ctx, resource, path); err != nil {
+		_ = os.Remove(path)
+		return uuid.Nil, err
+	}
+
+	return id, nil
+}
+
+// ChunkSize negotiates the size of file chunks with the size requested by the client.
+func (s *ResourceService) ChunkSize(requested int) int {
+	max := s.fileStore.MaxChunkSize
+	if max <= 0 || max > fileutil.MaxChunkSize {
+		max = fileutil.MaxChunkSize
+	}
+	return fileutil.ClampChunkSize(requested, max)
+}
+
+// GetFile sends length bytes of the file starting at offset, zero length means the whole rest of the file.
+// The checksum can be verified only when the whole file is sent.
+func (s *ResourceService) GetFile(resource *model.Resource, offset int64, length int64, chunkSize int, chunkSender func([]byte) error) error {
+	hash := sha256.New()
+	err := fileutil.SendRange(string(resource.Data), resource.Compressed, offset, length, chunkSize, func(chunk []byte) error {
+		hash.Write(chunk)
+		return chunkSender(chunk)
+	})
+	if err != nil {
+		return err
+	}
+	isWhole := offset == 0 && length == 0
+	if isWhole && len(resource.Checksum) != 0 && !bytes.Equal(resource.Checksum, hash.Sum(nil)) {
+		Log.Error("stored file is corrupted", zap.String("id", resource.Id.String()))
+		return reservederrors.ErrChecksumMismatch
+	}
+	return nil
 }
 
-func (s *ResourceService) GetFile(resource *model.Resource, chunkSender func([]byte) error) error {
-	return fileutil.Send(string(resource.Data), chunkSender)
+// VerifyFiles recomputes checksums of all stored files and returns ids of the ones that don't match.
+func (s *ResourceService) VerifyFiles(ctx context.Context) ([]api.ResourceId, error) {
+	files, err := s.store.ListFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	corrupted := make([]api.ResourceId, 0)
+	for i := 0; i < len(files); i++ {
+		if len(files[i].Checksum) == 0 {
+			continue
+		}
+		checksum, err := fileutil.Checksum(string(files[i].Data), files[i].Compressed)
+		if err != nil || !bytes.Equal(checksum, files[i].Checksum) {
+			Log.Error("file verification failed", zap.String("id", files[i].Id.String()), zap.Error(err))
+			corrupted = append(corrupted, files[i].Id)
+		}
+	}
+	return corrupted, nil
 }
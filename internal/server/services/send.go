@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"secstorage/internal/api"
+	"secstorage/internal/server/storage/send/model"
+	"time"
+)
+
+const (
+	maxSendSize  = 1024 * 1024
+	maxSendViews = 100
+	maxSendTTL   = 30 * 24 * time.Hour
+	// sends are kept outside of the storage quota, so their number is limited instead
+	maxActiveSends = 20
+)
+
+type SendStore interface {
+	Create(context.Context, *model.Send, int) error
+	Open(context.Context, uuid.UUID) (*model.Send, error)
+	ListByUserId(context.Context, api.UserId) ([]model.Send, error)
+	Delete(context.Context, uuid.UUID, api.UserId) error
+}
+
+// SendService keeps encrypted payloads handed to people without an account. The key is kept
+// in the link fragment by the client, so the server can't read the payload.
+type SendService struct {
+	store SendStore
+}
+
+func NewSendService(store SendStore) *SendService {
+	return &SendService{store: store}
+}
+
+func (s *SendService) Create(ctx context.Context, userId api.UserId, data []byte, maxViews int, expiresAt time.Time) (uuid.UUID, error) {
+	if len(data) == 0 || len(data) > maxSendSize {
+		return uuid.Nil, invalid("send data must be from 1 byte to 1 MiB")
+	}
+	if maxViews < 1 || maxViews > maxSendViews {
+		return uuid.Nil, invalid("max views must be from 1 to 100")
+	}
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 || ttl > maxSendTTL {
+		return uuid.Nil, invalid("send must expire within 30 days")
+	}
+	send := &model.Send{
+		Id:        uuid.New(),
+		UserId:    userId,
+		Data:      data,
+		MaxViews:  maxViews,
+		ExpiresAt: expiresAt,
+	}
+	return send.Id, s.store.Create(ctx, send, maxActiveSends)
+}
+
+func (s *SendService) Open(ctx context.Context, id uuid.UUID) (*model.Send, error) {
+	return s.store.Open(ctx, id)
+}
+
+func (s *SendService) List(ctx context.Context, userId api.UserId) ([]model.Send, error) {
+	return s.store.ListByUserId(ctx, userId)
+}
+
+func (s *SendService) Delete(ctx context.Context, userId api.UserId, id uuid.UUID) error {
+	return s.store.Delete(ctx, id, userId)
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"secstorage/internal/api"
+	. "secstorage/internal/logger"
+	"secstorage/internal/server/reservederrors"
+	authModel "secstorage/internal/server/storage/auth/model"
+	"secstorage/internal/server/storage/resource/model"
+)
+
+const publicKeyLength = 32
+
+type KeyStore interface {
+	SetKeys(context.Context, api.UserId, authModel.KeyPair) error
+	GetKeys(context.Context, api.UserId) (*authModel.KeyPair, error)
+	UpdatePrivateKey(context.Context, api.UserId, authModel.KeyPair) error
+	GetPublicKey(context.Context, string) (*authModel.PublicKey, error)
+}
+
+type ShareStore interface {
+	Share(context.Context, *model.SharedItem, *model.Grant) error
+	Unshare(context.Context, api.ResourceId, api.UserId) error
+	UpdateData(context.Context, api.ResourceId, []byte) error
+	GetItem(context.Context, api.ResourceId) (*model.SharedItem, error)
+	GetGrant(context.Context, api.ResourceId, api.UserId) (*model.Grant, error)
+	ListGrants(context.Context, api.ResourceId) ([]model.Grant, error)
+	ListShared(context.Context, api.UserId, api.ResourceType) ([]model.ShortResourceInfo, error)
+}
+
+// ShareService shares resources between users. The server never sees item keys: the owner's client
+// encrypts the resource with a random item key and wraps that key with the public key of every recipient.
+type ShareService struct {
+	keys      KeyStore
+	shares    ShareStore
+	resources ResourceStore
+	changes   ChangeBroker
+}
+
+func NewShareService(keys KeyStore, shares ShareStore, resources ResourceStore, changes ChangeBroker) *ShareService {
+	return &ShareService{keys: keys, shares: shares, resources: resources, changes: changes}
+}
+
+func (s *ShareService) SetKeys(ctx context.Context, userId api.UserId, keys authModel.KeyPair) error {
+	if len(keys.PublicKey) != publicKeyLength || len(keys.EncryptedPrivateKey) == 0 {
+		return invalid("malformed key pair")
+	}
+	return s.keys.SetKeys(ctx, userId, keys)
+}
+
+func (s *ShareService) GetKeys(ctx context.Context, userId api.UserId) (*authModel.KeyPair, error) {
+	return s.keys.GetKeys(ctx, userId)
+}
+
+// UpdateKeys replaces the encrypted private key, e.g. after the password is changed.
+func (s *ShareService) UpdateKeys(ctx context.Context, userId api.UserId, keys authModel.KeyPair) error {
+	if len(keys.PublicKey) != publicKeyLength || len(keys.EncryptedPrivateKey) == 0 {
+		return invalid("malformed key pair")
+	}
+	return s.keys.UpdatePrivateKey(ctx, userId, keys)
+}
+
+func (s *ShareService) GetPublicKey(ctx context.Context, login string) (*authModel.PublicKey, error) {
+	return s.keys.GetPublicKey(ctx, login)
+}
+
+// Share grants the recipient access to the resource, data replaces the shared copy for all recipients.
+func (s *ShareService) Share(ctx context.Context, userId api.UserId, item *model.SharedItem, recipient string, wrappedKey []byte, permission api.Permission) error {
+	resource, err := s.checkOwner(ctx, item.ResourceId, userId)
+	if err != nil {
+		return err
+	}
+	if resource.CollectionId.Valid {
+		// members update the resource without the item key, the shared copy would fall behind
+		return invalid("resources of collections are shared through the organization")
+	}
+	if len(item.Data) == 0 || len(item.OwnerKey) == 0 || len(wrappedKey) == 0 {
+		return invalid("shared data and keys are required")
+	}
+	if permission != api.Read && permission != api.ReadWrite {
+		return invalid("unknown permission")
+	}
+	recipientKey, err := s.keys.GetPublicKey(ctx, recipient)
+	if err != nil {
+		return err
+	}
+	if recipientKey.UserId == userId {
+		return invalid("resource can't be shared with the owner")
+	}
+	err = s.shares.Share(ctx, item, &model.Grant{
+		ResourceId:  item.ResourceId,
+		RecipientId: recipientKey.UserId,
+		WrappedKey:  wrappedKey,
+		Permission:  permission,
+	})
+	if err != nil {
+		return err
+	}
+	publish(ctx, s.changes, api.ChangeCreated, resource, recipientKey.UserId)
+	s.notify(ctx, api.ChangeUpdated, resource, recipientKey.UserId)
+	return nil
+}
+
+func (s *ShareService) Unshare(ctx context.Context, userId api.UserId, resourceId api.ResourceId, recipient string) error {
+	resource, err := s.checkOwner(ctx, resourceId, userId)
+	if err != nil {
+		return err
+	}
+	recipientKey, err := s.keys.GetPublicKey(ctx, recipient)
+	if errors.Is(err, reservederrors.ErrKeysNotFound) {
+		return reservederrors.ErrNotShared
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.shares.Unshare(ctx, resourceId, recipientKey.UserId); err != nil {
+		return err
+	}
+	publish(ctx, s.changes, api.ChangeDeleted, resource, recipientKey.UserId)
+	return nil
+}
+
+// UpdateShared replaces the shared copy, allowed to the owner and recipients with read-write permission.
+func (s *ShareService) UpdateShared(ctx context.Context, userId api.UserId, resourceId api.ResourceId, data []byte) error {
+	if len(data) == 0 {
+		return invalid("shared data is required")
+	}
+	_, err := s.checkOwner(ctx, resourceId, userId)
+	if errors.Is(err, reservederrors.ErrPermissionDenied) {
+		grant, grantErr := s.shares.GetGrant(ctx, resourceId, userId)
+		if grantErr != nil {
+			return grantErr
+		}
+		if grant.Permission != api.ReadWrite {
+			return reservederrors.ErrPermissionDenied
+		}
+		err = nil
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := s.shares.GetItem(ctx, resourceId); err != nil {
+		return err
+	}
+	if err := s.shares.UpdateData(ctx, resourceId, data); err != nil {
+		return err
+	}
+	resource, err := s.resources.GetById(ctx, resourceId, api.Undefined)
+	if err != nil {
+		return err
+	}
+	s.notify(ctx, api.ChangeUpdated, resource, uuid.Nil)
+	return nil
+}
+
+func (s *ShareService) ListGrants(ctx context.Context, userId api.UserId, resourceId api.ResourceId) ([]model.Grant, error) {
+	if _, err := s.checkOwner(ctx, resourceId, userId); err != nil {
+		return nil, err
+	}
+	return s.shares.ListGrants(ctx, resourceId)
+}
+
+func (s *ShareService) checkOwner(ctx context.Context, resourceId api.ResourceId, userId api.UserId) (*model.Resource, error) {
+	resource, err := s.resources.Get(ctx, resourceId, api.Undefined, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, reservederrors.ErrPermissionDenied
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resource.Type == api.File {
+		return nil, invalid("files can't be shared")
+	}
+	return resource, nil
+}
+
+// notify tells watchers of users who can see the resource, except the given one, about the change of the shared copy.
+func (s *ShareService) notify(ctx context.Context, kind api.ChangeKind, resource *model.Resource, except api.UserId) {
+	audience, err := s.resources.Audience(ctx, resource)
+	if err != nil {
+		Log.Error("error on get users of resource change", zap.String("id", resource.Id.String()), zap.Error(err))
+		return
+	}
+	for i := 0; i < len(audience); i++ {
+		if audience[i] != except {
+			publish(ctx, s.changes, kind, resource, audience[i])
+		}
+	}
+}
@@ -8,53 +8,119 @@ import (
 	"google.golang.org/grpc/metadata"
 	"secstorage/internal/api"
 	"secstorage/internal/server/reservederrors"
+	"secstorage/internal/server/storage/auth/model"
 	"time"
 )
 
+// SessionStore reports the state of users, tokens of disabled or logged out users are rejected.
+type SessionStore interface {
+	GetSession(context.Context, api.UserId) (*model.Session, error)
+}
+
 type TokenService struct {
-	key string
+	key      string
+	sessions SessionStore
 }
 
 type authClaims struct {
 	Id api.UserId `json:"id"`
+	// Version is the token version of the user at issue time, tokens of an older version are revoked
+	Version int64 `json:"ver"`
 	jwt.RegisteredClaims
 }
 
 func NewTokenService(key string) *TokenService {
-	return &TokenService{key}
+	return &TokenService{key: key}
 }
 
-func (s *TokenService) Generate(id uuid.UUID, expireAt time.Time) (string, error) {
+func (s *TokenService) SetSessionStore(sessions SessionStore) {
+	s.sessions = sessions
+}
+
+func (s *TokenService) Generate(ctx context.Context, id uuid.UUID, expireAt time.Time) (string, error) {
+	var version int64
+	if s.sessions != nil {
+		session, err := s.sessions.GetSession(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		version = session.TokenVersion
+	}
 	claims := &authClaims{
-		Id:               id,
-		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(expireAt)},
+		Id:      id,
+		Version: version,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expireAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
 	}
 
 	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.key))
 }
 
 func (s *TokenService) Extract(tokenStr string) (api.UserId, error) {
+	claims, err := s.extractClaims(tokenStr)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return claims.Id, nil
+}
+
+func (s *TokenService) extractClaims(tokenStr string) (*authClaims, error) {
 	token, err := jwt.ParseWithClaims(tokenStr, &authClaims{}, func(token *jwt.Token) (interface{}, error) {
 		return []byte(s.key), nil
 	})
 
 	if claims, ok := token.Claims.(*authClaims); ok && token.Valid {
+		return claims, nil
+	}
+	return nil, err
+}
+
+// Authorize returns the id of the caller if the token is still valid for the user,
+// admin requires the caller to be an admin.
+func (s *TokenService) Authorize(ctx context.Context, admin bool) (api.UserId, error) {
+	claims, err := s.claimsGRPC(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if s.sessions == nil {
+		if admin {
+			return uuid.Nil, reservederrors.ErrPermissionDenied
+		}
 		return claims.Id, nil
 	}
-	return uuid.Nil, err
+
+	session, err := s.sessions.GetSession(ctx, claims.Id)
+	if errors.Is(err, reservederrors.ErrUserNotFound) {
+		return uuid.Nil, reservederrors.ErrTokenInvalid
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if session.Disabled {
+		return uuid.Nil, reservederrors.ErrUserDisabled
+	}
+	if claims.Version != session.TokenVersion {
+		return uuid.Nil, reservederrors.ErrTokenInvalid
+	}
+	if admin && !session.IsAdmin {
+		return uuid.Nil, reservederrors.ErrPermissionDenied
+	}
+	return claims.Id, nil
 }
 
-func (s *TokenService) GetUserIdGRPC(ctx context.Context) (api.UserId, error) {
+func (s *TokenService) claimsGRPC(ctx context.Context) (*authClaims, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return uuid.Nil, errors.New("can't read md")
+		return nil, errors.New("can't read md")
 	}
 	var tokenStr string
 	if values := md.Get("token"); len(values) == 0 {
-		return uuid.Nil, reservederrors.ErrTokenNotFound
+		return nil, reservederrors.ErrTokenNotFound
 	} else {
 		tokenStr = values[0]
 	}
 
-	return s.Extract(tokenStr)
+	return s.extractClaims(tokenStr)
 }
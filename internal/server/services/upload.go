@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"secstorage/internal/api"
+	"secstorage/internal/fileutil"
+	"secstorage/internal/server/reservederrors"
+	"secstorage/internal/server/storage/resource/model"
+	uploadModel "secstorage/internal/server/storage/upload/model"
+	"time"
+)
+
+type UploadStore interface {
+	Create(context.Context, *uploadModel.Session) error
+	Get(context.Context, uuid.UUID, api.UserId) (*uploadModel.Session, error)
+	SetCommitted(context.Context, uuid.UUID, int64, int64, func() error) error
+	Uploading(context.Context, api.UserId, uuid.UUID) (int64, error)
+	Delete(context.Context, uuid.UUID) error
+	ListPaths(context.Context) ([]string, error)
+	DeleteExpired(context.Context, time.Duration) ([]string, error)
+}
+
+func (s *ResourceService) InitUpload(ctx context.Context, userId api.UserId, meta []byte, parentId uuid.NullUUID) (*uploadModel.Session, error) {
+	if _, err := s.checkParent(ctx, userId, parentId); err != nil {
+		return nil, err
+	}
+	usage, err := s.uploadUsage(ctx, userId, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.quota.checkSave(usage, int64(len(meta))); err != nil {
+		return nil, err
+	}
+
+	id := uuid.New()
+	session := &uploadModel.Session{
+		Id:       id,
+		UserId:   userId,
+		Meta:     meta,
+		Path:     s.createTempFilePath(id),
+		ParentId: parentId,
+	}
+	if err := s.uploads.Create(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// UploadChunk writes data at offset, which must be equal to the committed offset of the session,
+// and returns the new committed offset.
+func (s *ResourceService) UploadChunk(ctx context.Context, userId api.UserId, sessionId uuid.UUID, offset int64, data []byte) (int64, error) {
+	session, err := s.uploads.Get(ctx, sessionId, userId)
+	if err != nil {
+		return 0, err
+	}
+	if offset != session.Committed {
+		return session.Committed, reservederrors.ErrOffsetMismatch
+	}
+	if len(data) > s.ChunkSize(fileutil.MaxChunkSize) {
+		return session.Committed, reservederrors.ErrChunkTooLarge
+	}
+
+	usage, err := s.uploadUsage(ctx, userId, sessionId)
+	if err != nil {
+		return 0, err
+	}
+	limit, limitErr := s.quota.fileLimit(usage)
+	committed := offset + int64(len(data))
+	if limit >= 0 && committed > limit {
+		return session.Committed, limitErr
+	}
+
+	err = s.uploads.SetCommitted(ctx, sessionId, offset, committed, func() error {
+		return fileutil.WriteAt(session.Path, offset, data)
+	})
+	if err != nil {
+		return session.Committed, err
+	}
+	return committed, nil
+}
+
+// uploadUsage counts bytes of open upload sessions except the given one as used, partial files take the disk
+// until the sessions are completed or expire.
+func (s *ResourceService) uploadUsage(ctx context.Context, userId api.UserId, except uuid.UUID) (model.Usage, error) {
+	usage, err := s.store.Usage(ctx, userId)
+	if err != nil {
+		return usage, err
+	}
+	uploading, err := s.uploads.Uploading(ctx, userId, except)
+	if err != nil {
+		return usage, err
+	}
+	usage.Bytes += uploading
+	return usage, nil
+}
+
+func (s *ResourceService) UploadOffset(ctx context.Context, userId api.UserId, sessionId uuid.UUID) (int64, error) {
+	session, err := s.uploads.Get(ctx, sessionId, userId)
+	if err != nil {
+		return 0, err
+	}
+	return session.Committed, nil
+}
+
+func (s *ResourceService) CompleteUpload(ctx context.Context, userId api.UserId, sessionId uuid.UUID) (api.ResourceId, error) {
+	session, err := s.uploads.Get(ctx, sessionId, userId)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if session.Committed == 0 {
+		// nothing was uploaded, but the file still has to exist
+		if err := fileutil.WriteAt(session.Path, 0, nil); err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	collectionId, err := s.checkParent(ctx, userId, session.ParentId)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	checksum, err := fileutil.Checksum(session.Path, false)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	id := uuid.New()
+	resource := &model.Resource{
+		Id:           id,
+		UserId:       userId,
+		Type:         api.File,
+		Meta:         session.Meta,
+		Size:         session.Committed + int64(len(session.Meta)),
+		Checksum:     checksum,
+		ParentId:     session.ParentId,
+		CollectionId: collectionId,
+	}
+	if err := s.saveFileResource(ctx, resource, session.Path); err != nil {
+		return uuid.Nil, err
+	}
+
+	return id, s.uploads.Delete(ctx, sessionId)
+}
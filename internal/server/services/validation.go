@@ -0,0 +1,264 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/ssh"
+	"net/url"
+	"regexp"
+	"secstorage/internal/api"
+	"secstorage/internal/otp"
+	"secstorage/internal/server/reservederrors"
+	"secstorage/internal/server/storage/resource/model"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+var untilPattern = regexp.MustCompile(`^(0[1-9]|1[0-2])/[0-9]{2}$`)
+
+const maxNoteTitleLength = 256
+const maxNoteBodyLength = 64 * 1024
+const maxFieldCount = 100
+const maxFieldNameLength = 256
+const maxFieldValueLength = 64 * 1024
+
+func invalid(reason string) error {
+	return fmt.Errorf("%w: %v", reservederrors.ErrInvalidResource, reason)
+}
+
+// validate checks the json payload of the resource against the rules of its type.
+func validate(resource *model.Resource) error {
+	if resource.Type != api.File {
+		var fields model.CustomFields
+		if err := json.Unmarshal(resource.Data, &fields); err != nil {
+			return invalid("malformed custom fields")
+		}
+		if err := validateFields(fields.Fields); err != nil {
+			return err
+		}
+	}
+
+	switch resource.Type {
+	case api.LoginPassword:
+		var lp model.LoginPassword
+		if err := json.Unmarshal(resource.Data, &lp); err != nil {
+			return invalid("malformed login password")
+		}
+		return validateLoginPassword(&lp)
+
+	case api.BankCard:
+		var bc model.BankCard
+		if err := json.Unmarshal(resource.Data, &bc); err != nil {
+			return invalid("malformed bank card")
+		}
+		return validateBankCard(&bc)
+
+	case api.SecureNote:
+		var sn model.SecureNote
+		if err := json.Unmarshal(resource.Data, &sn); err != nil {
+			return invalid("malformed secure note")
+		}
+		return validateSecureNote(&sn)
+
+	case api.OTP:
+		var key model.OTP
+		if err := json.Unmarshal(resource.Data, &key); err != nil {
+			return invalid("malformed otp")
+		}
+		return validateOTP(&key)
+
+	case api.SSHKey:
+		var key model.SSHKey
+		if err := json.Unmarshal(resource.Data, &key); err != nil {
+			return invalid("malformed ssh key")
+		}
+		return validateSSHKey(&key)
+
+	case api.Template:
+		var template model.Template
+		if err := json.Unmarshal(resource.Data, &template); err != nil {
+			return invalid("malformed template")
+		}
+		return validateTemplate(&template)
+
+	case api.File:
+		return invalid("files must be saved with SaveFile")
+	}
+	return invalid(fmt.Sprintf("unknown type %v", resource.Type))
+}
+
+func validateFields(fields []model.CustomField) error {
+	if len(fields) > maxFieldCount {
+		return invalid("too many custom fields")
+	}
+	names := make(map[string]bool, len(fields))
+	for i := 0; i < len(fields); i++ {
+		field := &fields[i]
+		if len(strings.TrimSpace(field.Name)) == 0 {
+			return invalid("custom field name is empty")
+		}
+		if len(field.Name) > maxFieldNameLength {
+			return invalid("custom field name is too long")
+		}
+		if names[field.Name] {
+			return invalid(fmt.Sprintf("duplicate custom field %v", field.Name))
+		}
+		names[field.Name] = true
+		if len(field.Value) > maxFieldValueLength {
+			return invalid(fmt.Sprintf("custom field %v is too long", field.Name))
+		}
+		if len(field.Value) == 0 {
+			continue
+		}
+
+		switch field.Type {
+		case api.Text, api.Hidden:
+		case api.URL:
+			if !isURL(field.Value) {
+				return invalid(fmt.Sprintf("custom field %v is not a URL", field.Name))
+			}
+		case api.Date:
+			if _, err := time.Parse(dateLayout, field.Value); err != nil {
+				return invalid(fmt.Sprintf("custom field %v must be a date in YYYY-MM-DD format", field.Name))
+			}
+		default:
+			return invalid(fmt.Sprintf("custom field %v has unknown type %v", field.Name, field.Type))
+		}
+	}
+	return nil
+}
+
+func validateTemplate(template *model.Template) error {
+	if len(strings.TrimSpace(template.Name)) == 0 {
+		return invalid("template name is empty")
+	}
+	switch template.ResourceType {
+	case api.LoginPassword, api.BankCard, api.SecureNote, api.OTP, api.SSHKey:
+		return nil
+	}
+	return invalid(fmt.Sprintf("templates are not supported for type %v", template.ResourceType))
+}
+
+func validateLoginPassword(lp *model.LoginPassword) error {
+	if len(lp.Login) == 0 && len(lp.Password) == 0 {
+		return invalid("login and password are empty")
+	}
+	for _, u := range lp.URLs {
+		if !isURL(u) {
+			return invalid(fmt.Sprintf("%v is not a URL", u))
+		}
+	}
+	if len(lp.Notes) > maxNoteBodyLength {
+		return invalid("notes are too long")
+	}
+	if lp.TOTP != nil {
+		return validateOTP(lp.TOTP)
+	}
+	return nil
+}
+
+func isURL(s string) bool {
+	u, err := url.ParseRequestURI(s)
+	return err == nil && len(u.Scheme) != 0
+}
+
+func validateSecureNote(sn *model.SecureNote) error {
+	if len(strings.TrimSpace(sn.Title)) == 0 {
+		return invalid("note title is empty")
+	}
+	if len(sn.Title) > maxNoteTitleLength {
+		return invalid("note title is too long")
+	}
+	if len(sn.Body) > maxNoteBodyLength {
+		return invalid("note body is too long")
+	}
+	return nil
+}
+
+func validateOTP(key *model.OTP) error {
+	otpKey := otp.Key{
+		Secret:    key.Secret,
+		Digits:    key.Digits,
+		Period:    key.Period,
+		Algorithm: key.Algorithm,
+	}
+	if err := otpKey.Validate(); err != nil {
+		return invalid(err.Error())
+	}
+	return nil
+}
+
+// validateSSHKey checks that the private key can be parsed and matches the public key if both are known.
+// Keys encrypted with a passphrase that isn't stored are accepted, clients ask for it on use.
+func validateSSHKey(key *model.SSHKey) error {
+	if len(strings.TrimSpace(key.PrivateKey)) == 0 {
+		return invalid("private key is empty")
+	}
+	var publicKey ssh.PublicKey
+	var signer ssh.Signer
+	var err error
+	if len(key.Passphrase) != 0 {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(key.PrivateKey), []byte(key.Passphrase))
+	} else {
+		signer, err = ssh.ParsePrivateKey([]byte(key.PrivateKey))
+	}
+	var missing *ssh.PassphraseMissingError
+	switch {
+	case errors.As(err, &missing):
+		publicKey = missing.PublicKey
+	case err != nil:
+		return invalid("malformed private key")
+	default:
+		publicKey = signer.PublicKey()
+	}
+
+	if len(key.PublicKey) == 0 {
+		return nil
+	}
+	parsed, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key.PublicKey))
+	if err != nil {
+		return invalid("malformed public key")
+	}
+	if publicKey != nil && !bytes.Equal(parsed.Marshal(), publicKey.Marshal()) {
+		return invalid("public key doesn't match private key")
+	}
+	return nil
+}
+
+func validateBankCard(bc *model.BankCard) error {
+	number := strings.ReplaceAll(bc.Number, " ", "")
+	if len(number) < 12 || len(number) > 19 {
+		return invalid("card number must have 12-19 digits")
+	}
+	if !isLuhnValid(number) {
+		return invalid("card number fails Luhn check")
+	}
+	if !untilPattern.MatchString(bc.Until) {
+		return invalid("expiry must be in MM/YY format")
+	}
+	return nil
+}
+
+func isLuhnValid(number string) bool {
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		if number[i] < '0' || number[i] > '9' {
+			return false
+		}
+		digit := int(number[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
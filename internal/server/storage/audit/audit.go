@@ -0,0 +1,130 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"secstorage/internal/api"
+	"secstorage/internal/server/storage"
+	"secstorage/internal/server/storage/audit/model"
+)
+
+// appendLock serializes appends, so every event is chained to the latest one
+const appendLock = 0x61756469
+
+// selectEvent shows the login of the user for events that don't have their own
+const selectEvent = `select a.seq, a.user_id, coalesce(nullif(a.login, ''), u.login, '') as login, a.action, a.target_id, a.owner_id,
+	a.details, a.created_at, a.prev_hash, a.hash
+	from audit_events a
+	left join users u on u.id = a.user_id`
+
+type Storage struct {
+	ctx context.Context
+	db  *sqlx.DB
+}
+
+func NewStorage(ctx context.Context, db *sqlx.DB) *Storage {
+	return &Storage{ctx: ctx, db: db}
+}
+
+// Append saves the event with the hash computed from the hash of the latest event.
+func (s *Storage) Append(ctx context.Context, event *model.Event, hash func(prev []byte) []byte) error {
+	return storage.RunInTx(
+		func(tx *sqlx.Tx) error {
+			_, err := tx.ExecContext(ctx, "select pg_advisory_xact_lock($1)", appendLock)
+			return err
+		},
+		func(tx *sqlx.Tx) error {
+			err := tx.GetContext(ctx, &event.PrevHash, "select hash from audit_events order by seq desc limit 1")
+			if errors.Is(err, sql.ErrNoRows) {
+				event.PrevHash = nil
+				return nil
+			}
+			return err
+		},
+		func(tx *sqlx.Tx) error {
+			event.Hash = hash(event.PrevHash)
+			return tx.GetContext(
+				ctx,
+				&event.Seq,
+				`insert into audit_events(user_id, login, action, target_id, owner_id, details, created_at, prev_hash, hash)
+				values ($1, $2, $3, $4, $5, $6, $7, $8, $9) returning seq`,
+				event.UserId,
+				event.Login,
+				event.Action,
+				event.TargetId,
+				event.OwnerId,
+				event.Details,
+				event.CreatedAt,
+				event.PrevHash,
+				event.Hash,
+			)
+		},
+	)
+}
+
+// Owner returns the owner of the resource or the grantor of the emergency access with the id.
+func (s *Storage) Owner(ctx context.Context, targetId uuid.UUID) (uuid.NullUUID, error) {
+	var result uuid.NullUUID
+	err := s.db.GetContext(
+		ctx,
+		&result,
+		"select user_id from resources where id = $1 union all select grantor_id from emergency_access where id = $1 limit 1",
+		targetId,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.NullUUID{}, nil
+	}
+	return result, err
+}
+
+func (s *Storage) UserIdByLogin(ctx context.Context, login string) (uuid.NullUUID, error) {
+	var result uuid.NullUUID
+	err := s.db.GetContext(ctx, &result, "select id from users where login = $1", login)
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.NullUUID{}, nil
+	}
+	return result, err
+}
+
+// ListByUserId lists events done by the user or on targets owned by the user, latest first.
+func (s *Storage) ListByUserId(ctx context.Context, userId api.UserId, before int64, limit int) ([]model.Event, error) {
+	var results []model.Event
+	err := s.db.SelectContext(
+		ctx,
+		&results,
+		selectEvent+" where (a.user_id = $1 or a.owner_id = $1) and ($2 = 0 or a.seq < $2) order by a.seq desc limit $3",
+		userId,
+		before,
+		limit,
+	)
+	return results, err
+}
+
+func (s *Storage) List(ctx context.Context, before int64, limit int) ([]model.Event, error) {
+	var results []model.Event
+	err := s.db.SelectContext(
+		ctx,
+		&results,
+		selectEvent+" where $1 = 0 or a.seq < $1 order by a.seq desc limit $2",
+		before,
+		limit,
+	)
+	return results, err
+}
+
+// ListAfter lists events as they are stored in order of the chain.
+func (s *Storage) ListAfter(ctx context.Context, after int64, limit int) ([]model.Event, error) {
+	var results []model.Event
+	err := s.db.SelectContext(
+		ctx,
+		&results,
+		`select seq, user_id, login, action, target_id, owner_id, details, created_at, prev_hash, hash
+		from audit_events where seq > $1 order by seq limit $2`,
+		after,
+		limit,
+	)
+	return results, err
+}
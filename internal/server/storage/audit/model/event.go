@@ -0,0 +1,20 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"secstorage/internal/api"
+	"time"
+)
+
+type Event struct {
+	Seq       int64           `db:"seq"`
+	UserId    uuid.NullUUID   `db:"user_id"`
+	Login     string          `db:"login"`
+	Action    api.AuditAction `db:"action"`
+	TargetId  uuid.NullUUID   `db:"target_id"`
+	OwnerId   uuid.NullUUID   `db:"owner_id"`
+	Details   string          `db:"details"`
+	CreatedAt time.Time       `db:"created_at"`
+	PrevHash  []byte          `db:"prev_hash"`
+	Hash      []byte          `db:"hash"`
+}
@@ -34,14 +34,183 @@ func (s *Storage) Register(ctx context.Context, user model.User) (uuid.UUID, err
 }
 
 func (s *Storage) Login(ctx context.Context, user model.User) (uuid.UUID, error) {
-	id := uuid.Nil
-	err := s.db.GetContext(ctx, &id, "select id from users where login = $1 and password = $2", user.Login, user.Password)
+	var result struct {
+		Id       uuid.UUID `db:"id"`
+		Disabled bool      `db:"disabled"`
+	}
+	err := s.db.GetContext(ctx, &result, "select id, disabled from users where login = $1 and password = $2", user.Login, user.Password)
 	if errors.Is(err, sql.ErrNoRows) {
 		return uuid.Nil, reservederrors.ErrUserNotFound
 	}
 	if err != nil {
 		return uuid.Nil, err
 	}
+	if result.Disabled {
+		return uuid.Nil, reservederrors.ErrUserDisabled
+	}
 
-	return id, nil
+	return result.Id, nil
+}
+
+// SetKeys sets the key pair of the user once, replacing it would make existing shares unreadable.
+func (s *Storage) SetKeys(ctx context.Context, userId uuid.UUID, keys model.KeyPair) error {
+	result, err := s.db.ExecContext(
+		ctx,
+		"update users set public_key = $1, encrypted_private_key = $2 where id = $3 and public_key is null",
+		keys.PublicKey,
+		keys.EncryptedPrivateKey,
+		userId,
+	)
+	if err != nil {
+		return err
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return reservederrors.ErrKeysAlreadySet
+	}
+	return nil
+}
+
+func (s *Storage) GetKeys(ctx context.Context, userId uuid.UUID) (*model.KeyPair, error) {
+	var result model.KeyPair
+	err := s.db.GetContext(
+		ctx,
+		&result,
+		"select public_key, encrypted_private_key from users where id = $1 and public_key is not null",
+		userId,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, reservederrors.ErrKeysNotFound
+	}
+	return &result, err
+}
+
+func (s *Storage) GetPublicKey(ctx context.Context, login string) (*model.PublicKey, error) {
+	var result model.PublicKey
+	err := s.db.GetContext(ctx, &result, "select id, public_key from users where login = $1", login)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, reservederrors.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(result.PublicKey) == 0 {
+		return nil, reservederrors.ErrKeysNotFound
+	}
+	return &result, nil
+}
+
+// SetRecovery sets the recovery key of the user if the password matches, ErrWrongPassword is returned otherwise.
+func (s *Storage) SetRecovery(ctx context.Context, userId uuid.UUID, password string, recovery model.Recovery) error {
+	result, err := s.db.ExecContext(
+		ctx,
+		"update users set recovery_auth = $1, recovery_private_key = $2 where id = $3 and password = $4",
+		recovery.AuthHash,
+		recovery.PrivateKey,
+		userId,
+		password,
+	)
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return reservederrors.ErrWrongPassword
+	}
+	return nil
+}
+
+// Recover sets the new password of the user if the recovery auth hash matches and revokes tokens issued before.
+func (s *Storage) Recover(ctx context.Context, login string, authHash []byte, newPassword string) (*model.RecoveredUser, error) {
+	var result model.RecoveredUser
+	err := s.db.GetContext(
+		ctx,
+		&result,
+		`update users set password = $1, token_version = token_version + 1
+		where login = $2 and recovery_auth = $3 returning id, recovery_private_key`,
+		newPassword,
+		login,
+		authHash,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, reservederrors.ErrRecoveryFailed
+	}
+	return &result, err
+}
+
+// UpdatePrivateKey replaces the encrypted private key of the key pair with the same public key.
+func (s *Storage) UpdatePrivateKey(ctx context.Context, userId uuid.UUID, keys model.KeyPair) error {
+	result, err := s.db.ExecContext(
+		ctx,
+		"update users set encrypted_private_key = $1 where id = $2 and public_key = $3",
+		keys.EncryptedPrivateKey,
+		userId,
+		keys.PublicKey,
+	)
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return reservederrors.ErrKeysNotFound
+	}
+	return nil
+}
+
+func (s *Storage) GetSession(ctx context.Context, userId uuid.UUID) (*model.Session, error) {
+	var result model.Session
+	err := s.db.GetContext(ctx, &result, "select is_admin, disabled, token_version from users where id = $1", userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, reservederrors.ErrUserNotFound
+	}
+	return &result, err
+}
+
+const selectUserInfo = `select u.id, u.login, u.is_admin, u.disabled, count(r.id) as items, coalesce(sum(r.size), 0) as bytes
+from users u left join resources r on r.user_id = u.id`
+
+func (s *Storage) ListUsers(ctx context.Context) ([]model.UserInfo, error) {
+	var results []model.UserInfo
+	err := s.db.SelectContext(ctx, &results, selectUserInfo+" group by u.id order by u.login")
+	return results, err
+}
+
+func (s *Storage) GetUserInfo(ctx context.Context, login string) (*model.UserInfo, error) {
+	var result model.UserInfo
+	err := s.db.GetContext(ctx, &result, selectUserInfo+" where u.login = $1 group by u.id", login)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, reservederrors.ErrUserNotFound
+	}
+	return &result, err
+}
+
+// SetDisabled disables or enables the user, tokens issued before disabling stay revoked after enabling.
+func (s *Storage) SetDisabled(ctx context.Context, login string, disabled bool) error {
+	return s.updateUser(
+		ctx,
+		"update users set disabled = $1, token_version = case when $1 then token_version + 1 else token_version end where login = $2",
+		disabled,
+		login,
+	)
+}
+
+// Logout revokes tokens issued to the user.
+func (s *Storage) Logout(ctx context.Context, login string) error {
+	return s.updateUser(ctx, "update users set token_version = token_version + 1 where login = $1", login)
+}
+
+func (s *Storage) SetAdmin(ctx context.Context, login string, isAdmin bool) error {
+	return s.updateUser(ctx, "update users set is_admin = $1 where login = $2", isAdmin, login)
+}
+
+func (s *Storage) updateUser(ctx context.Context, query string, args ...interface{}) error {
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return reservederrors.ErrUserNotFound
+	}
+	return nil
 }
@@ -1,9 +1,46 @@
 package model
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+)
 
 type User struct {
 	Id       uuid.UUID `db:"id"`
 	Login    string    `db:"login"`
 	Password string    `db:"password"`
 }
+
+type KeyPair struct {
+	PublicKey           []byte `db:"public_key"`
+	EncryptedPrivateKey []byte `db:"encrypted_private_key"`
+}
+
+type PublicKey struct {
+	UserId    uuid.UUID `db:"id"`
+	PublicKey []byte    `db:"public_key"`
+}
+
+type Recovery struct {
+	AuthHash   []byte `db:"recovery_auth"`
+	PrivateKey []byte `db:"recovery_private_key"`
+}
+
+type RecoveredUser struct {
+	Id         uuid.UUID `db:"id"`
+	PrivateKey []byte    `db:"recovery_private_key"`
+}
+
+type Session struct {
+	IsAdmin      bool  `db:"is_admin"`
+	Disabled     bool  `db:"disabled"`
+	TokenVersion int64 `db:"token_version"`
+}
+
+type UserInfo struct {
+	Id       uuid.UUID `db:"id"`
+	Login    string    `db:"login"`
+	IsAdmin  bool      `db:"is_admin"`
+	Disabled bool      `db:"disabled"`
+	Items    int64     `db:"items"`
+	Bytes    int64     `db:"bytes"`
+}
@@ -0,0 +1,95 @@
+package emergency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"secstorage/internal/api"
+	"secstorage/internal/server/reservederrors"
+	"secstorage/internal/server/storage"
+	"secstorage/internal/server/storage/emergency/model"
+)
+
+// selectAccess reports requested access as granted once the waiting period is over.
+const selectAccess = `select e.id, e.grantor_id, g.login as grantor_login, e.trustee_id, t.login as trustee_login, e.wait_hours,
+	case when e.status = 1 and e.requested_at + e.wait_hours * interval '1 hour' <= now() then 2 else e.status end as status,
+	e.requested_at
+	from emergency_access e
+	join users g on g.id = e.grantor_id
+	join users t on t.id = e.trustee_id`
+
+type Storage struct {
+	ctx context.Context
+	db  *sqlx.DB
+}
+
+func NewStorage(ctx context.Context, db *sqlx.DB) *Storage {
+	return &Storage{ctx: ctx, db: db}
+}
+
+// Create makes the user with the login a trusted contact of the grantor.
+func (s *Storage) Create(ctx context.Context, access *model.Access) error {
+	result, err := s.db.ExecContext(
+		ctx,
+		"insert into emergency_access(id, grantor_id, trustee_id, wait_hours) select $1, $2, id, $3 from users where login = $4 and id <> $2",
+		access.Id,
+		access.GrantorId,
+		access.WaitHours,
+		access.TrusteeLogin,
+	)
+	if err != nil && storage.IsUniqueViolation(err) {
+		return reservederrors.ErrEmergencyExists
+	}
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return reservederrors.ErrUserNotFound
+	}
+	return nil
+}
+
+func (s *Storage) Get(ctx context.Context, id uuid.UUID) (*model.Access, error) {
+	var result model.Access
+	err := s.db.GetContext(ctx, &result, selectAccess+" where e.id = $1", id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, reservederrors.ErrEmergencyNotFound
+	}
+	return &result, err
+}
+
+// ListByUserId lists accesses where the user is either the grantor or the trustee.
+func (s *Storage) ListByUserId(ctx context.Context, userId api.UserId) ([]model.Access, error) {
+	var results []model.Access
+	err := s.db.SelectContext(ctx, &results, selectAccess+" where e.grantor_id = $1 or e.trustee_id = $1 order by g.login, t.login", userId)
+	return results, err
+}
+
+func (s *Storage) SetStatus(ctx context.Context, id uuid.UUID, status api.EmergencyStatus) error {
+	var err error
+	if status == api.EmergencyRequested {
+		_, err = s.db.ExecContext(ctx, "update emergency_access set status = $1, requested_at = now() where id = $2", status, id)
+	} else {
+		_, err = s.db.ExecContext(ctx, "update emergency_access set status = $1 where id = $2", status, id)
+	}
+	return err
+}
+
+func (s *Storage) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := s.db.ExecContext(ctx, "delete from emergency_access where id = $1", id)
+	return err
+}
+
+func (s *Storage) IsGranted(ctx context.Context, grantorId api.UserId, trusteeId api.UserId) (bool, error) {
+	var result model.Access
+	err := s.db.GetContext(ctx, &result, selectAccess+" where e.grantor_id = $1 and e.trustee_id = $2", grantorId, trusteeId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return result.Status == api.EmergencyGranted, nil
+}
@@ -0,0 +1,19 @@
+package model
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"secstorage/internal/api"
+)
+
+// Access lets the trustee read resources of the grantor once it is granted.
+type Access struct {
+	Id           uuid.UUID           `db:"id"`
+	GrantorId    api.UserId          `db:"grantor_id"`
+	GrantorLogin string              `db:"grantor_login"`
+	TrusteeId    api.UserId          `db:"trustee_id"`
+	TrusteeLogin string              `db:"trustee_login"`
+	WaitHours    int                 `db:"wait_hours"`
+	Status       api.EmergencyStatus `db:"status"`
+	RequestedAt  sql.NullTime        `db:"requested_at"`
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"secstorage/internal/api"
+)
+
+type Organization struct {
+	Id   uuid.UUID `db:"id"`
+	Name string    `db:"name"`
+}
+
+type Member struct {
+	OrgId    uuid.UUID  `db:"org_id"`
+	UserId   api.UserId `db:"user_id"`
+	Login    string     `db:"login"`
+	Role     api.Role   `db:"role"`
+	Accepted bool       `db:"accepted"`
+}
+
+// Membership is the organization as seen by one of its members.
+type Membership struct {
+	OrgId    uuid.UUID `db:"org_id"`
+	Name     string    `db:"name"`
+	Role     api.Role  `db:"role"`
+	Accepted bool      `db:"accepted"`
+}
+
+type Collection struct {
+	Id    uuid.UUID `db:"id"`
+	OrgId uuid.UUID `db:"org_id"`
+	Name  string    `db:"name"`
+}
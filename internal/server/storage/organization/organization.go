@@ -0,0 +1,165 @@
+package organization
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"secstorage/internal/api"
+	"secstorage/internal/server/reservederrors"
+	"secstorage/internal/server/storage"
+	"secstorage/internal/server/storage/organization/model"
+)
+
+const selectMember = `select m.org_id, m.user_id, u.login, m.role, m.accepted
+	from org_members m join users u on u.id = m.user_id`
+
+type Storage struct {
+	ctx context.Context
+	db  *sqlx.DB
+}
+
+func NewStorage(ctx context.Context, db *sqlx.DB) *Storage {
+	return &Storage{ctx: ctx, db: db}
+}
+
+// Create creates the organization with the user as its owner.
+func (s *Storage) Create(ctx context.Context, org *model.Organization, ownerId api.UserId) error {
+	return storage.RunInTx(
+		func(tx *sqlx.Tx) error {
+			_, err := tx.ExecContext(ctx, "insert into organizations(id, name) values ($1, $2)", org.Id, org.Name)
+			return err
+		},
+		func(tx *sqlx.Tx) error {
+			_, err := tx.ExecContext(
+				ctx,
+				"insert into org_members(org_id, user_id, role, accepted) values ($1, $2, $3, true)",
+				org.Id,
+				ownerId,
+				api.Owner,
+			)
+			if err != nil && storage.IsForeignKeyViolation(err) {
+				return reservederrors.ErrUserNotFound
+			}
+			return err
+		},
+	)
+}
+
+func (s *Storage) ListByUserId(ctx context.Context, userId api.UserId) ([]model.Membership, error) {
+	var results []model.Membership
+	err := s.db.SelectContext(
+		ctx,
+		&results,
+		"select m.org_id, o.name, m.role, m.accepted from org_members m join organizations o on o.id = m.org_id where m.user_id = $1 order by o.name",
+		userId,
+	)
+	return results, err
+}
+
+func (s *Storage) GetMember(ctx context.Context, orgId uuid.UUID, userId api.UserId) (*model.Member, error) {
+	var result model.Member
+	err := s.db.GetContext(ctx, &result, selectMember+" where m.org_id = $1 and m.user_id = $2", orgId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, reservederrors.ErrNotMember
+	}
+	return &result, err
+}
+
+func (s *Storage) GetMemberByLogin(ctx context.Context, orgId uuid.UUID, login string) (*model.Member, error) {
+	var result model.Member
+	err := s.db.GetContext(ctx, &result, selectMember+" where m.org_id = $1 and u.login = $2", orgId, login)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, reservederrors.ErrNotMember
+	}
+	return &result, err
+}
+
+func (s *Storage) ListMembers(ctx context.Context, orgId uuid.UUID) ([]model.Member, error) {
+	var results []model.Member
+	err := s.db.SelectContext(ctx, &results, selectMember+" where m.org_id = $1 order by u.login", orgId)
+	return results, err
+}
+
+// Invite adds the user with the login as a member who has not accepted the invitation yet.
+func (s *Storage) Invite(ctx context.Context, orgId uuid.UUID, login string, role api.Role) error {
+	result, err := s.db.ExecContext(
+		ctx,
+		"insert into org_members(org_id, user_id, role) select $1, id, $2 from users where login = $3",
+		orgId,
+		role,
+		login,
+	)
+	if err != nil && storage.IsUniqueViolation(err) {
+		return reservederrors.ErrMemberExists
+	}
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return reservederrors.ErrUserNotFound
+	}
+	return nil
+}
+
+func (s *Storage) Accept(ctx context.Context, orgId uuid.UUID, userId api.UserId) error {
+	result, err := s.db.ExecContext(ctx, "update org_members set accepted = true where org_id = $1 and user_id = $2", orgId, userId)
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return reservederrors.ErrNotMember
+	}
+	return nil
+}
+
+func (s *Storage) SetRole(ctx context.Context, orgId uuid.UUID, userId api.UserId, role api.Role) error {
+	_, err := s.db.ExecContext(ctx, "update org_members set role = $1 where org_id = $2 and user_id = $3", role, orgId, userId)
+	return err
+}
+
+func (s *Storage) RemoveMember(ctx context.Context, orgId uuid.UUID, userId api.UserId) error {
+	_, err := s.db.ExecContext(ctx, "delete from org_members where org_id = $1 and user_id = $2", orgId, userId)
+	return err
+}
+
+func (s *Storage) CountOwners(ctx context.Context, orgId uuid.UUID) (int, error) {
+	var count int
+	err := s.db.GetContext(ctx, &count, "select count(*) from org_members where org_id = $1 and role = $2", orgId, api.Owner)
+	return count, err
+}
+
+func (s *Storage) CreateCollection(ctx context.Context, collection *model.Collection) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		"insert into collections(id, org_id, name) values ($1, $2, $3)",
+		collection.Id,
+		collection.OrgId,
+		collection.Name,
+	)
+	return err
+}
+
+func (s *Storage) ListCollections(ctx context.Context, orgId uuid.UUID) ([]model.Collection, error) {
+	var results []model.Collection
+	err := s.db.SelectContext(ctx, &results, "select id, org_id, name from collections where org_id = $1 order by name", orgId)
+	return results, err
+}
+
+// CollectionRole returns the role of the user in the organization owning the collection,
+// invitations that are not accepted yet give no access.
+func (s *Storage) CollectionRole(ctx context.Context, collectionId uuid.UUID, userId api.UserId) (api.Role, error) {
+	var role api.Role
+	err := s.db.GetContext(
+		ctx,
+		&role,
+		"select m.role from collections c join org_members m on m.org_id = c.org_id where c.id = $1 and m.user_id = $2 and m.accepted",
+		collectionId,
+		userId,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return role, reservederrors.ErrNotMember
+	}
+	return role, err
+}
@@ -0,0 +1,12 @@
+package model
+
+import "secstorage/internal/api"
+
+type Blob struct {
+	UserId     api.UserId `db:"user_id"`
+	Checksum   []byte     `db:"checksum"`
+	Path       string     `db:"path"`
+	Size       int64      `db:"size"`
+	Compressed bool       `db:"compressed"`
+	RefCount   int64      `db:"ref_count"`
+}
@@ -0,0 +1,17 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"secstorage/internal/api"
+)
+
+// Change is the latest change of the resource, Meta, ParentId and CollectionId are empty for deleted resources.
+type Change struct {
+	Seq          int64            `db:"seq"`
+	ResourceId   api.ResourceId   `db:"resource_id"`
+	Type         api.ResourceType `db:"type"`
+	Kind         api.ChangeKind   `db:"kind"`
+	Meta         []byte           `db:"meta"`
+	ParentId     uuid.NullUUID    `db:"parent_id"`
+	CollectionId uuid.NullUUID    `db:"collection_id"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"secstorage/internal/api"
+	"time"
+)
+
+// Conflict is an update of the resource made without knowing a later revision, UserId is the user who made it.
+type Conflict struct {
+	Id           uuid.UUID      `db:"id"`
+	ResourceId   api.ResourceId `db:"resource_id"`
+	UserId       api.UserId     `db:"user_id"`
+	Data         []byte         `db:"data"`
+	Meta         []byte         `db:"meta"`
+	BaseRevision int64          `db:"base_revision"`
+	CreatedAt    time.Time      `db:"created_at"`
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"secstorage/internal/api"
+	"time"
+)
+
+type CustomField struct {
+	Name  string        `json:"name"`
+	Type  api.FieldType `json:"type"`
+	Value string        `json:"value,omitempty"`
+}
+
+// CustomFields are user defined fields kept in the payload of any resource.
+type CustomFields struct {
+	Fields []CustomField `json:"fields,omitempty"`
+}
+
+type LoginPassword struct {
+	CustomFields
+	Login             string     `json:"login,omitempty"`
+	Password          string     `json:"password,omitempty"`
+	URLs              []string   `json:"urls,omitempty"`
+	Notes             string     `json:"notes,omitempty"`
+	TOTP              *OTP       `json:"totp,omitempty"`
+	PasswordChangedAt *time.Time `json:"passwordChangedAt,omitempty"`
+}
+
+type SecureNote struct {
+	CustomFields
+	Title string `json:"title"`
+	Body  string `json:"body,omitempty"`
+}
+
+type OTP struct {
+	CustomFields
+	Secret    string `json:"secret"`
+	Issuer    string `json:"issuer,omitempty"`
+	Account   string `json:"account,omitempty"`
+	Digits    int    `json:"digits"`
+	Period    int    `json:"period"`
+	Algorithm string `json:"algorithm"`
+}
+
+type SSHKey struct {
+	CustomFields
+	PrivateKey string `json:"privateKey"`
+	PublicKey  string `json:"publicKey,omitempty"`
+	Comment    string `json:"comment,omitempty"`
+	Passphrase string `json:"passphrase,omitempty"`
+}
+
+type BankCard struct {
+	CustomFields
+	Number  string `json:"number,omitempty"`
+	Until   string `json:"until"`
+	Name    string `json:"name,omitempty"`
+	Surname string `json:"surname,omitempty"`
+}
+
+type Template struct {
+	CustomFields
+	Name         string           `json:"name"`
+	ResourceType api.ResourceType `json:"resourceType"`
+}
@@ -1,11 +1,25 @@
 package model
 
-import "secstorage/internal/api"
+import (
+	"github.com/google/uuid"
+	"secstorage/internal/api"
+)
 
 type Resource struct {
-	Id     api.ResourceId   `db:"id"`
-	UserId api.UserId       `db:"user_id"`
-	Type   api.ResourceType `db:"type"`
-	Data   []byte           `db:"data"`
-	Meta   []byte           `db:"meta"`
+	Id         api.ResourceId   `db:"id"`
+	UserId     api.UserId       `db:"user_id"`
+	Type       api.ResourceType `db:"type"`
+	Data       []byte           `db:"data"`
+	Meta       []byte           `db:"meta"`
+	Size       int64            `db:"size"`
+	Checksum   []byte           `db:"checksum"`
+	Compressed bool             `db:"compressed"`
+	// ParentId is set for files attached to another resource
+	ParentId uuid.NullUUID `db:"parent_id"`
+	// CollectionId is set for resources of an organization collection
+	CollectionId uuid.NullUUID `db:"collection_id"`
+	// Revision grows with every update, updates based on an older revision are kept as conflicts
+	Revision int64 `db:"revision"`
+	// Shared is set when the resource is shared with or by the caller
+	Shared *Shared `db:"-"`
 }
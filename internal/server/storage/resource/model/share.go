@@ -0,0 +1,34 @@
+package model
+
+import (
+	"secstorage/internal/api"
+	"time"
+)
+
+// SharedItem is the shared copy of the resource encrypted with the item key.
+type SharedItem struct {
+	ResourceId api.ResourceId `db:"resource_id"`
+	OwnerKey   []byte         `db:"owner_key"`
+	Data       []byte         `db:"data"`
+	UpdatedAt  time.Time      `db:"updated_at"`
+}
+
+// Grant gives the recipient access to the shared item, WrappedKey is the item key wrapped for the recipient.
+type Grant struct {
+	ResourceId     api.ResourceId `db:"resource_id"`
+	RecipientId    api.UserId     `db:"recipient_id"`
+	RecipientLogin string         `db:"recipient_login"`
+	WrappedKey     []byte         `db:"wrapped_key"`
+	Permission     api.Permission `db:"permission"`
+	OwnerId        api.UserId     `db:"owner_id"`
+	OwnerLogin     string         `db:"owner_login"`
+}
+
+// Shared is what the caller gets along with the shared resource.
+type Shared struct {
+	OwnerLogin string
+	Permission api.Permission
+	WrappedKey []byte
+	Data       []byte
+	IsOwner    bool
+}
@@ -5,4 +5,6 @@ import "secstorage/internal/api"
 type ShortResourceInfo struct {
 	Id   api.ResourceId `db:"id"`
 	Meta []byte         `db:"meta"`
+	// Shared is set for resources shared with the caller by other users
+	Shared bool `db:"shared"`
 }
@@ -0,0 +1,6 @@
+package model
+
+type Usage struct {
+	Items int64 `db:"items"`
+	Bytes int64 `db:"bytes"`
+}
@@ -2,6 +2,9 @@ package resource
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"secstorage/internal/api"
 	"secstorage/internal/server/reservederrors"
@@ -9,6 +12,13 @@ import (
 	"secstorage/internal/server/storage/resource/model"
 )
 
+const selectResource = `select r.id, r.user_id, r.type, r.data, r.meta, r.size, r.checksum, r.parent_id, r.collection_id, r.revision, coalesce(b.compressed, false) as compressed
+	from resources r left join blobs b on b.user_id = r.user_id and b.checksum = r.checksum and convert_to(b.path, 'UTF8') = r.data`
+
+const selectUsage = "select count(*) as items, coalesce(sum(size), 0) as bytes from resources where user_id = $1"
+
+const deletedColumns = "id, user_id, type, data, meta, size, checksum, parent_id, collection_id"
+
 type Storage struct {
 	ctx context.Context
 	db  *sqlx.DB
@@ -18,60 +28,411 @@ func NewStore(ctx context.Context, db *sqlx.DB) *Storage {
 	return &Storage{ctx: ctx, db: db}
 }
 
-func (s *Storage) Save(ctx context.Context, resource *model.Resource) error {
-	_, err := s.db.ExecContext(
+// Save inserts the resource if check accepts the usage of the owner.
+func (s *Storage) Save(ctx context.Context, resource *model.Resource, check func(model.Usage) error) error {
+	return storage.RunInTx(
+		func(tx *sqlx.Tx) error {
+			// the change goes first to lock the owner together with other users in order
+			return recordChange(ctx, tx, resource, api.ChangeCreated)
+		},
+		func(tx *sqlx.Tx) error {
+			return checkUsage(ctx, tx, resource.UserId, check)
+		},
+		func(tx *sqlx.Tx) error {
+			return insertResource(ctx, tx, resource)
+		},
+	)
+}
+
+// checkUsage locks the user row until the commit, so concurrent saves of the user can't exceed the quota together.
+func checkUsage(ctx context.Context, tx *sqlx.Tx, userId api.UserId, check func(model.Usage) error) error {
+	var id api.UserId
+	err := tx.GetContext(ctx, &id, "select id from users where id = $1 for update", userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return reservederrors.ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	var usage model.Usage
+	if err := tx.GetContext(ctx, &usage, selectUsage, userId); err != nil {
+		return err
+	}
+	return check(usage)
+}
+
+func insertResource(ctx context.Context, tx *sqlx.Tx, resource *model.Resource) error {
+	_, err := tx.ExecContext(
 		ctx,
-		"insert into resources(id, user_id, type, data, meta) values ($1, $2, $3, $4, $5)",
+		"insert into resources(id, user_id, type, data, meta, size, checksum, parent_id, collection_id) values ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
 		resource.Id,
 		resource.UserId,
 		resource.Type,
 		resource.Data,
 		resource.Meta,
+		resource.Size,
+		resource.Checksum,
+		resource.ParentId,
+		resource.CollectionId,
 	)
-
 	if err != nil && storage.IsForeignKeyViolation(err) {
 		return reservederrors.ErrUserNotFound
 	}
+	return err
+}
+
+// Update replaces data and meta of the resource and its shared copy when it is set if the resource is still at the base revision,
+// ErrRevisionConflict is returned when the resource was updated since then.
+func (s *Storage) Update(ctx context.Context, resource *model.Resource, baseRevision int64) error {
+	return storage.RunInTx(
+		func(tx *sqlx.Tx) error {
+			err := tx.GetContext(
+				ctx,
+				&resource.Revision,
+				"update resources set data = $2, meta = $3, size = $4, revision = revision + 1 where id = $1 and revision = $5 returning revision",
+				resource.Id,
+				resource.Data,
+				resource.Meta,
+				resource.Size,
+				baseRevision,
+			)
+			if errors.Is(err, sql.ErrNoRows) {
+				return reservederrors.ErrRevisionConflict
+			}
+			return err
+		},
+		func(tx *sqlx.Tx) error {
+			if resource.Shared == nil {
+				return nil
+			}
+			_, err := tx.ExecContext(ctx, "update shared_items set data = $1, updated_at = now() where resource_id = $2", resource.Shared.Data, resource.Id)
+			return err
+		},
+		func(tx *sqlx.Tx) error {
+			return recordChange(ctx, tx, resource, api.ChangeUpdated)
+		},
+	)
+}
 
+func (s *Storage) SaveConflict(ctx context.Context, conflict *model.Conflict) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		"insert into resource_conflicts(id, resource_id, user_id, data, meta, base_revision) values ($1, $2, $3, $4, $5, $6)",
+		conflict.Id,
+		conflict.ResourceId,
+		conflict.UserId,
+		conflict.Data,
+		conflict.Meta,
+		conflict.BaseRevision,
+	)
+	return err
+}
+
+// ListConflicts lists conflicts made by the user or on resources of the user, oldest first.
+func (s *Storage) ListConflicts(ctx context.Context, userId api.UserId) ([]model.Conflict, error) {
+	var results []model.Conflict
+	err := s.db.SelectContext(
+		ctx,
+		&results,
+		`select c.id, c.resource_id, c.user_id, c.data, c.meta, c.base_revision, c.created_at
+		from resource_conflicts c join resources r on r.id = c.resource_id
+		where c.user_id = $1 or r.user_id = $1 order by c.created_at`,
+		userId,
+	)
+	return results, err
+}
+
+func (s *Storage) GetConflict(ctx context.Context, id uuid.UUID) (*model.Conflict, error) {
+	var result model.Conflict
+	err := s.db.GetContext(
+		ctx,
+		&result,
+		"select id, resource_id, user_id, data, meta, base_revision, created_at from resource_conflicts where id = $1",
+		id,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, reservederrors.ErrConflictNotFound
+	}
+	return &result, err
+}
+
+func (s *Storage) DeleteConflict(ctx context.Context, id uuid.UUID) error {
+	_, err := s.db.ExecContext(ctx, "delete from resource_conflicts where id = $1", id)
 	return err
 }
 
-func (s *Storage) Delete(ctx context.Context, resourceId api.ResourceId, userId api.UserId) error {
-	_, err := s.db.ExecContext(ctx, "delete from resources where id = $1 and user_id = $2", resourceId, userId)
+// selectAudience selects users who can see the resource: the owner of a personal resource and trusted contacts
+// with granted emergency access to it, accepted members of the organization of its collection and share recipients.
+// $1 is the owner, $2 the collection and $3 the resource.
+const selectAudience = `select $1::uuid where $2::uuid is null
+	union select m.user_id from collections c join org_members m on m.org_id = c.org_id and m.accepted where c.id = $2
+	union select e.trustee_id from emergency_access e where e.grantor_id = $1 and $2::uuid is null
+		and (e.status = 2 or e.status = 1 and e.requested_at + e.wait_hours * interval '1 hour' <= now())
+	union select s.recipient_id from resource_shares s where s.resource_id = $3`
+
+// recordChange takes the next change sequence of every user who can see the resource and keeps it as their latest change of it.
+// The user rows are locked in order of ids until the commit, so changes of every user are committed in order of the sequence.
+func recordChange(ctx context.Context, tx *sqlx.Tx, resource *model.Resource, kind api.ChangeKind) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`with audience as (select id from users where id in (`+selectAudience+`) order by id for update),
+		next as (update users u set change_seq = u.change_seq + 1 from audience a where u.id = a.id returning u.id, u.change_seq)
+		insert into resource_changes(user_id, resource_id, seq, type, kind) select id, $3, change_seq, $4, $5 from next
+		on conflict (user_id, resource_id) do update set seq = excluded.seq, type = excluded.type, kind = excluded.kind`,
+		resource.UserId,
+		resource.CollectionId,
+		resource.Id,
+		resource.Type,
+		kind,
+	)
 	return err
 }
 
+// RecordUserChange records the change of the resource for the user only, e.g. when the resource is shared with the user.
+func RecordUserChange(ctx context.Context, tx *sqlx.Tx, userId api.UserId, resourceId api.ResourceId, kind api.ChangeKind) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`with next as (update users set change_seq = change_seq + 1 where id = $1 returning change_seq)
+		insert into resource_changes(user_id, resource_id, seq, type, kind) select $1, r.id, change_seq, r.type, $3 from next, resources r where r.id = $2
+		on conflict (user_id, resource_id) do update set seq = excluded.seq, type = excluded.type, kind = excluded.kind`,
+		userId,
+		resourceId,
+		kind,
+	)
+	return err
+}
+
+// Audience lists users who can see the resource.
+func (s *Storage) Audience(ctx context.Context, resource *model.Resource) ([]api.UserId, error) {
+	var results []api.UserId
+	err := s.db.SelectContext(ctx, &results, selectAudience, resource.UserId, resource.CollectionId, resource.Id)
+	return results, err
+}
+
+// Sync returns changes of resources the user can see after the sequence since, at most limit of them,
+// and the sequence the changes are complete up to.
+func (s *Storage) Sync(ctx context.Context, userId api.UserId, since int64, limit int) ([]model.Change, int64, error) {
+	var highWaterMark int64
+	if err := s.db.GetContext(ctx, &highWaterMark, "select change_seq from users where id = $1", userId); err != nil {
+		return nil, 0, err
+	}
+	var results []model.Change
+	err := s.db.SelectContext(
+		ctx,
+		&results,
+		`select c.seq, c.resource_id, c.type, c.kind, r.meta, r.parent_id, r.collection_id
+		from resource_changes c left join resources r on r.id = c.resource_id
+		where c.user_id = $1 and c.seq > $2 and c.seq <= $3 order by c.seq limit $4`,
+		userId,
+		since,
+		highWaterMark,
+		limit,
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(results) == limit {
+		highWaterMark = results[len(results)-1].Seq
+	}
+	return results, highWaterMark, nil
+}
+
 func (s *Storage) ListByUserId(ctx context.Context, userId api.UserId, resourceType api.ResourceType) ([]model.ShortResourceInfo, error) {
 	var results []model.ShortResourceInfo
 	err := s.db.SelectContext(
 		ctx,
 		&results,
-		"select id, meta from resources where user_id = $1 and type = $2",
+		"select id, meta from resources where user_id = $1 and type = $2 and parent_id is null and collection_id is null",
 		userId,
 		resourceType,
 	)
 	return results, err
 }
 
+func (s *Storage) ListByCollection(ctx context.Context, collectionId uuid.UUID, resourceType api.ResourceType) ([]model.ShortResourceInfo, error) {
+	var results []model.ShortResourceInfo
+	err := s.db.SelectContext(
+		ctx,
+		&results,
+		"select id, meta from resources where collection_id = $1 and type = $2 and parent_id is null",
+		collectionId,
+		resourceType,
+	)
+	return results, err
+}
+
+func (s *Storage) ListAttachments(ctx context.Context, parentId api.ResourceId) ([]model.ShortResourceInfo, error) {
+	var results []model.ShortResourceInfo
+	err := s.db.SelectContext(
+		ctx,
+		&results,
+		"select id, meta from resources where parent_id = $1",
+		parentId,
+	)
+	return results, err
+}
+
 func (s *Storage) Get(ctx context.Context, resourceId api.ResourceId, resourceType api.ResourceType, userId api.UserId) (*model.Resource, error) {
 	var result model.Resource
 	var err error
 	if resourceType == api.Undefined {
-		err = s.db.GetContext(ctx, &result, "select id, user_id, type, data, meta from resources where id = $1 and user_id = $2", resourceId, userId)
+		err = s.db.GetContext(ctx, &result, selectResource+" where r.id = $1 and r.user_id = $2", resourceId, userId)
 	} else {
-		err = s.db.GetContext(ctx, &result, "select id, user_id, type, data, meta from resources where id = $1 and type = $2 and user_id = $3", resourceId, resourceType, userId)
+		err = s.db.GetContext(ctx, &result, selectResource+" where r.id = $1 and r.type = $2 and r.user_id = $3", resourceId, resourceType, userId)
 	}
 	return &result, err
 }
 
-func (s *Storage) DeleteTx(ctx context.Context, id api.ResourceId, userId api.UserId, call func() error) error {
+// GetById gets the resource regardless of its owner, access has to be checked by the caller.
+func (s *Storage) GetById(ctx context.Context, resourceId api.ResourceId, resourceType api.ResourceType) (*model.Resource, error) {
+	var result model.Resource
+	var err error
+	if resourceType == api.Undefined {
+		err = s.db.GetContext(ctx, &result, selectResource+" where r.id = $1", resourceId)
+	} else {
+		err = s.db.GetContext(ctx, &result, selectResource+" where r.id = $1 and r.type = $2", resourceId, resourceType)
+	}
+	return &result, err
+}
+
+func (s *Storage) Usage(ctx context.Context, userId api.UserId) (model.Usage, error) {
+	var result model.Usage
+	err := s.db.GetContext(ctx, &result, selectUsage, userId)
+	return result, err
+}
+
+func (s *Storage) ListFiles(ctx context.Context) ([]model.Resource, error) {
+	var results []model.Resource
+	err := s.db.SelectContext(
+		ctx,
+		&results,
+		selectResource+" where r.type = $1",
+		api.File,
+	)
+	return results, err
+}
+
+// SaveFile saves the file resource if check accepts the usage of the owner and takes a reference to the blob
+// with its content, call is told whether the blob is referenced for the first time and has to be placed to the store.
+func (s *Storage) SaveFile(ctx context.Context, resource *model.Resource, blob *model.Blob, check func(model.Usage) error, call func(isNew bool) error) error {
 	return storage.RunInTx(
 		func(tx *sqlx.Tx) error {
-			return call()
+			// the change goes first to lock the owner together with other users in order
+			return recordChange(ctx, tx, resource, api.ChangeCreated)
 		},
 		func(tx *sqlx.Tx) error {
-			_, err := tx.ExecContext(ctx, "delete from resources where id = $1 and user_id = $2", id, userId)
-			return err
+			return checkUsage(ctx, tx, resource.UserId, check)
+		},
+		func(tx *sqlx.Tx) error {
+			return insertResource(ctx, tx, resource)
+		},
+		func(tx *sqlx.Tx) error {
+			var refCount int64
+			err := tx.QueryRowxContext(
+				ctx,
+				`insert into blobs(user_id, checksum, path, size, compressed, ref_count) values ($1, $2, $3, $4, $5, 1)
+				on conflict (user_id, checksum) do update set ref_count = blobs.ref_count + 1
+				returning ref_count`,
+				blob.UserId,
+				blob.Checksum,
+				blob.Path,
+				blob.Size,
+				blob.Compressed,
+			).Scan(&refCount)
+			if err != nil {
+				return err
+			}
+			return call(refCount == 1)
+		},
+	)
+}
+
+// DeleteTx deletes the resource with its attachments and returns paths of blobs nobody references anymore,
+// the caller removes them once the delete is committed. Access has to be checked by the caller.
+func (s *Storage) DeleteTx(ctx context.Context, id api.ResourceId) ([]string, error) {
+	var resource model.Resource
+	var released []string
+	err := storage.RunInTx(
+		func(tx *sqlx.Tx) error {
+			// recipients of the shares lose them with the resource, so the change is recorded before the delete
+			err := tx.GetContext(ctx, &resource, "select "+deletedColumns+" from resources where id = $1 for update", id)
+			if err != nil {
+				return err
+			}
+			return recordChange(ctx, tx, &resource, api.ChangeDeleted)
+		},
+		func(tx *sqlx.Tx) error {
+			var attachments []model.Resource
+			err := tx.SelectContext(
+				ctx,
+				&attachments,
+				"delete from resources where parent_id = $1 returning "+deletedColumns,
+				id,
+			)
+			if err != nil {
+				return err
+			}
+			for i := 0; i < len(attachments); i++ {
+				if err := recordChange(ctx, tx, &attachments[i], api.ChangeDeleted); err != nil {
+					return err
+				}
+				path, err := releaseBlob(ctx, tx, &attachments[i])
+				if err != nil {
+					return err
+				}
+				if len(path) != 0 {
+					released = append(released, path)
+				}
+			}
+			return nil
+		},
+		func(tx *sqlx.Tx) error {
+			_, err := tx.ExecContext(ctx, "delete from resources where id = $1", id)
+			if err != nil {
+				return err
+			}
+			if resource.Type != api.File {
+				return nil
+			}
+			path, err := releaseBlob(ctx, tx, &resource)
+			if err != nil {
+				return err
+			}
+			if len(path) != 0 {
+				released = append(released, path)
+			}
+			return nil
 		},
 	)
+	if err != nil {
+		return nil, err
+	}
+	return released, nil
+}
+
+// releaseBlob drops the reference of the file to its blob and returns the blob path when nothing references it anymore.
+func releaseBlob(ctx context.Context, tx *sqlx.Tx, resource *model.Resource) (string, error) {
+	var blob model.Blob
+	err := tx.GetContext(
+		ctx,
+		&blob,
+		"update blobs set ref_count = ref_count - 1 where user_id = $1 and checksum = $2 and path = $3 returning user_id, checksum, path, size, compressed, ref_count",
+		resource.UserId,
+		resource.Checksum,
+		string(resource.Data),
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		// the file was stored before deduplication and owns its path, even if a blob with the same content exists
+		return string(resource.Data), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if blob.RefCount > 0 {
+		return "", nil
+	}
+	if _, err := tx.ExecContext(ctx, "delete from blobs where user_id = $1 and checksum = $2", blob.UserId, blob.Checksum); err != nil {
+		return "", err
+	}
+	return blob.Path, nil
 }
@@ -0,0 +1,17 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"secstorage/internal/api"
+	"time"
+)
+
+// Send is a one-off encrypted payload available without an account until views or time run out.
+type Send struct {
+	Id        uuid.UUID  `db:"id"`
+	UserId    api.UserId `db:"user_id"`
+	Data      []byte     `db:"data"`
+	MaxViews  int        `db:"max_views"`
+	Views     int        `db:"views"`
+	ExpiresAt time.Time  `db:"expires_at"`
+}
@@ -0,0 +1,122 @@
+package send
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"secstorage/internal/api"
+	"secstorage/internal/server/reservederrors"
+	"secstorage/internal/server/storage"
+	"secstorage/internal/server/storage/send/model"
+)
+
+const sendColumns = "id, user_id, data, max_views, views, expires_at"
+
+type Storage struct {
+	ctx context.Context
+	db  *sqlx.DB
+}
+
+func NewStorage(ctx context.Context, db *sqlx.DB) *Storage {
+	return &Storage{ctx: ctx, db: db}
+}
+
+// Create saves the send unless the user has maxActive sends already and purges expired ones.
+// The user row is locked until the commit, so concurrent sends can't exceed the limit together.
+func (s *Storage) Create(ctx context.Context, send *model.Send, maxActive int) error {
+	return storage.RunInTx(
+		func(tx *sqlx.Tx) error {
+			_, err := tx.ExecContext(ctx, "delete from sends where expires_at <= now()")
+			return err
+		},
+		func(tx *sqlx.Tx) error {
+			var id api.UserId
+			err := tx.GetContext(ctx, &id, "select id from users where id = $1 for update", send.UserId)
+			if errors.Is(err, sql.ErrNoRows) {
+				return reservederrors.ErrUserNotFound
+			}
+			if err != nil {
+				return err
+			}
+			var active int
+			if err := tx.GetContext(ctx, &active, "select count(*) from sends where user_id = $1", send.UserId); err != nil {
+				return err
+			}
+			if active >= maxActive {
+				return reservederrors.ErrTooManySends
+			}
+			return nil
+		},
+		func(tx *sqlx.Tx) error {
+			_, err := tx.ExecContext(
+				ctx,
+				"insert into sends(id, user_id, data, max_views, expires_at) values ($1, $2, $3, $4, $5)",
+				send.Id,
+				send.UserId,
+				send.Data,
+				send.MaxViews,
+				send.ExpiresAt,
+			)
+			if err != nil && storage.IsForeignKeyViolation(err) {
+				return reservederrors.ErrUserNotFound
+			}
+			return err
+		},
+	)
+}
+
+// Open counts the view and returns the send, the send is deleted after the last view.
+func (s *Storage) Open(ctx context.Context, id uuid.UUID) (*model.Send, error) {
+	var result model.Send
+	err := storage.RunInTx(
+		func(tx *sqlx.Tx) error {
+			err := tx.GetContext(
+				ctx,
+				&result,
+				`update sends set views = views + 1
+				where id = $1 and views < max_views and expires_at > now()
+				returning `+sendColumns,
+				id,
+			)
+			if errors.Is(err, sql.ErrNoRows) {
+				return reservederrors.ErrSendNotFound
+			}
+			return err
+		},
+		func(tx *sqlx.Tx) error {
+			if result.Views < result.MaxViews {
+				return nil
+			}
+			_, err := tx.ExecContext(ctx, "delete from sends where id = $1", id)
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (s *Storage) ListByUserId(ctx context.Context, userId api.UserId) ([]model.Send, error) {
+	var results []model.Send
+	err := s.db.SelectContext(
+		ctx,
+		&results,
+		"select id, user_id, max_views, views, expires_at from sends where user_id = $1 and expires_at > now() order by expires_at",
+		userId,
+	)
+	return results, err
+}
+
+func (s *Storage) Delete(ctx context.Context, id uuid.UUID, userId api.UserId) error {
+	result, err := s.db.ExecContext(ctx, "delete from sends where id = $1 and user_id = $2", id, userId)
+	if err != nil {
+		return err
+	}
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return reservederrors.ErrSendNotFound
+	}
+	return nil
+}
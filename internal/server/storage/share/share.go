@@ -0,0 +1,122 @@
+package share
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"secstorage/internal/api"
+	"secstorage/internal/server/reservederrors"
+	"secstorage/internal/server/storage"
+	"secstorage/internal/server/storage/resource"
+	"secstorage/internal/server/storage/resource/model"
+)
+
+const selectGrant = `select s.resource_id, s.recipient_id, s.wrapped_key, s.permission,
+	rcp.login as recipient_login, r.user_id as owner_id, own.login as owner_login
+	from resource_shares s
+	join resources r on r.id = s.resource_id
+	join users own on own.id = r.user_id
+	join users rcp on rcp.id = s.recipient_id`
+
+type Storage struct {
+	ctx context.Context
+	db  *sqlx.DB
+}
+
+func NewStorage(ctx context.Context, db *sqlx.DB) *Storage {
+	return &Storage{ctx: ctx, db: db}
+}
+
+// Share replaces the shared copy of the resource and grants the recipient access to it.
+func (s *Storage) Share(ctx context.Context, item *model.SharedItem, grant *model.Grant) error {
+	return storage.RunInTx(
+		func(tx *sqlx.Tx) error {
+			_, err := tx.ExecContext(
+				ctx,
+				`insert into shared_items(resource_id, owner_key, data) values ($1, $2, $3)
+				on conflict (resource_id) do update set owner_key = excluded.owner_key, data = excluded.data, updated_at = now()`,
+				item.ResourceId,
+				item.OwnerKey,
+				item.Data,
+			)
+			return err
+		},
+		func(tx *sqlx.Tx) error {
+			_, err := tx.ExecContext(
+				ctx,
+				`insert into resource_shares(resource_id, recipient_id, wrapped_key, permission) values ($1, $2, $3, $4)
+				on conflict (resource_id, recipient_id) do update set wrapped_key = excluded.wrapped_key, permission = excluded.permission`,
+				grant.ResourceId,
+				grant.RecipientId,
+				grant.WrappedKey,
+				grant.Permission,
+			)
+			if err != nil && storage.IsForeignKeyViolation(err) {
+				return reservederrors.ErrUserNotFound
+			}
+			return err
+		},
+		func(tx *sqlx.Tx) error {
+			return resource.RecordUserChange(ctx, tx, grant.RecipientId, grant.ResourceId, api.ChangeCreated)
+		},
+	)
+}
+
+// Unshare takes the access away from the recipient, the resource is reported to the recipient as deleted.
+func (s *Storage) Unshare(ctx context.Context, resourceId api.ResourceId, recipientId api.UserId) error {
+	return storage.RunInTx(
+		func(tx *sqlx.Tx) error {
+			result, err := tx.ExecContext(ctx, "delete from resource_shares where resource_id = $1 and recipient_id = $2", resourceId, recipientId)
+			if err != nil {
+				return err
+			}
+			if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+				return err
+			}
+			return resource.RecordUserChange(ctx, tx, recipientId, resourceId, api.ChangeDeleted)
+		},
+	)
+}
+
+func (s *Storage) UpdateData(ctx context.Context, resourceId api.ResourceId, data []byte) error {
+	_, err := s.db.ExecContext(ctx, "update shared_items set data = $1, updated_at = now() where resource_id = $2", data, resourceId)
+	return err
+}
+
+func (s *Storage) GetItem(ctx context.Context, resourceId api.ResourceId) (*model.SharedItem, error) {
+	var result model.SharedItem
+	err := s.db.GetContext(ctx, &result, "select resource_id, owner_key, data, updated_at from shared_items where resource_id = $1", resourceId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, reservederrors.ErrNotShared
+	}
+	return &result, err
+}
+
+func (s *Storage) GetGrant(ctx context.Context, resourceId api.ResourceId, recipientId api.UserId) (*model.Grant, error) {
+	var result model.Grant
+	err := s.db.GetContext(ctx, &result, selectGrant+" where s.resource_id = $1 and s.recipient_id = $2", resourceId, recipientId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, reservederrors.ErrNotShared
+	}
+	return &result, err
+}
+
+func (s *Storage) ListGrants(ctx context.Context, resourceId api.ResourceId) ([]model.Grant, error) {
+	var results []model.Grant
+	err := s.db.SelectContext(ctx, &results, selectGrant+" where s.resource_id = $1", resourceId)
+	return results, err
+}
+
+// ListShared lists resources of the type shared with the recipient.
+func (s *Storage) ListShared(ctx context.Context, recipientId api.UserId, resourceType api.ResourceType) ([]model.ShortResourceInfo, error) {
+	var results []model.ShortResourceInfo
+	err := s.db.SelectContext(
+		ctx,
+		&results,
+		"select r.id, r.meta, true as shared from resources r join resource_shares s on s.resource_id = r.id where s.recipient_id = $1 and r.type = $2",
+		recipientId,
+		resourceType,
+	)
+	return results, err
+}
@@ -0,0 +1,15 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"secstorage/internal/api"
+)
+
+type Session struct {
+	Id        uuid.UUID     `db:"id"`
+	UserId    api.UserId    `db:"user_id"`
+	Meta      []byte        `db:"meta"`
+	Path      string        `db:"path"`
+	Committed int64         `db:"committed"`
+	ParentId  uuid.NullUUID `db:"parent_id"`
+}
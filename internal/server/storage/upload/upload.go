@@ -0,0 +1,119 @@
+package upload
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"secstorage/internal/api"
+	"secstorage/internal/server/reservederrors"
+	"secstorage/internal/server/storage"
+	"secstorage/internal/server/storage/upload/model"
+	"time"
+)
+
+type Storage struct {
+	ctx context.Context
+	db  *sqlx.DB
+}
+
+func NewStorage(ctx context.Context, db *sqlx.DB) *Storage {
+	return &Storage{ctx: ctx, db: db}
+}
+
+func (s *Storage) Create(ctx context.Context, session *model.Session) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		"insert into upload_sessions(id, user_id, meta, path, committed, parent_id) values ($1, $2, $3, $4, $5, $6)",
+		session.Id,
+		session.UserId,
+		session.Meta,
+		session.Path,
+		session.Committed,
+		session.ParentId,
+	)
+	if err != nil && storage.IsForeignKeyViolation(err) {
+		return reservederrors.ErrUserNotFound
+	}
+	return err
+}
+
+func (s *Storage) Get(ctx context.Context, id uuid.UUID, userId api.UserId) (*model.Session, error) {
+	var result model.Session
+	err := s.db.GetContext(
+		ctx,
+		&result,
+		"select id, user_id, meta, path, committed, parent_id from upload_sessions where id = $1 and user_id = $2",
+		id,
+		userId,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, reservederrors.ErrUploadNotFound
+	}
+	return &result, err
+}
+
+// SetCommitted moves the committed offset of the session from offset and calls write while the session row is locked,
+// so chunks written concurrently at the same offset can't both pass. ErrOffsetMismatch is returned when the
+// committed offset is not offset anymore, the offset is kept when write fails.
+func (s *Storage) SetCommitted(ctx context.Context, id uuid.UUID, offset int64, committed int64, write func() error) error {
+	return storage.RunInTx(
+		func(tx *sqlx.Tx) error {
+			result, err := tx.ExecContext(
+				ctx,
+				"update upload_sessions set committed = $1 where id = $2 and committed = $3",
+				committed,
+				id,
+				offset,
+			)
+			if err != nil {
+				return err
+			}
+			rows, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if rows == 0 {
+				return reservederrors.ErrOffsetMismatch
+			}
+			return write()
+		},
+	)
+}
+
+// Uploading returns bytes committed to open sessions of the user except the given one.
+func (s *Storage) Uploading(ctx context.Context, userId api.UserId, except uuid.UUID) (int64, error) {
+	var result int64
+	err := s.db.GetContext(
+		ctx,
+		&result,
+		"select coalesce(sum(committed), 0) from upload_sessions where user_id = $1 and id <> $2",
+		userId,
+		except,
+	)
+	return result, err
+}
+
+func (s *Storage) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := s.db.ExecContext(ctx, "delete from upload_sessions where id = $1", id)
+	return err
+}
+
+func (s *Storage) ListPaths(ctx context.Context) ([]string, error) {
+	var results []string
+	err := s.db.SelectContext(ctx, &results, "select path from upload_sessions")
+	return results, err
+}
+
+// DeleteExpired deletes sessions created more than maxAge ago and returns paths of their partial files.
+func (s *Storage) DeleteExpired(ctx context.Context, maxAge time.Duration) ([]string, error) {
+	var results []string
+	err := s.db.SelectContext(
+		ctx,
+		&results,
+		"delete from upload_sessions where created_at < now() - make_interval(secs => $1) returning path",
+		maxAge.Seconds(),
+	)
+	return results, err
+}
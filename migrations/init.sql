@@ -1,7 +1,45 @@
 create table users(
   id uuid primary key,
   login varchar(20) unique not null,
-  password varchar not null
+  password varchar not null,
+  -- curve25519 key pair for sharing, the private key is encrypted by the client
+  public_key bytea,
+  encrypted_private_key bytea,
+  -- sha256 of the proof derived from the recovery key
+  recovery_auth bytea,
+  recovery_private_key bytea,
+  is_admin boolean not null default false,
+  disabled boolean not null default false,
+  -- tokens carry the version they were issued with, bumping it revokes them
+  token_version bigint not null default 0,
+  -- sequence of the latest change of the user's resources
+  change_seq bigint not null default 0
+);
+
+create table organizations(
+  id uuid primary key,
+  name varchar not null,
+  created_at timestamp not null default now()
+);
+
+create table org_members(
+  org_id uuid not null,
+  user_id uuid not null,
+  role int not null,
+  -- invited members get access once they accept the invitation
+  accepted boolean not null default false,
+
+  primary key (org_id, user_id),
+  CONSTRAINT fk_organizations FOREIGN KEY(org_id) REFERENCES organizations(id) on delete cascade,
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) on delete cascade
+);
+
+create table collections(
+  id uuid primary key,
+  org_id uuid not null,
+  name varchar not null,
+
+  CONSTRAINT fk_organizations FOREIGN KEY(org_id) REFERENCES organizations(id) on delete cascade
 );
 
 create table resources(
@@ -10,6 +48,144 @@ create table resources(
   type int not null,
   data bytea,
   meta bytea,
+  size bigint not null default 0,
+  checksum bytea,
+  parent_id uuid,
+  collection_id uuid,
+  revision bigint not null default 1,
+
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) on delete cascade,
+  CONSTRAINT fk_parent FOREIGN KEY(parent_id) REFERENCES resources(id),
+  CONSTRAINT fk_collections FOREIGN KEY(collection_id) REFERENCES collections(id)
+);
+
+create index resources_collection_id on resources(collection_id);
+
+create index resources_parent_id on resources(parent_id);
+
+create table blobs(
+  user_id uuid not null,
+  checksum bytea not null,
+  path varchar not null,
+  size bigint not null,
+  compressed boolean not null default false,
+  ref_count bigint not null,
+
+  primary key (user_id, checksum),
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) on delete cascade
+);
+
+-- the latest change of every resource of the user, deleted resources stay as tombstones for sync
+create table resource_changes(
+  user_id uuid not null,
+  resource_id uuid not null,
+  seq bigint not null,
+  type smallint not null,
+  kind smallint not null,
+
+  primary key (user_id, resource_id),
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) on delete cascade
+);
+
+create index resource_changes_seq on resource_changes(user_id, seq);
+
+-- updates based on an older revision of the resource, kept until the user resolves them
+create table resource_conflicts(
+  id uuid primary key,
+  resource_id uuid not null,
+  user_id uuid not null,
+  data bytea,
+  meta bytea,
+  base_revision bigint not null,
+  created_at timestamp not null default now(),
 
+  CONSTRAINT fk_resources FOREIGN KEY(resource_id) REFERENCES resources(id) on delete cascade,
   CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) on delete cascade
-);
\ No newline at end of file
+);
+
+create index resource_conflicts_resource_id on resource_conflicts(resource_id);
+
+create table upload_sessions(
+  id uuid primary key,
+  user_id uuid not null,
+  meta bytea,
+  path varchar not null,
+  parent_id uuid,
+  committed bigint not null default 0,
+  created_at timestamp not null default now(),
+
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) on delete cascade
+);
+create table shared_items(
+  resource_id uuid primary key,
+  -- item key wrapped for the owner
+  owner_key bytea not null,
+  -- resource encrypted with the item key
+  data bytea not null,
+  updated_at timestamp not null default now(),
+
+  CONSTRAINT fk_resources FOREIGN KEY(resource_id) REFERENCES resources(id) on delete cascade
+);
+
+create table resource_shares(
+  resource_id uuid not null,
+  recipient_id uuid not null,
+  -- item key wrapped for the recipient
+  wrapped_key bytea not null,
+  permission int not null,
+
+  primary key (resource_id, recipient_id),
+  CONSTRAINT fk_shared_items FOREIGN KEY(resource_id) REFERENCES shared_items(resource_id) on delete cascade,
+  CONSTRAINT fk_users FOREIGN KEY(recipient_id) REFERENCES users(id) on delete cascade
+);
+
+create table sends(
+  id uuid primary key,
+  user_id uuid not null,
+  -- encrypted by the client, the key never reaches the server
+  data bytea not null,
+  max_views int not null,
+  views int not null default 0,
+  expires_at timestamptz not null,
+
+  CONSTRAINT fk_users FOREIGN KEY(user_id) REFERENCES users(id) on delete cascade
+);
+
+create table emergency_access(
+  id uuid primary key,
+  grantor_id uuid not null,
+  trustee_id uuid not null,
+  wait_hours int not null,
+  status int not null default 0,
+  requested_at timestamptz,
+
+  unique (grantor_id, trustee_id),
+  CONSTRAINT fk_grantor FOREIGN KEY(grantor_id) REFERENCES users(id) on delete cascade,
+  CONSTRAINT fk_trustee FOREIGN KEY(trustee_id) REFERENCES users(id) on delete cascade
+);
+
+-- append-only, every event contains the hash of the previous one, so changed or deleted events break the chain
+create table audit_events(
+  seq bigserial primary key,
+  user_id uuid,
+  login varchar not null default '',
+  action smallint not null,
+  target_id uuid,
+  owner_id uuid,
+  details varchar not null default '',
+  created_at timestamptz not null,
+  prev_hash bytea,
+  hash bytea not null
+);
+
+create index audit_events_user_id on audit_events(user_id);
+create index audit_events_owner_id on audit_events(owner_id);
+
+create function audit_events_append_only() returns trigger as $$
+begin
+  raise exception 'audit log is append-only';
+end;
+$$ language plpgsql;
+
+create trigger audit_events_append_only before update or delete on audit_events
+  for each row execute function audit_events_append_only();